@@ -24,6 +24,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
@@ -32,6 +33,7 @@ type AutoBackupCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.AutoBackupClient
 
 	Info *prometheus.Desc
@@ -39,10 +41,14 @@ type AutoBackupCollector struct {
 	BackupCount    *prometheus.Desc
 	LastBackupTime *prometheus.Desc
 	BackupInfo     *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewAutoBackupCollector returns a new AutoBackupCollector.
-func NewAutoBackupCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.AutoBackupClient) *AutoBackupCollector {
+func NewAutoBackupCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.AutoBackupClient) *AutoBackupCollector {
 	errors.WithLabelValues("auto_backup").Add(0)
 
 	labels := []string{"id", "name", "disk_id"}
@@ -53,27 +59,31 @@ func NewAutoBackupCollector(ctx context.Context, logger *slog.Logger, errors *pr
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Info: prometheus.NewDesc(
+		Info: opts.newDesc(
 			"sakuracloud_auto_backup_info",
 			"A metric with a constant '1' value labeled by auto_backup information",
 			infoLabels, nil,
 		),
-		BackupCount: prometheus.NewDesc(
+		BackupCount: opts.newDesc(
 			"sakuracloud_auto_backup_count",
 			"A count of archives created by AutoBackup",
 			labels, nil,
 		),
-		LastBackupTime: prometheus.NewDesc(
+		LastBackupTime: opts.newDesc(
 			"sakuracloud_auto_backup_last_time",
 			"Last backup time in seconds since epoch (1970)",
 			labels, nil,
 		),
-		BackupInfo: prometheus.NewDesc(
+		BackupInfo: opts.newDesc(
 			"sakuracloud_auto_backup_archive_info",
 			"A metric with a constant '1' value labeled by backuped archive information",
 			backupLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("auto_backup"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("auto_backup"),
+		CountByTag:      opts.newResourceCountByTagDesc("auto_backup"),
 	}
 }
 
@@ -84,11 +94,19 @@ func (c *AutoBackupCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.BackupCount
 	ch <- c.LastBackupTime
 	ch <- c.BackupInfo
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *AutoBackupCollector) Collect(ch chan<- prometheus.Metric) {
-	autoBackups, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *AutoBackupCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	autoBackups, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("auto_backup").Add(1)
 		c.logger.Warn(
@@ -97,10 +115,19 @@ func (c *AutoBackupCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(autoBackups))
+	for _, autoBackup := range autoBackups {
+		tags = append(tags, autoBackup.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
 	var wg sync.WaitGroup
 
 	for i := range autoBackups {
 		func(autoBackup *iaas.AutoBackup) {
+			collectResourceUnnamed(ch, c.Unnamed, autoBackup.ID, autoBackup.ZoneName, autoBackup.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, autoBackup.ID, autoBackup.ZoneName, autoBackup.CreatedAt)
+
 			ch <- prometheus.MustNewConstMetric(
 				c.Info,
 				prometheus.GaugeValue,
@@ -112,7 +139,7 @@ func (c *AutoBackupCollector) Collect(ch chan<- prometheus.Metric) {
 			wg.Add(1)
 			go func() {
 				defer wg.Done()
-				c.collectBackupMetrics(ch, autoBackup, now)
+				c.collectBackupMetrics(ctx, ch, autoBackup, now)
 			}()
 		}(autoBackups[i])
 	}
@@ -123,7 +150,7 @@ func (c *AutoBackupCollector) Collect(ch chan<- prometheus.Metric) {
 func (c *AutoBackupCollector) autoBackupLabels(autoBackup *iaas.AutoBackup) []string {
 	return []string{
 		autoBackup.ID.String(),
-		autoBackup.Name,
+		c.opts.truncateLabelValue(autoBackup.Name),
 		autoBackup.DiskID.String(),
 	}
 }
@@ -134,8 +161,8 @@ func (c *AutoBackupCollector) autoBackupInfoLabels(autoBackup *iaas.AutoBackup)
 	return append(labels,
 		fmt.Sprintf("%d", autoBackup.MaximumNumberOfArchives),
 		flattenBackupSpanWeekdays(autoBackup.BackupSpanWeekdays),
-		flattenStringSlice(autoBackup.Tags),
-		autoBackup.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(autoBackup.Tags)),
+		c.opts.truncateLabelValue(autoBackup.Description),
 	)
 }
 
@@ -143,14 +170,14 @@ func (c *AutoBackupCollector) archiveInfoLabels(autoBackup *iaas.AutoBackup, arc
 	labels := c.autoBackupLabels(autoBackup)
 	return append(labels,
 		archive.ID.String(),
-		archive.Name,
-		flattenStringSlice(archive.Tags),
-		archive.Description,
+		c.opts.truncateLabelValue(archive.Name),
+		c.opts.truncateLabelValue(flattenStringSlice(archive.Tags)),
+		c.opts.truncateLabelValue(archive.Description),
 	)
 }
 
-func (c *AutoBackupCollector) collectBackupMetrics(ch chan<- prometheus.Metric, autoBackup *iaas.AutoBackup, now time.Time) {
-	archives, err := c.client.ListBackups(c.ctx, autoBackup.ZoneName, autoBackup.ID)
+func (c *AutoBackupCollector) collectBackupMetrics(ctx context.Context, ch chan<- prometheus.Metric, autoBackup *iaas.AutoBackup, now time.Time) {
+	archives, err := c.client.ListBackups(ctx, autoBackup.ZoneName, autoBackup.ID)
 	if err != nil {
 		c.errors.WithLabelValues("auto_backup").Add(1)
 		c.logger.Warn(
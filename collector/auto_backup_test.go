@@ -44,7 +44,7 @@ func (d *dummyAutoBackupClient) ListBackups(ctx context.Context, zone string, au
 
 func TestAutoBackupCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewAutoBackupCollector(context.Background(), testLogger, testErrors, &dummyAutoBackupClient{})
+	c := NewAutoBackupCollector(context.Background(), testLogger, testErrors, Options{}, &dummyAutoBackupClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -52,12 +52,15 @@ func TestAutoBackupCollector_Describe(t *testing.T) {
 		c.BackupCount,
 		c.LastBackupTime,
 		c.BackupInfo,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestAutoBackupCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewAutoBackupCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewAutoBackupCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 
 	cases := []struct {
 		name           string
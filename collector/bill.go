@@ -27,13 +27,14 @@ type BillCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.BillClient
 
 	Amount *prometheus.Desc
 }
 
 // NewBillCollector returns a new BillCollector.
-func NewBillCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.BillClient) *BillCollector {
+func NewBillCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.BillClient) *BillCollector {
 	errors.WithLabelValues("bill").Add(0)
 
 	labels := []string{"member_id"}
@@ -42,9 +43,10 @@ func NewBillCollector(ctx context.Context, logger *slog.Logger, errors *promethe
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
 
-		Amount: prometheus.NewDesc(
+		Amount: opts.newDesc(
 			"sakuracloud_bill_amount",
 			"Amount billed for the month",
 			labels, nil,
@@ -60,7 +62,12 @@ func (c *BillCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *BillCollector) Collect(ch chan<- prometheus.Metric) {
-	bill, err := c.client.Read(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *BillCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	bill, err := c.client.Read(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("bill").Add(1)
 		c.logger.Warn(
@@ -37,7 +37,7 @@ func (d *dummyBillClient) Read(ctx context.Context) (*iaas.Bill, error) {
 
 func TestBillCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewBillCollector(context.Background(), testLogger, testErrors, &dummyBillClient{})
+	c := NewBillCollector(context.Background(), testLogger, testErrors, Options{}, &dummyBillClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -47,7 +47,7 @@ func TestBillCollector_Describe(t *testing.T) {
 
 func TestBillCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewBillCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewBillCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 
 	cases := []struct {
 		name           string
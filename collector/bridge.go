@@ -0,0 +1,142 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// BridgeCollector collects metrics about all bridges.
+//
+// The API doesn't expose the traffic through a bridge, so the switches connected by the bridge are reported instead.
+type BridgeCollector struct {
+	ctx    context.Context
+	logger *slog.Logger
+	errors *prometheus.CounterVec
+	opts   Options
+	client platform.BridgeClient
+
+	BridgeInfo      *prometheus.Desc
+	SwitchCount     *prometheus.Desc
+	ZoneSwitchCount *prometheus.Desc
+}
+
+// NewBridgeCollector returns a new BridgeCollector.
+func NewBridgeCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.BridgeClient) *BridgeCollector {
+	errors.WithLabelValues("bridge").Add(0)
+
+	bridgeLabels := []string{"id", "name"}
+	bridgeInfoLabels := append(bridgeLabels, "description")
+
+	return &BridgeCollector{
+		ctx:    ctx,
+		logger: logger,
+		errors: errors,
+		opts:   opts,
+		client: client,
+		BridgeInfo: opts.newDesc(
+			"sakuracloud_bridge_info",
+			"A metric with a constant '1' value labeled by bridge information",
+			bridgeInfoLabels, nil,
+		),
+		SwitchCount: opts.newDesc(
+			"sakuracloud_bridge_switch_count",
+			"The number of switches connected by the bridge",
+			bridgeLabels, nil,
+		),
+		ZoneSwitchCount: opts.newDesc(
+			"sakuracloud_bridge_zone_switch_count",
+			"The number of switches connected by the bridge per zone",
+			append(bridgeLabels, "zone"), nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *BridgeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.BridgeInfo
+	ch <- c.SwitchCount
+	ch <- c.ZoneSwitchCount
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *BridgeCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *BridgeCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	bridges, err := c.client.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("bridge").Add(1)
+		c.logger.Warn(
+			"can't list bridges",
+			slog.Any("err", err),
+		)
+	}
+
+	for _, bridge := range bridges {
+		c.collectBridgeInfo(ch, bridge)
+		c.collectSwitchCount(ch, bridge)
+	}
+}
+
+func (c *BridgeCollector) bridgeLabels(bridge *iaas.Bridge) []string {
+	return []string{
+		bridge.ID.String(),
+		c.opts.truncateLabelValue(bridge.Name),
+	}
+}
+
+func (c *BridgeCollector) collectBridgeInfo(ch chan<- prometheus.Metric, bridge *iaas.Bridge) {
+	labels := append(c.bridgeLabels(bridge),
+		c.opts.truncateLabelValue(bridge.Description),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.BridgeInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		labels...,
+	)
+}
+
+func (c *BridgeCollector) collectSwitchCount(ch chan<- prometheus.Metric, bridge *iaas.Bridge) {
+	ch <- prometheus.MustNewConstMetric(
+		c.SwitchCount,
+		prometheus.GaugeValue,
+		float64(len(bridge.BridgeInfo)),
+		c.bridgeLabels(bridge)...,
+	)
+
+	counts := make(map[string]int)
+	for _, sw := range bridge.BridgeInfo {
+		counts[sw.ZoneName]++
+	}
+	for zone, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.ZoneSwitchCount,
+			prometheus.GaugeValue,
+			float64(count),
+			append(c.bridgeLabels(bridge), zone)...,
+		)
+	}
+}
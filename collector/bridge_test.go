@@ -0,0 +1,138 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyBridgeClient struct {
+	find    []*iaas.Bridge
+	findErr error
+}
+
+func (d *dummyBridgeClient) Find(ctx context.Context) ([]*iaas.Bridge, error) {
+	return d.find, d.findErr
+}
+
+func TestBridgeCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewBridgeCollector(context.Background(), testLogger, testErrors, Options{}, &dummyBridgeClient{})
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.BridgeInfo,
+		c.SwitchCount,
+		c.ZoneSwitchCount,
+	}))
+}
+
+func TestBridgeCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewBridgeCollector(context.Background(), testLogger, testErrors, Options{}, nil)
+
+	cases := []struct {
+		name           string
+		in             platform.BridgeClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "collector returns error",
+			in: &dummyBridgeClient{
+				findErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list bridges" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			in:          &dummyBridgeClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a bridge across two zones",
+			in: &dummyBridgeClient{
+				find: []*iaas.Bridge{
+					{
+						ID:          101,
+						Name:        "bridge",
+						Description: "desc",
+						BridgeInfo: []*iaas.BridgeInfo{
+							{ID: 201, Name: "switch1", ZoneName: "is1a"},
+							{ID: 202, Name: "switch2", ZoneName: "is1a"},
+							{ID: 203, Name: "switch3", ZoneName: "tk1a"},
+						},
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.BridgeInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "bridge",
+						"description": "desc",
+					}),
+				},
+				{
+					desc: c.SwitchCount,
+					metric: createGaugeMetric(3, map[string]string{
+						"id":   "101",
+						"name": "bridge",
+					}),
+				},
+				{
+					desc: c.ZoneSwitchCount,
+					metric: createGaugeMetric(2, map[string]string{
+						"id":   "101",
+						"name": "bridge",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.ZoneSwitchCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "bridge",
+						"zone": "tk1a",
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = tc.in
+
+		collected, err := collectMetrics(c, "bridge")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
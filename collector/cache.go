@@ -0,0 +1,104 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// CachingCollector wraps a collector and serves the cached metrics until the interval has passed
+//
+// This is intended for expensive collectors whose values rarely change, such as billing.
+type CachingCollector struct {
+	collector prometheus.Collector
+	interval  time.Duration
+	now       func() time.Time
+
+	mu          sync.Mutex
+	metrics     []prometheus.Metric
+	collectedAt time.Time
+}
+
+// NewCachingCollector returns a new CachingCollector.
+//
+// If interval is 0, the wrapped collector is called on every scrape.
+func NewCachingCollector(collector prometheus.Collector, interval time.Duration) *CachingCollector {
+	return &CachingCollector{
+		collector: collector,
+		interval:  interval,
+		now:       time.Now,
+	}
+}
+
+// Describe sends metric descriptors of the wrapped collector to the prometheus desc channel.
+func (c *CachingCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+// Collect sends the cached metrics, and refreshes them via the wrapped collector when the interval has passed.
+func (c *CachingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, c.collector.Collect)
+}
+
+// CollectWithContext is the same as Collect, but refreshes the metrics with ctx.
+func (c *CachingCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	c.collect(ch, collectFunc(ctx, c.collector))
+}
+
+func (c *CachingCollector) collect(ch chan<- prometheus.Metric, collect func(chan<- prometheus.Metric)) {
+	if c.interval <= 0 {
+		collect(ch)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if c.collectedAt.IsZero() || now.Sub(c.collectedAt) >= c.interval {
+		c.metrics = c.refresh(collect)
+		c.collectedAt = now
+	}
+	for _, m := range c.metrics {
+		ch <- m
+	}
+}
+
+// Reset discards the cached metrics, so that the wrapped collector is called on the next scrape.
+func (c *CachingCollector) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.metrics = nil
+	c.collectedAt = time.Time{}
+}
+
+func (c *CachingCollector) refresh(collect func(chan<- prometheus.Metric)) []prometheus.Metric {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collect(metrics)
+		close(metrics)
+	}()
+
+	var collected []prometheus.Metric
+	for m := range metrics {
+		collected = append(collected, m)
+	}
+	return collected
+}
@@ -0,0 +1,106 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+type countingBillClient struct {
+	dummyBillClient
+	called int
+}
+
+func (d *countingBillClient) Read(ctx context.Context) (*iaas.Bill, error) {
+	d.called++
+	return d.dummyBillClient.Read(ctx)
+}
+
+func TestCachingCollector_Collect(t *testing.T) {
+	client := &countingBillClient{
+		dummyBillClient: dummyBillClient{
+			bill: &iaas.Bill{ID: 101, Amount: 1000, Date: time.Now()},
+		},
+	}
+
+	initLoggerAndErrors()
+	c := NewCachingCollector(NewBillCollector(context.Background(), testLogger, testErrors, Options{}, client), 5*time.Minute)
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	require.Len(t, collectDescs(c), 1)
+
+	collected, err := collectMetrics(c, "bill")
+	require.NoError(t, err)
+	require.Len(t, collected.collected, 1)
+	require.Equal(t, 1, client.called)
+
+	// within the interval, the cached result is served
+	now = now.Add(4 * time.Minute)
+	collected, err = collectMetrics(c, "bill")
+	require.NoError(t, err)
+	require.Len(t, collected.collected, 1)
+	require.Equal(t, 1, client.called)
+
+	// the interval has passed
+	now = now.Add(time.Minute)
+	collected, err = collectMetrics(c, "bill")
+	require.NoError(t, err)
+	require.Len(t, collected.collected, 1)
+	require.Equal(t, 2, client.called)
+}
+
+func TestCachingCollector_Collect_NoInterval(t *testing.T) {
+	client := &countingBillClient{
+		dummyBillClient: dummyBillClient{
+			bill: &iaas.Bill{ID: 101, Amount: 1000, Date: time.Now()},
+		},
+	}
+
+	initLoggerAndErrors()
+	c := NewCachingCollector(NewBillCollector(context.Background(), testLogger, testErrors, Options{}, client), 0)
+
+	for i := 0; i < 2; i++ {
+		_, err := collectMetrics(c, "bill")
+		require.NoError(t, err)
+	}
+	require.Equal(t, 2, client.called)
+}
+
+func TestCachingCollector_Reset(t *testing.T) {
+	client := &countingBillClient{
+		dummyBillClient: dummyBillClient{
+			bill: &iaas.Bill{ID: 101, Amount: 1000, Date: time.Now()},
+		},
+	}
+
+	initLoggerAndErrors()
+	c := NewCachingCollector(NewBillCollector(context.Background(), testLogger, testErrors, Options{}, client), 5*time.Minute)
+
+	_, err := collectMetrics(c, "bill")
+	require.NoError(t, err)
+	require.Equal(t, 1, client.called)
+
+	// the cached result is discarded even within the interval
+	c.Reset()
+	_, err = collectMetrics(c, "bill")
+	require.NoError(t, err)
+	require.Equal(t, 2, client.called)
+}
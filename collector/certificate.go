@@ -0,0 +1,249 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// CertificateCollector collects the expiration of certificates managed by multiple products.
+type CertificateCollector struct {
+	ctx            context.Context
+	logger         *slog.Logger
+	errors         *prometheus.CounterVec
+	opts           Options
+	proxyLBClient  platform.ProxyLBClient
+	webAccelClient platform.WebAccelClient
+	caClient       platform.CertificateAuthorityClient
+
+	ExpireTimestamp *prometheus.Desc
+}
+
+// NewCertificateCollector returns a new CertificateCollector.
+//
+// Each client can be nil, and the certificates of the product are not collected in that case.
+// cert_index distinguishes the certificates of a resource, since they can share the common name
+// or have an empty one if the certificate can't be parsed.
+func NewCertificateCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, proxyLBClient platform.ProxyLBClient, webAccelClient platform.WebAccelClient, caClient platform.CertificateAuthorityClient) *CertificateCollector {
+	errors.WithLabelValues("certificate").Add(0)
+
+	return &CertificateCollector{
+		ctx:            ctx,
+		logger:         logger,
+		errors:         errors,
+		opts:           opts,
+		proxyLBClient:  proxyLBClient,
+		webAccelClient: webAccelClient,
+		caClient:       caClient,
+		ExpireTimestamp: opts.newDesc(
+			"sakuracloud_certificate_expire_timestamp",
+			"Certificate expiration date in seconds since epoch (1970)",
+			[]string{"product", "resource_id", "cert_index", "common_name"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *CertificateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ExpireTimestamp
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *CertificateCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *CertificateCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	var wg sync.WaitGroup
+	if c.proxyLBClient != nil {
+		wg.Add(1)
+		go func() {
+			c.collectProxyLBCerts(ctx, ch)
+			wg.Done()
+		}()
+	}
+	if c.webAccelClient != nil {
+		wg.Add(1)
+		go func() {
+			c.collectWebAccelCerts(ctx, ch)
+			wg.Done()
+		}()
+	}
+	if c.caClient != nil {
+		wg.Add(1)
+		go func() {
+			c.collectCertificateAuthorityCerts(ctx, ch)
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+func (c *CertificateCollector) collectProxyLBCerts(ctx context.Context, ch chan<- prometheus.Metric) {
+	proxyLBs, err := c.proxyLBClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("certificate").Add(1)
+		c.logger.Warn(
+			"can't list proxyLBs",
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(proxyLBs))
+	for i := range proxyLBs {
+		go func(proxyLB *iaas.ProxyLB) {
+			defer wg.Done()
+
+			certs, err := c.proxyLBClient.GetCertificate(ctx, proxyLB.ID)
+			if err != nil {
+				c.errors.WithLabelValues("certificate").Add(1)
+				c.logger.Warn(
+					fmt.Sprintf("can't get certificate: proxyLB=%d", proxyLB.ID),
+					slog.Any("err", err),
+				)
+				return
+			}
+			if certs == nil {
+				return
+			}
+
+			if certs.PrimaryCert != nil && certs.PrimaryCert.PrivateKey != "" && certs.PrimaryCert.ServerCertificate != "" {
+				commonName, _, _ := parseServerCertificate(certs.PrimaryCert.ServerCertificate)
+				ch <- prometheus.MustNewConstMetric(
+					c.ExpireTimestamp,
+					prometheus.GaugeValue,
+					float64(certs.PrimaryCert.CertificateEndDate.Unix()),
+					"proxylb", proxyLB.ID.String(), "0", commonName,
+				)
+			}
+			for i, cert := range certs.AdditionalCerts {
+				commonName, _, _ := parseServerCertificate(cert.ServerCertificate)
+				ch <- prometheus.MustNewConstMetric(
+					c.ExpireTimestamp,
+					prometheus.GaugeValue,
+					float64(cert.CertificateEndDate.Unix()),
+					"proxylb", proxyLB.ID.String(), fmt.Sprintf("%d", i+1), commonName,
+				)
+			}
+		}(proxyLBs[i])
+	}
+	wg.Wait()
+}
+
+func (c *CertificateCollector) collectWebAccelCerts(ctx context.Context, ch chan<- prometheus.Metric) {
+	sites, err := c.webAccelClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("certificate").Add(1)
+		c.logger.Warn(
+			"can't get webAccel info",
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	for _, site := range sites {
+		if !site.HasCertificate {
+			continue
+		}
+		// the webaccel API doesn't return the certificate itself, so the site's domain is used as the common name.
+		// CertValidNotAfter is in milliseconds, same as webaccel_cert_expire.
+		ch <- prometheus.MustNewConstMetric(
+			c.ExpireTimestamp,
+			prometheus.GaugeValue,
+			float64(site.CertValidNotAfter)/1000,
+			"webaccel", site.ID, "0", site.Domain,
+		)
+	}
+}
+
+func (c *CertificateCollector) collectCertificateAuthorityCerts(ctx context.Context, ch chan<- prometheus.Metric) {
+	cas, err := c.caClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("certificate").Add(1)
+		c.logger.Warn(
+			"can't list certificate authorities",
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(len(cas))
+	for i := range cas {
+		go func(ca *iaas.CertificateAuthority) {
+			defer wg.Done()
+
+			// the certificate of the CA itself
+			ch <- prometheus.MustNewConstMetric(
+				c.ExpireTimestamp,
+				prometheus.GaugeValue,
+				float64(ca.NotAfter.Unix()),
+				"certificate_authority", ca.ID.String(), "0", ca.CommonName,
+			)
+
+			servers, err := c.caClient.ListServers(ctx, ca.ID)
+			if err != nil {
+				c.errors.WithLabelValues("certificate").Add(1)
+				c.logger.Warn(
+					fmt.Sprintf("can't list server certificates: certificateAuthority=%d", ca.ID),
+					slog.Any("err", err),
+				)
+				return
+			}
+			for i, server := range servers {
+				if server.CertificateData == nil {
+					// not issued yet
+					continue
+				}
+				commonName, _, _ := parseServerCertificate(server.CertificateData.CertificatePEM)
+				ch <- prometheus.MustNewConstMetric(
+					c.ExpireTimestamp,
+					prometheus.GaugeValue,
+					float64(server.CertificateData.NotAfter.Unix()),
+					"certificate_authority", ca.ID.String(), fmt.Sprintf("%d", i+1), commonName,
+				)
+			}
+		}(cas[i])
+	}
+	wg.Wait()
+}
+
+// parseServerCertificate returns the common name, the issuer's common name and the SANs of the PEM encoded certificate
+//
+// Empty values are returned if the certificate can't be parsed.
+func parseServerCertificate(serverCertificate string) (commonName, issuerName string, sans []string) {
+	block, _ := pem.Decode([]byte(serverCertificate))
+	if block == nil {
+		return "", "", nil
+	}
+	cert, err := x509.ParseCertificate(block.Bytes) // ignore err
+	if err != nil {
+		return "", "", nil
+	}
+	return cert.Subject.CommonName, cert.Issuer.CommonName, cert.DNSNames
+}
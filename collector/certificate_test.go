@@ -0,0 +1,292 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/webaccel-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyCertificateAuthorityClient struct {
+	find       []*iaas.CertificateAuthority
+	findErr    error
+	servers    []*iaas.CertificateAuthorityServer
+	serversErr error
+}
+
+func (d *dummyCertificateAuthorityClient) Find(ctx context.Context) ([]*iaas.CertificateAuthority, error) {
+	return d.find, d.findErr
+}
+
+func (d *dummyCertificateAuthorityClient) ListServers(ctx context.Context, id types.ID) ([]*iaas.CertificateAuthorityServer, error) {
+	return d.servers, d.serversErr
+}
+
+func TestCertificateCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewCertificateCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{}, &dummyWebAccelClient{}, &dummyCertificateAuthorityClient{})
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.ExpireTimestamp,
+	}))
+}
+
+func TestCertificateCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewCertificateCollector(context.Background(), testLogger, testErrors, Options{}, nil, nil, nil)
+
+	cases := []struct {
+		name           string
+		proxyLB        *dummyProxyLBClient
+		webAccel       *dummyWebAccelClient
+		ca             *dummyCertificateAuthorityClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name:           "collector returns error",
+			proxyLB:        &dummyProxyLBClient{findErr: errors.New("dummy1")},
+			webAccel:       &dummyWebAccelClient{err: errors.New("dummy2")},
+			ca:             &dummyCertificateAuthorityClient{findErr: errors.New("dummy3")},
+			wantLogs:       []string{`level=WARN msg="can't get webAccel info" err=dummy2`, `level=WARN msg="can't list proxyLBs" err=dummy1`, `level=WARN msg="can't list certificate authorities" err=dummy3`},
+			wantErrCounter: 3,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			proxyLB:     &dummyProxyLBClient{},
+			webAccel:    &dummyWebAccelClient{},
+			ca:          &dummyCertificateAuthorityClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a proxyLB and a webaccel site with certificates",
+			proxyLB: &dummyProxyLBClient{
+				find: []*iaas.ProxyLB{{ID: 101, Name: "proxylb"}},
+				cert: &iaas.ProxyLBCertificates{
+					PrimaryCert: &iaas.ProxyLBPrimaryCert{
+						ServerCertificate:  proxyLBMultiSANCert,
+						PrivateKey:         "dummy",
+						CertificateEndDate: time.Unix(1700000000, 0),
+					},
+				},
+			},
+			webAccel: &dummyWebAccelClient{
+				sites: []*webaccel.Site{
+					{
+						ID:                "201",
+						Domain:            "www.example.com",
+						HasCertificate:    true,
+						CertValidNotAfter: 1800000000000,
+					},
+					{
+						ID:     "202",
+						Domain: "www2.example.com",
+					},
+				},
+			},
+			ca: &dummyCertificateAuthorityClient{},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1700000000, map[string]string{
+						"product":     "proxylb",
+						"resource_id": "101",
+						"cert_index":  "0",
+						"common_name": "example.com",
+					}),
+				},
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1800000000, map[string]string{
+						"product":     "webaccel",
+						"resource_id": "201",
+						"cert_index":  "0",
+						"common_name": "www.example.com",
+					}),
+				},
+			},
+		},
+		{
+			name: "certificates sharing the common name or failed to parse",
+			proxyLB: &dummyProxyLBClient{
+				find: []*iaas.ProxyLB{{ID: 101, Name: "proxylb"}},
+				cert: &iaas.ProxyLBCertificates{
+					PrimaryCert: &iaas.ProxyLBPrimaryCert{
+						ServerCertificate:  proxyLBMultiSANCert,
+						PrivateKey:         "dummy",
+						CertificateEndDate: time.Unix(1700000000, 0),
+					},
+					AdditionalCerts: []*iaas.ProxyLBAdditionalCert{
+						{
+							ServerCertificate:  proxyLBMultiSANCert,
+							CertificateEndDate: time.Unix(1710000000, 0),
+						},
+						{
+							ServerCertificate:  "invalid",
+							CertificateEndDate: time.Unix(1720000000, 0),
+						},
+						{
+							ServerCertificate:  "invalid",
+							CertificateEndDate: time.Unix(1730000000, 0),
+						},
+					},
+				},
+			},
+			webAccel: &dummyWebAccelClient{},
+			ca:       &dummyCertificateAuthorityClient{},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1700000000, map[string]string{
+						"product":     "proxylb",
+						"resource_id": "101",
+						"cert_index":  "0",
+						"common_name": "example.com",
+					}),
+				},
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1710000000, map[string]string{
+						"product":     "proxylb",
+						"resource_id": "101",
+						"cert_index":  "1",
+						"common_name": "example.com",
+					}),
+				},
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1720000000, map[string]string{
+						"product":     "proxylb",
+						"resource_id": "101",
+						"cert_index":  "2",
+						"common_name": "",
+					}),
+				},
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1730000000, map[string]string{
+						"product":     "proxylb",
+						"resource_id": "101",
+						"cert_index":  "3",
+						"common_name": "",
+					}),
+				},
+			},
+		},
+		{
+			name:     "a certificate authority with server certificates",
+			proxyLB:  &dummyProxyLBClient{},
+			webAccel: &dummyWebAccelClient{},
+			ca: &dummyCertificateAuthorityClient{
+				find: []*iaas.CertificateAuthority{
+					{
+						ID:         301,
+						Name:       "ca",
+						CommonName: "ca.example.com",
+						NotAfter:   time.Unix(1900000000, 0),
+					},
+				},
+				servers: []*iaas.CertificateAuthorityServer{
+					{
+						ID: "server1",
+						CertificateData: &iaas.CertificateData{
+							CertificatePEM: proxyLBMultiSANCert,
+							NotAfter:       time.Unix(1750000000, 0),
+						},
+					},
+					{
+						// not issued yet
+						ID: "server2",
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1900000000, map[string]string{
+						"product":     "certificate_authority",
+						"resource_id": "301",
+						"cert_index":  "0",
+						"common_name": "ca.example.com",
+					}),
+				},
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1750000000, map[string]string{
+						"product":     "certificate_authority",
+						"resource_id": "301",
+						"cert_index":  "1",
+						"common_name": "example.com",
+					}),
+				},
+			},
+		},
+		{
+			name:     "listing server certificates returns error",
+			proxyLB:  &dummyProxyLBClient{},
+			webAccel: &dummyWebAccelClient{},
+			ca: &dummyCertificateAuthorityClient{
+				find: []*iaas.CertificateAuthority{
+					{
+						ID:         301,
+						Name:       "ca",
+						CommonName: "ca.example.com",
+						NotAfter:   time.Unix(1900000000, 0),
+					},
+				},
+				serversErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list server certificates: certificateAuthority=301" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.ExpireTimestamp,
+					metric: createGaugeMetric(1900000000, map[string]string{
+						"product":     "certificate_authority",
+						"resource_id": "301",
+						"cert_index":  "0",
+						"common_name": "ca.example.com",
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.proxyLBClient = tc.proxyLB
+		c.webAccelClient = tc.webAccel
+		c.caClient = tc.ca
+
+		collected, err := collectMetrics(c, "certificate")
+		require.NoError(t, err)
+		require.ElementsMatch(t, tc.wantLogs, collected.logged, tc.name)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value, tc.name)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
@@ -149,6 +150,35 @@ func collectMetrics(collector prometheus.Collector, errLabel string) (*collectRe
 	}, nil
 }
 
+// filter returns the collected metrics of the descs
+func (r *collectResult) filter(descs ...*prometheus.Desc) []*collectedMetric {
+	var res []*collectedMetric
+	for _, m := range r.collected {
+		if slices.Contains(descs, m.desc) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// metrics returns the dto.Metric of the collected metrics of the descs
+func (r *collectResult) metrics(descs ...*prometheus.Desc) []*dto.Metric {
+	var res []*dto.Metric
+	for _, m := range r.filter(descs...) {
+		res = append(res, m.metric)
+	}
+	return res
+}
+
+// values returns the gauge values of the collected metrics of the descs
+func (r *collectResult) values(descs ...*prometheus.Desc) []float64 {
+	var res []float64
+	for _, m := range r.filter(descs...) {
+		res = append(res, m.metric.GetGauge().GetValue())
+	}
+	return res
+}
+
 func createGaugeMetric(value float64, labels map[string]string) *dto.Metric {
 	metric := &dto.Metric{
 		Gauge: &dto.Gauge{
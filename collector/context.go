@@ -0,0 +1,124 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ContextCollector is a prometheus.Collector which can collect metrics with the context of each scrape
+type ContextCollector interface {
+	prometheus.Collector
+
+	// CollectWithContext is the same as Collect, but the API calls are issued with ctx
+	CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric)
+}
+
+// collectFunc returns the function which collects the metrics of collector with ctx.
+// If collector is not a ContextCollector, ctx is ignored.
+func collectFunc(ctx context.Context, collector prometheus.Collector) func(chan<- prometheus.Metric) {
+	if c, ok := collector.(ContextCollector); ok {
+		return func(ch chan<- prometheus.Metric) {
+			c.CollectWithContext(ctx, ch)
+		}
+	}
+	return collector.Collect
+}
+
+// ContextRegistry holds the collectors which collect metrics with the context of each scrape request.
+//
+// prometheus.Collector can't receive the context of the scrape request,
+// so the collectors are registered to a new registry bound to the context per scrape.
+type ContextRegistry struct {
+	mu            sync.Mutex
+	registrations []contextRegistration
+
+	// checked detects invalid or duplicate collectors on Register rather than on each scrape
+	checked *prometheus.Registry
+}
+
+type contextRegistration struct {
+	labels    prometheus.Labels
+	collector prometheus.Collector
+}
+
+// NewContextRegistry returns a new ContextRegistry.
+func NewContextRegistry() *ContextRegistry {
+	return &ContextRegistry{
+		checked: prometheus.NewRegistry(),
+	}
+}
+
+// Register adds the collector whose metrics are labeled with labels.
+func (r *ContextRegistry) Register(labels prometheus.Labels, collector prometheus.Collector) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := prometheus.WrapRegistererWith(labels, r.checked).Register(collector); err != nil {
+		return err
+	}
+	r.registrations = append(r.registrations, contextRegistration{labels: labels, collector: collector})
+	return nil
+}
+
+// MustRegister is the same as Register, but panics on error.
+func (r *ContextRegistry) MustRegister(labels prometheus.Labels, collector prometheus.Collector) {
+	if err := r.Register(labels, collector); err != nil {
+		panic(err)
+	}
+}
+
+// Describe sends the descriptors of the registered collectors to the prometheus desc channel.
+func (r *ContextRegistry) Describe(ch chan<- *prometheus.Desc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, registration := range r.registrations {
+		registration.collector.Describe(ch)
+	}
+}
+
+// Gatherer returns the gatherer which collects the metrics of the registered collectors with ctx.
+func (r *ContextRegistry) Gatherer(ctx context.Context) prometheus.Gatherer {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registry := prometheus.NewRegistry()
+	for _, registration := range r.registrations {
+		// the collectors have been checked on Register
+		prometheus.WrapRegistererWith(registration.labels, registry).MustRegister(&contextBoundCollector{
+			ctx:       ctx,
+			collector: registration.collector,
+		})
+	}
+	return registry
+}
+
+// contextBoundCollector collects the metrics of the wrapped collector with ctx
+type contextBoundCollector struct {
+	ctx       context.Context
+	collector prometheus.Collector
+}
+
+func (c *contextBoundCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+func (c *contextBoundCollector) Collect(ch chan<- prometheus.Metric) {
+	collectFunc(c.ctx, c.collector)(ch)
+}
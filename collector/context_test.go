@@ -0,0 +1,75 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type requestIDRecordingBillClient struct {
+	dummyBillClient
+	requestIDs []string
+}
+
+func (d *requestIDRecordingBillClient) Read(ctx context.Context) (*iaas.Bill, error) {
+	d.requestIDs = append(d.requestIDs, platform.RequestIDFromContext(ctx))
+	return d.dummyBillClient.Read(ctx)
+}
+
+func TestContextRegistry_Gatherer(t *testing.T) {
+	client := &requestIDRecordingBillClient{
+		dummyBillClient: dummyBillClient{
+			bill: &iaas.Bill{ID: 101, Amount: 1000, Date: time.Now()},
+		},
+	}
+
+	initLoggerAndErrors()
+	bill := NewBillCollector(context.Background(), testLogger, testErrors, Options{}, client)
+	// the context is passed through the wrappers used by main
+	wrapped := NewInstrumentation(0).Wrap("bill", NewSkipMetricsCollector(NewDeltaCollector(NewCachingCollector(bill, 0)), nil))
+
+	r := NewContextRegistry()
+	require.NoError(t, r.Register(prometheus.Labels{"source": "primary"}, wrapped))
+	// the same collector with the same labels is rejected on Register
+	require.Error(t, r.Register(prometheus.Labels{"source": "primary"}, NewBillCollector(context.Background(), testLogger, testErrors, Options{}, client)))
+
+	for _, id := range []string{"first", "second"} {
+		families, err := r.Gatherer(platform.WithRequestID(context.Background(), id)).Gather()
+		require.NoError(t, err)
+		require.NotEmpty(t, families)
+		for _, family := range families {
+			for _, m := range family.GetMetric() {
+				labels := make(map[string]string)
+				for _, label := range m.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				require.Equal(t, "primary", labels["source"], family.GetName())
+			}
+		}
+	}
+	require.Equal(t, []string{"first", "second"}, client.requestIDs)
+
+	// Collect uses the context passed to the constructor
+	_, err := collectMetrics(wrapped, "bill")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second", ""}, client.requestIDs)
+}
@@ -29,6 +29,7 @@ type CouponCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.CouponClient
 
 	Discount      *prometheus.Desc
@@ -38,7 +39,7 @@ type CouponCollector struct {
 }
 
 // NewCouponCollector returns a new CouponCollector.
-func NewCouponCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.CouponClient) *CouponCollector {
+func NewCouponCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.CouponClient) *CouponCollector {
 	errors.WithLabelValues("coupon").Add(0)
 
 	labels := []string{"id", "member_id", "contract_id"}
@@ -47,24 +48,25 @@ func NewCouponCollector(ctx context.Context, logger *slog.Logger, errors *promet
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
 
-		Discount: prometheus.NewDesc(
+		Discount: opts.newDesc(
 			"sakuracloud_coupon_discount",
 			"The balance of coupon",
 			labels, nil,
 		),
-		RemainingDays: prometheus.NewDesc(
+		RemainingDays: opts.newDesc(
 			"sakuracloud_coupon_remaining_days",
 			"The count of coupon's remaining days",
 			labels, nil,
 		),
-		ExpDate: prometheus.NewDesc(
+		ExpDate: opts.newDesc(
 			"sakuracloud_coupon_exp_date",
 			"Coupon expiration date in seconds since epoch (1970)",
 			labels, nil,
 		),
-		Usable: prometheus.NewDesc(
+		Usable: opts.newDesc(
 			"sakuracloud_coupon_usable",
 			"1 if your coupon is usable",
 			labels, nil,
@@ -83,7 +85,12 @@ func (c *CouponCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *CouponCollector) Collect(ch chan<- prometheus.Metric) {
-	coupons, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *CouponCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	coupons, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("coupon").Add(1)
 		c.logger.Warn(
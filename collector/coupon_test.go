@@ -37,7 +37,7 @@ func (d *dummyCouponClient) Find(ctx context.Context) ([]*iaas.Coupon, error) {
 
 func TestCouponCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewCouponCollector(context.Background(), testLogger, testErrors, &dummyCouponClient{})
+	c := NewCouponCollector(context.Background(), testLogger, testErrors, Options{}, &dummyCouponClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -50,7 +50,7 @@ func TestCouponCollector_Describe(t *testing.T) {
 
 func TestCouponCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewCouponCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewCouponCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	untilAt := time.Now().Add(time.Hour * 24 * 3).Add(time.Hour)
 
 	cases := []struct {
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/packages-go/newsfeed"
 	"github.com/sacloud/sakuracloud_exporter/platform"
@@ -32,13 +33,18 @@ type DatabaseCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.DatabaseClient
 
+	binlogs *databaseBinlogTracker
+
 	Up               *prometheus.Desc
 	DatabaseInfo     *prometheus.Desc
+	CPUs             *prometheus.Desc
 	CPUTime          *prometheus.Desc
 	MemoryUsed       *prometheus.Desc
 	MemoryTotal      *prometheus.Desc
+	MemoryUtil       *prometheus.Desc
 	NICInfo          *prometheus.Desc
 	NICReceive       *prometheus.Desc
 	NICSend          *prometheus.Desc
@@ -46,19 +52,28 @@ type DatabaseCollector struct {
 	SystemDiskTotal  *prometheus.Desc
 	BackupDiskUsed   *prometheus.Desc
 	BackupDiskTotal  *prometheus.Desc
+	SystemDiskUtil   *prometheus.Desc
+	BackupDiskUtil   *prometheus.Desc
 	BinlogUsed       *prometheus.Desc
+	BinlogGrowth     *prometheus.Desc
 	DiskRead         *prometheus.Desc
 	DiskWrite        *prometheus.Desc
 	ReplicationDelay *prometheus.Desc
 
+	MonitorSampleTimestamp *prometheus.Desc
+
 	MaintenanceScheduled *prometheus.Desc
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewDatabaseCollector returns a new DatabaseCollector.
-func NewDatabaseCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.DatabaseClient) *DatabaseCollector {
+func NewDatabaseCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.DatabaseClient) *DatabaseCollector {
 	errors.WithLabelValues("database").Add(0)
 
 	databaseLabels := []string{"id", "name", "zone"}
@@ -73,107 +88,144 @@ func NewDatabaseCollector(ctx context.Context, logger *slog.Logger, errors *prom
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+
+		binlogs: newDatabaseBinlogTracker(),
+
+		Up: opts.newDesc(
 			"sakuracloud_database_up",
 			"If 1 the database is up and running, 0 otherwise",
 			databaseLabels, nil,
 		),
-		DatabaseInfo: prometheus.NewDesc(
+		DatabaseInfo: opts.newDesc(
 			"sakuracloud_database_info",
 			"A metric with a constant '1' value labeled by database information",
-			databaseInfoLabels, nil,
+			opts.withRegionLabel(databaseInfoLabels), nil,
 		),
-		CPUTime: prometheus.NewDesc(
+		CPUs: opts.newDesc(
+			"sakuracloud_database_cpus",
+			"Number of database's vCPU cores",
+			databaseLabels, nil,
+		),
+		CPUTime: opts.newDesc(
 			"sakuracloud_database_cpu_time",
 			"Database's CPU time(unit:ms)",
 			databaseLabels, nil,
 		),
-		MemoryUsed: prometheus.NewDesc(
+		MemoryUsed: opts.newDesc(
 			"sakuracloud_database_memory_used",
 			"Database's used memory size(unit:GB)",
 			databaseLabels, nil,
 		),
-		MemoryTotal: prometheus.NewDesc(
+		MemoryTotal: opts.newDesc(
 			"sakuracloud_database_memory_total",
 			"Database's total memory size(unit:GB)",
 			databaseLabels, nil,
 		),
-		NICInfo: prometheus.NewDesc(
+		MemoryUtil: opts.newDesc(
+			"sakuracloud_database_memory_utilization",
+			"Ratio of database's used memory size to the total memory size",
+			databaseLabels, nil,
+		),
+		NICInfo: opts.newDesc(
 			"sakuracloud_database_nic_info",
 			"A metric with a constant '1' value labeled by nic information",
 			nicInfoLabels, nil,
 		),
-		NICReceive: prometheus.NewDesc(
+		NICReceive: opts.newNetworkTrafficDesc(
 			"sakuracloud_database_nic_receive",
-			"NIC's receive bytes(unit: Kbps)",
+			"NIC's receive traffic(unit: %s)",
 			databaseLabels, nil,
 		),
-		NICSend: prometheus.NewDesc(
+		NICSend: opts.newNetworkTrafficDesc(
 			"sakuracloud_database_nic_send",
-			"NIC's send bytes(unit: Kbps)",
+			"NIC's send traffic(unit: %s)",
 			databaseLabels, nil,
 		),
-		SystemDiskUsed: prometheus.NewDesc(
+		MonitorSampleTimestamp: opts.newDesc(
+			"sakuracloud_database_monitor_sample_timestamp_seconds",
+			"Time of the latest CPU-TIME sample returned by the monitor API in seconds since epoch (1970)",
+			databaseLabels, nil,
+		),
+		SystemDiskUsed: opts.newDesc(
 			"sakuracloud_database_disk_system_used",
 			"Database's used system-disk size(unit:GB)",
 			databaseLabels, nil,
 		),
-		SystemDiskTotal: prometheus.NewDesc(
+		SystemDiskTotal: opts.newDesc(
 			"sakuracloud_database_disk_system_total",
 			"Database's total system-disk size(unit:GB)",
 			databaseLabels, nil,
 		),
-		BackupDiskUsed: prometheus.NewDesc(
+		BackupDiskUsed: opts.newDesc(
 			"sakuracloud_database_disk_backup_used",
 			"Database's used backup-disk size(unit:GB)",
 			databaseLabels, nil,
 		),
-		BackupDiskTotal: prometheus.NewDesc(
+		BackupDiskTotal: opts.newDesc(
 			"sakuracloud_database_disk_backup_total",
 			"Database's total backup-disk size(unit:GB)",
 			databaseLabels, nil,
 		),
-		BinlogUsed: prometheus.NewDesc(
+		SystemDiskUtil: opts.newDesc(
+			"sakuracloud_database_disk_system_utilization",
+			"Ratio of database's used system-disk size to the total system-disk size",
+			databaseLabels, nil,
+		),
+		BackupDiskUtil: opts.newDesc(
+			"sakuracloud_database_disk_backup_utilization",
+			"Ratio of database's used backup-disk size to the total backup-disk size",
+			databaseLabels, nil,
+		),
+		BinlogUsed: opts.newDesc(
 			"sakuracloud_database_binlog_used",
 			"Database's used binlog size(unit:GB)",
 			databaseLabels, nil,
 		),
-		DiskRead: prometheus.NewDesc(
+		BinlogGrowth: opts.newDesc(
+			"sakuracloud_database_binlog_growth_bytes_per_sec",
+			"Growth rate of database's used binlog size computed from consecutive samples(unit: bytes/sec)",
+			databaseLabels, nil,
+		),
+		DiskRead: opts.newDesc(
 			"sakuracloud_database_disk_read",
 			"Disk's read bytes(unit: KBps)",
 			databaseLabels, nil,
 		),
-		DiskWrite: prometheus.NewDesc(
+		DiskWrite: opts.newDesc(
 			"sakuracloud_database_disk_write",
 			"Disk's write bytes(unit: KBps)",
 			databaseLabels, nil,
 		),
-		ReplicationDelay: prometheus.NewDesc(
+		ReplicationDelay: opts.newDesc(
 			"sakuracloud_database_replication_delay",
 			"Replication delay time(unit:second)",
 			databaseLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_database_maintenance_scheduled",
 			"If 1 the database has scheduled maintenance info, 0 otherwise",
 			databaseLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_database_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			append(databaseLabels, "info_url", "info_title", "description", "start_date", "end_date"), nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_database_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			databaseLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_database_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			databaseLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("database"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("database"),
+		CountByTag:      opts.newResourceCountByTagDesc("database"),
 	}
 }
 
@@ -182,9 +234,11 @@ func NewDatabaseCollector(ctx context.Context, logger *slog.Logger, errors *prom
 func (c *DatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
 	ch <- c.DatabaseInfo
+	ch <- c.CPUs
 	ch <- c.CPUTime
 	ch <- c.MemoryUsed
 	ch <- c.MemoryTotal
+	ch <- c.MemoryUtil
 	ch <- c.NICInfo
 	ch <- c.NICReceive
 	ch <- c.NICSend
@@ -192,27 +246,50 @@ func (c *DatabaseCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.SystemDiskTotal
 	ch <- c.BackupDiskUsed
 	ch <- c.BackupDiskTotal
+	ch <- c.SystemDiskUtil
+	ch <- c.BackupDiskUtil
 	ch <- c.BinlogUsed
+	ch <- c.BinlogGrowth
 	ch <- c.DiskRead
 	ch <- c.DiskWrite
 	ch <- c.ReplicationDelay
 
+	ch <- c.MonitorSampleTimestamp
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
-	databases, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *DatabaseCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	databases, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
 		c.logger.Warn(
 			"can't list databases",
 			slog.Any("err", err),
 		)
+	} else {
+		c.binlogs.retain(databases)
+	}
+
+	tags := make([]types.Tags, 0, len(databases))
+	for _, database := range databases {
+		tags = append(tags, database.Tags)
 	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	// system info, cpu-time, disk, NICs and maintenance info for each database
+	c.opts.warnGoroutines(c.logger, "database", len(databases)*5)
 
 	var wg sync.WaitGroup
 	wg.Add(len(databases))
@@ -222,6 +299,8 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			databaseLabels := c.databaseLabels(database)
+			collectResourceUnnamed(ch, c.Unnamed, database.ID, database.ZoneName, database.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, database.ID, database.ZoneName, database.CreatedAt)
 
 			var up float64
 			if database.InstanceStatus.IsUp() {
@@ -237,8 +316,16 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 				c.DatabaseInfo,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.databaseInfoLabels(database)...,
+				c.opts.withRegionLabelValue(c.databaseInfoLabels(database), database.ZoneName)...,
 			)
+			if cpus, ok := databasePlanCPUs[database.PlanID]; ok {
+				ch <- prometheus.MustNewConstMetric(
+					c.CPUs,
+					prometheus.GaugeValue,
+					float64(cpus),
+					databaseLabels...,
+				)
+			}
 			ch <- prometheus.MustNewConstMetric(
 				c.NICInfo,
 				prometheus.GaugeValue,
@@ -252,28 +339,28 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 				// system info
 				wg.Add(1)
 				go func() {
-					c.collectDatabaseMetrics(ch, database, now)
+					c.collectDatabaseMetrics(ctx, ch, database, now)
 					wg.Done()
 				}()
 
 				// cpu-time
 				wg.Add(1)
 				go func() {
-					c.collectCPUTime(ch, database, now)
+					c.collectCPUTime(ctx, ch, database, now)
 					wg.Done()
 				}()
 
 				// Disk read/write
 				wg.Add(1)
 				go func() {
-					c.collectDiskMetrics(ch, database, now)
+					c.collectDiskMetrics(ctx, ch, database, now)
 					wg.Done()
 				}()
 
 				// NICs
 				wg.Add(1)
 				go func() {
-					c.collectNICMetrics(ch, database, now)
+					c.collectNICMetrics(ctx, ch, database, now)
 					wg.Done()
 				}()
 
@@ -303,7 +390,7 @@ func (c *DatabaseCollector) Collect(ch chan<- prometheus.Metric) {
 func (c *DatabaseCollector) databaseLabels(database *platform.Database) []string {
 	return []string{
 		database.ID.String(),
-		database.Name,
+		c.opts.truncateLabelValue(database.Name),
 		database.ZoneName,
 	}
 }
@@ -317,6 +404,15 @@ var databasePlanLabels = map[types.ID]string{
 	types.DatabasePlans.DB1TB:   "1TB",
 }
 
+var databasePlanCPUs = map[types.ID]int{
+	types.DatabasePlans.DB10GB:  1,
+	types.DatabasePlans.DB30GB:  2,
+	types.DatabasePlans.DB90GB:  4,
+	types.DatabasePlans.DB240GB: 8,
+	types.DatabasePlans.DB500GB: 12,
+	types.DatabasePlans.DB1TB:   16,
+}
+
 func (c *DatabaseCollector) databaseInfoLabels(database *platform.Database) []string {
 	labels := c.databaseLabels(database)
 
@@ -345,8 +441,8 @@ func (c *DatabaseCollector) databaseInfoLabels(database *platform.Database) []st
 		"", // TODO libsacloud v2 doesn't support WebUI URL
 		replEnabled,
 		replRole,
-		flattenStringSlice(database.Tags),
-		database.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(database.Tags)),
+		c.opts.truncateLabelValue(database.Description),
 	)
 }
 
@@ -381,8 +477,8 @@ func (c *DatabaseCollector) nicInfoLabels(database *platform.Database) []string
 	)
 }
 
-func (c *DatabaseCollector) collectCPUTime(ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
-	values, err := c.client.MonitorCPU(c.ctx, database.ZoneName, database.ID, now)
+func (c *DatabaseCollector) collectCPUTime(ctx context.Context, ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
+	values, err := c.client.MonitorCPU(ctx, database.ZoneName, database.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
 		c.logger.Warn(
@@ -392,7 +488,12 @@ func (c *DatabaseCollector) collectCPUTime(ch chan<- prometheus.Metric, database
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorCPUTimeValue{Time: now}
+	} else {
+		c.opts.collectMonitorSampleTimestamp(ch, c.MonitorSampleTimestamp, values.Time, c.databaseLabels(database)...)
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -405,8 +506,8 @@ func (c *DatabaseCollector) collectCPUTime(ch chan<- prometheus.Metric, database
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *DatabaseCollector) collectDiskMetrics(ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
-	values, err := c.client.MonitorDisk(c.ctx, database.ZoneName, database.ID, now)
+func (c *DatabaseCollector) collectDiskMetrics(ctx context.Context, ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
+	values, err := c.client.MonitorDisk(ctx, database.ZoneName, database.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
 		c.logger.Warn(
@@ -416,7 +517,10 @@ func (c *DatabaseCollector) collectDiskMetrics(ch chan<- prometheus.Metric, data
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorDiskValue{Time: now}
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -435,8 +539,8 @@ func (c *DatabaseCollector) collectDiskMetrics(ch chan<- prometheus.Metric, data
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *DatabaseCollector) collectNICMetrics(ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
-	values, err := c.client.MonitorNIC(c.ctx, database.ZoneName, database.ID, now)
+func (c *DatabaseCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
+	values, err := c.client.MonitorNIC(ctx, database.ZoneName, database.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
 		c.logger.Warn(
@@ -446,13 +550,16 @@ func (c *DatabaseCollector) collectNICMetrics(ch chan<- prometheus.Metric, datab
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
 	}
 
 	m := prometheus.MustNewConstMetric(
 		c.NICReceive,
 		prometheus.GaugeValue,
-		values.Receive*8/1000,
+		c.opts.convertNetworkTraffic(values.Receive),
 		c.databaseLabels(database)...,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
@@ -460,14 +567,14 @@ func (c *DatabaseCollector) collectNICMetrics(ch chan<- prometheus.Metric, datab
 	m = prometheus.MustNewConstMetric(
 		c.NICSend,
 		prometheus.GaugeValue,
-		values.Send*8/1000,
+		c.opts.convertNetworkTraffic(values.Send),
 		c.databaseLabels(database)...,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
-	values, err := c.client.MonitorDatabase(c.ctx, database.ZoneName, database.ID, now)
+func (c *DatabaseCollector) collectDatabaseMetrics(ctx context.Context, ch chan<- prometheus.Metric, database *platform.Database, now time.Time) {
+	values, err := c.client.MonitorDatabase(ctx, database.ZoneName, database.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("database").Add(1)
 		c.logger.Warn(
@@ -505,6 +612,16 @@ func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 
+	if values.TotalMemorySize > 0 {
+		m = prometheus.MustNewConstMetric(
+			c.MemoryUtil,
+			prometheus.GaugeValue,
+			values.UsedMemorySize/values.TotalMemorySize,
+			labels...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
+
 	totalDisk1Size := values.TotalDisk1Size
 	if totalDisk1Size > 0 {
 		totalDisk1Size = totalDisk1Size / 1024 / 1024
@@ -529,6 +646,16 @@ func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 
+	if values.TotalDisk1Size > 0 {
+		m = prometheus.MustNewConstMetric(
+			c.SystemDiskUtil,
+			prometheus.GaugeValue,
+			values.UsedDisk1Size/values.TotalDisk1Size,
+			labels...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
+
 	totalDisk2Size := values.TotalDisk2Size
 	if totalDisk2Size > 0 {
 		totalDisk2Size = totalDisk2Size / 1024 / 1024
@@ -553,6 +680,16 @@ func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 
+	if values.TotalDisk2Size > 0 {
+		m = prometheus.MustNewConstMetric(
+			c.BackupDiskUtil,
+			prometheus.GaugeValue,
+			values.UsedDisk2Size/values.TotalDisk2Size,
+			labels...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
+
 	binlogUsed := values.BinlogUsedSizeKiB
 	if binlogUsed > 0 {
 		binlogUsed = binlogUsed / 1024 / 1024
@@ -565,6 +702,16 @@ func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 
+	if growth, ok := c.binlogs.observe(database.ID, values.Time, values.BinlogUsedSizeKiB); ok {
+		m = prometheus.MustNewConstMetric(
+			c.BinlogGrowth,
+			prometheus.GaugeValue,
+			growth,
+			c.databaseLabels(database)...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
+
 	m = prometheus.MustNewConstMetric(
 		c.ReplicationDelay,
 		prometheus.GaugeValue,
@@ -574,13 +721,68 @@ func (c *DatabaseCollector) collectDatabaseMetrics(ch chan<- prometheus.Metric,
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
+// databaseBinlogTracker keeps the last binlog sample of each database across Collects to compute the growth rate
+type databaseBinlogTracker struct {
+	mu      sync.Mutex
+	samples map[types.ID]*observedBinlogSample
+}
+
+type observedBinlogSample struct {
+	time    time.Time
+	usedKiB float64
+	growth  float64
+	hasRate bool
+}
+
+func newDatabaseBinlogTracker() *databaseBinlogTracker {
+	return &databaseBinlogTracker{
+		samples: make(map[types.ID]*observedBinlogSample),
+	}
+}
+
+// observe records the binlog sample of the database and returns its growth rate in bytes per second.
+// The rate can only be computed from the second sample, and the last rate is returned while the monitor API returns the same sample.
+func (t *databaseBinlogTracker) observe(id types.ID, sampleTime time.Time, usedKiB float64) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev, ok := t.samples[id]
+	if !ok {
+		t.samples[id] = &observedBinlogSample{time: sampleTime, usedKiB: usedKiB}
+		return 0, false
+	}
+	if !sampleTime.After(prev.time) {
+		return prev.growth, prev.hasRate
+	}
+
+	growth := (usedKiB - prev.usedKiB) * 1024 / sampleTime.Sub(prev.time).Seconds()
+	t.samples[id] = &observedBinlogSample{time: sampleTime, usedKiB: usedKiB, growth: growth, hasRate: true}
+	return growth, true
+}
+
+// retain removes the databases which no longer exist
+func (t *databaseBinlogTracker) retain(databases []*platform.Database) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exists := make(map[types.ID]bool)
+	for _, database := range databases {
+		exists[database.ID] = true
+	}
+	for id := range t.samples {
+		if !exists[id] {
+			delete(t.samples, id)
+		}
+	}
+}
+
 func (c *DatabaseCollector) maintenanceInfoLabels(resource *platform.Database, info *newsfeed.FeedItem) []string {
 	labels := c.databaseLabels(resource)
 
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
@@ -65,14 +65,16 @@ func (d *dummyDatabaseClient) MaintenanceInfo(infoURL string) (*newsfeed.FeedIte
 func TestDatabaseCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
 
-	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, &dummyDatabaseClient{})
+	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, Options{}, &dummyDatabaseClient{})
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
 		c.DatabaseInfo,
+		c.CPUs,
 		c.CPUTime,
 		c.MemoryUsed,
 		c.MemoryTotal,
+		c.MemoryUtil,
 		c.NICInfo,
 		c.NICReceive,
 		c.NICSend,
@@ -80,20 +82,27 @@ func TestDatabaseCollector_Describe(t *testing.T) {
 		c.SystemDiskTotal,
 		c.BackupDiskUsed,
 		c.BackupDiskTotal,
+		c.SystemDiskUtil,
+		c.BackupDiskUtil,
 		c.BinlogUsed,
+		c.BinlogGrowth,
 		c.DiskRead,
 		c.DiskWrite,
 		c.ReplicationDelay,
+		c.MonitorSampleTimestamp,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestDatabaseCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 
 	var (
 		dbValue = &platform.Database{
@@ -196,6 +205,10 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 					desc:   c.DatabaseInfo,
 					metric: createGaugeMetric(1, dbInfoLabels),
 				},
+				{
+					desc:   c.CPUs,
+					metric: createGaugeMetric(1, dbLabels),
+				},
 				{
 					desc:   c.NICInfo,
 					metric: createGaugeMetric(1, nicInfoLabels),
@@ -226,6 +239,10 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 					desc:   c.DatabaseInfo,
 					metric: createGaugeMetric(1, dbInfoLabels),
 				},
+				{
+					desc:   c.CPUs,
+					metric: createGaugeMetric(1, dbLabels),
+				},
 				{
 					desc:   c.NICInfo,
 					metric: createGaugeMetric(1, nicInfoLabels),
@@ -323,6 +340,10 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 					desc:   c.DatabaseInfo,
 					metric: createGaugeMetric(1, dbInfoLabels),
 				},
+				{
+					desc:   c.CPUs,
+					metric: createGaugeMetric(1, dbLabels),
+				},
 				{
 					desc:   c.NICInfo,
 					metric: createGaugeMetric(1, nicInfoLabels),
@@ -355,6 +376,10 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 					desc:   c.MemoryTotal,
 					metric: createGaugeWithTimestamp(float64(402)/1024/1024, dbLabels, monitorTime),
 				},
+				{
+					desc:   c.MemoryUtil,
+					metric: createGaugeWithTimestamp(float64(401)/402, dbLabels, monitorTime),
+				},
 				{
 					desc:   c.SystemDiskUsed,
 					metric: createGaugeWithTimestamp(float64(403)/1024/1024, dbLabels, monitorTime),
@@ -371,6 +396,14 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 					desc:   c.BackupDiskTotal,
 					metric: createGaugeWithTimestamp(float64(406)/1024/1024, dbLabels, monitorTime),
 				},
+				{
+					desc:   c.SystemDiskUtil,
+					metric: createGaugeWithTimestamp(float64(403)/404, dbLabels, monitorTime),
+				},
+				{
+					desc:   c.BackupDiskUtil,
+					metric: createGaugeWithTimestamp(float64(405)/406, dbLabels, monitorTime),
+				},
 				{
 					desc:   c.BinlogUsed,
 					metric: createGaugeWithTimestamp(float64(407)/1024/1024, dbLabels, monitorTime),
@@ -421,3 +454,108 @@ func TestDatabaseCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestDatabaseCollector_CPUs(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, Options{}, nil)
+
+	cases := []struct {
+		plan types.ID
+		want float64
+	}{
+		{plan: types.DatabasePlans.DB10GB, want: 1},
+		{plan: types.DatabasePlans.DB30GB, want: 2},
+		{plan: types.DatabasePlans.DB90GB, want: 4},
+		{plan: types.DatabasePlans.DB240GB, want: 8},
+		{plan: types.DatabasePlans.DB500GB, want: 12},
+		{plan: types.DatabasePlans.DB1TB, want: 16},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = &dummyDatabaseClient{
+			find: []*platform.Database{
+				{
+					Database: &iaas.Database{
+						ID:             101,
+						Name:           "database",
+						PlanID:         tc.plan,
+						Availability:   types.Availabilities.Migrating,
+						InstanceStatus: types.ServerInstanceStatuses.Down,
+						Conf: &iaas.DatabaseRemarkDBConfCommon{
+							DatabaseName: types.RDBMSTypesMariaDB.String(),
+						},
+						IPAddresses: []string{"192.168.0.11"},
+						Interfaces: []*iaas.InterfaceView{
+							{
+								ID:           201,
+								UpstreamType: types.UpstreamNetworkTypes.Switch,
+								SwitchID:     301,
+							},
+						},
+					},
+					ZoneName: "is1a",
+				},
+			},
+		}
+
+		collected, err := collectMetrics(c, "database")
+		require.NoError(t, err)
+
+		cpus := collected.filter(c.CPUs)
+		require.Len(t, cpus, 1)
+		require.Equal(t, tc.want, *cpus[0].metric.Gauge.Value)
+	}
+}
+
+func TestDatabaseCollector_BinlogGrowth(t *testing.T) {
+	initLoggerAndErrors()
+	sampleTime := time.Unix(1700000000, 0)
+	client := &dummyDatabaseClient{
+		find: []*platform.Database{
+			{
+				Database: &iaas.Database{
+					ID:             101,
+					Name:           "database",
+					PlanID:         types.DatabasePlans.DB10GB,
+					Availability:   types.Availabilities.Available,
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Conf: &iaas.DatabaseRemarkDBConfCommon{
+						DatabaseName: types.RDBMSTypesMariaDB.String(),
+					},
+					IPAddresses: []string{"192.168.0.11"},
+					Interfaces: []*iaas.InterfaceView{
+						{
+							ID:           201,
+							UpstreamType: types.UpstreamNetworkTypes.Switch,
+							SwitchID:     301,
+						},
+					},
+				},
+				ZoneName: "is1a",
+			},
+		},
+	}
+	c := NewDatabaseCollector(context.Background(), testLogger, testErrors, Options{}, client)
+
+	collectBinlogGrowth := func(used float64, at time.Time) []float64 {
+		client.monitorDB = &iaas.MonitorDatabaseValue{
+			Time:              at,
+			BinlogUsedSizeKiB: used,
+		}
+		collected, err := collectMetrics(c, "database")
+		require.NoError(t, err)
+
+		got := collected.values(c.BinlogGrowth)
+		return got
+	}
+
+	// the rate can't be computed from the first sample only
+	require.Empty(t, collectBinlogGrowth(1024, sampleTime))
+	// (3072KiB - 1024KiB) / 5min
+	require.Equal(t, []float64{2048 * 1024 / 300.0}, collectBinlogGrowth(3072, sampleTime.Add(5*time.Minute)))
+	// the same sample keeps the last rate
+	require.Equal(t, []float64{2048 * 1024 / 300.0}, collectBinlogGrowth(3072, sampleTime.Add(5*time.Minute)))
+}
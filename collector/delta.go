@@ -0,0 +1,122 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// DeltaCollector wraps a collector and drops the info metrics(*_info) that are unchanged since the last scrape.
+//
+// This is experimental and reduces the series churn of static inventory on very large accounts.
+// Metrics other than info metrics, such as up and monitor metrics, are always sent.
+type DeltaCollector struct {
+	collector prometheus.Collector
+
+	mu sync.Mutex
+	// previous holds the hashes of the info metrics sent or suppressed in the last scrape
+	previous map[uint64]struct{}
+}
+
+// NewDeltaCollector returns a new DeltaCollector.
+func NewDeltaCollector(collector prometheus.Collector) *DeltaCollector {
+	return &DeltaCollector{
+		collector: collector,
+		previous:  make(map[uint64]struct{}),
+	}
+}
+
+// Describe sends metric descriptors of the wrapped collector to the prometheus desc channel.
+func (c *DeltaCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+// Collect receives metrics from the wrapped collector and sends them except unchanged info metrics.
+func (c *DeltaCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, c.collector.Collect)
+}
+
+// CollectWithContext is the same as Collect, but collects the wrapped collector with ctx.
+func (c *DeltaCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	c.collect(ch, collectFunc(ctx, c.collector))
+}
+
+func (c *DeltaCollector) collect(ch chan<- prometheus.Metric, collect func(chan<- prometheus.Metric)) {
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collect(metrics)
+		close(metrics)
+	}()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	current := make(map[uint64]struct{})
+	for m := range metrics {
+		if !strings.HasSuffix(descFQName(m.Desc()), "_info") {
+			ch <- m
+			continue
+		}
+		h, ok := hashMetric(m)
+		if !ok {
+			ch <- m
+			continue
+		}
+		current[h] = struct{}{}
+		if _, unchanged := c.previous[h]; unchanged {
+			continue
+		}
+		ch <- m
+	}
+	c.previous = current
+}
+
+// Reset forgets the info metrics of the last scrape so that all of them are sent in the next scrape,
+// and resets the state of the wrapped collector if it is supported
+func (c *DeltaCollector) Reset() {
+	c.mu.Lock()
+	c.previous = make(map[uint64]struct{})
+	c.mu.Unlock()
+
+	if r, ok := c.collector.(resetter); ok {
+		r.Reset()
+	}
+}
+
+// hashMetric returns the hash of the desc, labels and value of the metric
+func hashMetric(m prometheus.Metric) (uint64, bool) {
+	var pb dto.Metric
+	if err := m.Write(&pb); err != nil {
+		return 0, false
+	}
+
+	var b strings.Builder
+	b.WriteString(m.Desc().String())
+	for _, label := range pb.Label {
+		b.WriteString("\x00" + label.GetName() + "\x00" + label.GetValue())
+	}
+	b.WriteString("\x00" + strconv.FormatFloat(pb.GetGauge().GetValue(), 'g', -1, 64))
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(b.String()))
+	return h.Sum64(), true
+}
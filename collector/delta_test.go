@@ -0,0 +1,79 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeltaCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	database := &platform.Database{
+		ZoneName: "is1a",
+		Database: &iaas.Database{
+			ID:             101,
+			Name:           "database",
+			PlanID:         types.DatabasePlans.DB10GB,
+			Availability:   types.Availabilities.Available,
+			InstanceStatus: types.ServerInstanceStatuses.Down,
+			Conf: &iaas.DatabaseRemarkDBConfCommon{
+				DatabaseName: types.RDBMSTypesMariaDB.String(),
+			},
+			IPAddresses: []string{"192.168.0.11"},
+			Interfaces: []*iaas.InterfaceView{
+				{
+					ID:           201,
+					UpstreamType: types.UpstreamNetworkTypes.Switch,
+					SwitchID:     301,
+				},
+			},
+		},
+	}
+	dc := NewDatabaseCollector(context.Background(), testLogger, testErrors, Options{}, &dummyDatabaseClient{
+		find: []*platform.Database{database},
+	})
+	c := NewDeltaCollector(dc)
+
+	collectDescsOf := func() []*prometheus.Desc {
+		collected, err := collectMetrics(c, "database")
+		require.NoError(t, err)
+
+		var descs []*prometheus.Desc
+		for _, m := range collected.collected {
+			descs = append(descs, m.desc)
+		}
+		return descs
+	}
+
+	// all metrics are sent on the first scrape
+	require.ElementsMatch(t, []*prometheus.Desc{dc.Up, dc.DatabaseInfo, dc.CPUs, dc.NICInfo}, collectDescsOf())
+	// unchanged info metrics are suppressed
+	require.ElementsMatch(t, []*prometheus.Desc{dc.Up, dc.CPUs}, collectDescsOf())
+
+	// changed info metrics are sent again
+	database.Name = "renamed"
+	require.ElementsMatch(t, []*prometheus.Desc{dc.Up, dc.DatabaseInfo, dc.CPUs, dc.NICInfo}, collectDescsOf())
+
+	// all metrics are sent after reset
+	c.Reset()
+	require.ElementsMatch(t, []*prometheus.Desc{dc.Up, dc.DatabaseInfo, dc.CPUs, dc.NICInfo}, collectDescsOf())
+}
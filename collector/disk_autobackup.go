@@ -0,0 +1,110 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// DiskAutoBackupCollector collects metrics about whether disks are protected by AutoBackup.
+//
+// Disks are listed from the servers they are connected to, so disks not connected to any server are not reported.
+type DiskAutoBackupCollector struct {
+	ctx              context.Context
+	logger           *slog.Logger
+	errors           *prometheus.CounterVec
+	opts             Options
+	serverClient     platform.ServerClient
+	autoBackupClient platform.AutoBackupClient
+
+	AutoBackupConfigured *prometheus.Desc
+}
+
+// NewDiskAutoBackupCollector returns a new DiskAutoBackupCollector.
+func NewDiskAutoBackupCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, serverClient platform.ServerClient, autoBackupClient platform.AutoBackupClient) *DiskAutoBackupCollector {
+	errors.WithLabelValues("disk_autobackup").Add(0)
+
+	return &DiskAutoBackupCollector{
+		ctx:              ctx,
+		logger:           logger,
+		errors:           errors,
+		opts:             opts,
+		serverClient:     serverClient,
+		autoBackupClient: autoBackupClient,
+		AutoBackupConfigured: opts.newDesc(
+			"sakuracloud_disk_autobackup_configured",
+			"If 1 the disk is targeted by an AutoBackup, 0 otherwise",
+			[]string{"disk_id", "name", "zone"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *DiskAutoBackupCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.AutoBackupConfigured
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DiskAutoBackupCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *DiskAutoBackupCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	autoBackups, err := c.autoBackupClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("disk_autobackup").Add(1)
+		c.logger.Warn(
+			"can't list autoBackups",
+			slog.Any("err", err),
+		)
+		return
+	}
+	servers, err := c.serverClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("disk_autobackup").Add(1)
+		c.logger.Warn(
+			"can't list servers",
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	backedUp := make(map[types.ID]struct{})
+	for _, autoBackup := range autoBackups {
+		backedUp[autoBackup.DiskID] = struct{}{}
+	}
+
+	for _, server := range servers {
+		for _, disk := range server.Disks {
+			configured := float64(0.0)
+			if _, ok := backedUp[disk.ID]; ok {
+				configured = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.AutoBackupConfigured,
+				prometheus.GaugeValue,
+				configured,
+				disk.ID.String(), c.opts.truncateLabelValue(disk.Name), server.ZoneName,
+			)
+		}
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// DNSCollector collects metrics about all DNS zones.
+type DNSCollector struct {
+	ctx    context.Context
+	logger *slog.Logger
+	errors *prometheus.CounterVec
+	opts   Options
+	client platform.DNSClient
+
+	resolver NSResolver
+
+	DNSInfo      *prometheus.Desc
+	RecordCount  *prometheus.Desc
+	DelegationOK *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
+}
+
+// NSResolver looks up the NS records of DNS zones. *net.Resolver satisfies it.
+type NSResolver interface {
+	LookupNS(ctx context.Context, name string) ([]*net.NS, error)
+}
+
+// NewDNSCollector returns a new DNSCollector.
+//
+// If resolver is nil, sakuracloud_dns_delegation_ok is not collected.
+func NewDNSCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.DNSClient, resolver NSResolver) *DNSCollector {
+	errors.WithLabelValues("dns").Add(0)
+
+	dnsLabels := []string{"id", "name"}
+	dnsInfoLabels := append(dnsLabels, "dns_zone", "name_servers", "tags", "description")
+
+	return &DNSCollector{
+		ctx:    ctx,
+		logger: logger,
+		errors: errors,
+		opts:   opts,
+		client: client,
+
+		resolver: resolver,
+
+		DNSInfo: opts.newDesc(
+			"sakuracloud_dns_info",
+			"A metric with a constant '1' value labeled by DNS zone information",
+			dnsInfoLabels, nil,
+		),
+		RecordCount: opts.newDesc(
+			"sakuracloud_dns_record_count",
+			"The number of records in the DNS zone per record type",
+			append(dnsLabels, "type"), nil,
+		),
+		DelegationOK: opts.newDesc(
+			"sakuracloud_dns_delegation_ok",
+			"If 1 the NS records of the DNS zone match the name servers of SakuraCloud, 0 otherwise",
+			[]string{"dns_zone"}, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("dns"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("dns"),
+		CountByTag:      opts.newResourceCountByTagDesc("dns"),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *DNSCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.DNSInfo
+	ch <- c.RecordCount
+	ch <- c.DelegationOK
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DNSCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *DNSCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	zones, err := c.client.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("dns").Add(1)
+		c.logger.Warn(
+			"can't list DNS zones",
+			slog.Any("err", err),
+		)
+	}
+
+	tags := make([]types.Tags, 0, len(zones))
+	for _, dns := range zones {
+		tags = append(tags, dns.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	var wg sync.WaitGroup
+	for _, dns := range zones {
+		collectResourceUnnamed(ch, c.Unnamed, dns.ID, "", dns.Name)
+		c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, dns.ID, "", dns.CreatedAt)
+
+		c.collectDNSInfo(ch, dns)
+		c.collectRecordCount(ch, dns)
+
+		if c.resolver != nil {
+			wg.Add(1)
+			go func(dns *iaas.DNS) {
+				c.collectDelegation(ctx, ch, dns)
+				wg.Done()
+			}(dns)
+		}
+	}
+
+	wg.Wait()
+}
+
+func (c *DNSCollector) dnsLabels(dns *iaas.DNS) []string {
+	return []string{
+		dns.ID.String(),
+		c.opts.truncateLabelValue(dns.Name),
+	}
+}
+
+func (c *DNSCollector) collectDNSInfo(ch chan<- prometheus.Metric, dns *iaas.DNS) {
+	labels := append(c.dnsLabels(dns),
+		dns.DNSZone,
+		flattenStringSlice(dns.DNSNameServers),
+		c.opts.truncateLabelValue(flattenStringSlice(dns.Tags)),
+		c.opts.truncateLabelValue(dns.Description),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.DNSInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		labels...,
+	)
+}
+
+// collectRecordCount reports the number of records per type.
+// The known types are always reported, even if 0, so that the deletion of all records of a type can be detected.
+func (c *DNSCollector) collectRecordCount(ch chan<- prometheus.Metric, dns *iaas.DNS) {
+	counts := make(map[string]int)
+	for _, recordType := range types.DNSRecordTypeStrings {
+		counts[recordType] = 0
+	}
+	for _, record := range dns.Records {
+		if record == nil {
+			continue
+		}
+		counts[record.Type.String()]++
+	}
+
+	for recordType, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.RecordCount,
+			prometheus.GaugeValue,
+			float64(count),
+			append(c.dnsLabels(dns), recordType)...,
+		)
+	}
+}
+
+// dnsDelegationLookupTimeout is the upper bound of the NS lookup per DNS zone
+const dnsDelegationLookupTimeout = 3 * time.Second
+
+// collectDelegation reports whether the NS records of the DNS zone resolved from the outside
+// are the same as the name servers assigned to the zone by SakuraCloud.
+// A zone which isn't delegated at all is reported as 0.
+func (c *DNSCollector) collectDelegation(ctx context.Context, ch chan<- prometheus.Metric, dns *iaas.DNS) {
+	ctx, cancel := context.WithTimeout(ctx, dnsDelegationLookupTimeout)
+	defer cancel()
+
+	records, err := c.resolver.LookupNS(ctx, dns.DNSZone)
+	if err != nil {
+		var dnsErr *net.DNSError
+		if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+			c.errors.WithLabelValues("dns").Add(1)
+			c.logger.Warn(
+				fmt.Sprintf("can't look up NS records: DNSZone=%s", dns.DNSZone),
+				slog.Any("err", err),
+			)
+			return
+		}
+		records = nil
+	}
+
+	var ok float64
+	if isSameNameServers(records, dns.DNSNameServers) {
+		ok = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.DelegationOK,
+		prometheus.GaugeValue,
+		ok,
+		dns.DNSZone,
+	)
+}
+
+// isSameNameServers returns true if the NS records and the name servers are the same set of hosts.
+// Host names are compared case-insensitively, ignoring the trailing dot.
+func isSameNameServers(records []*net.NS, nameServers []string) bool {
+	normalize := func(host string) string {
+		return strings.ToLower(strings.TrimSuffix(host, "."))
+	}
+
+	expected := make(map[string]bool)
+	for _, nameServer := range nameServers {
+		expected[normalize(nameServer)] = true
+	}
+	resolved := make(map[string]bool)
+	for _, record := range records {
+		if record == nil {
+			continue
+		}
+		host := normalize(record.Host)
+		if !expected[host] {
+			return false
+		}
+		resolved[host] = true
+	}
+	return len(expected) > 0 && len(resolved) == len(expected)
+}
@@ -0,0 +1,239 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyDNSClient struct {
+	find    []*iaas.DNS
+	findErr error
+}
+
+func (d *dummyDNSClient) Find(ctx context.Context) ([]*iaas.DNS, error) {
+	return d.find, d.findErr
+}
+
+type dummyNSResolver struct {
+	ns  []*net.NS
+	err error
+}
+
+func (d *dummyNSResolver) LookupNS(ctx context.Context, name string) ([]*net.NS, error) {
+	return d.ns, d.err
+}
+
+func TestDNSCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDNSCollector(context.Background(), testLogger, testErrors, Options{}, &dummyDNSClient{}, nil)
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.DNSInfo,
+		c.RecordCount,
+		c.DelegationOK,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
+	}))
+}
+
+// dnsRecordCountMetrics returns the sakuracloud_dns_record_count of all known record types, 0 unless specified in counts
+func dnsRecordCountMetrics(c *DNSCollector, id, name string, counts map[string]float64) []*collectedMetric {
+	var metrics []*collectedMetric
+	for _, recordType := range types.DNSRecordTypeStrings {
+		metrics = append(metrics, &collectedMetric{
+			desc: c.RecordCount,
+			metric: createGaugeMetric(counts[recordType], map[string]string{
+				"id":   id,
+				"name": name,
+				"type": recordType,
+			}),
+		})
+	}
+	return metrics
+}
+
+func TestDNSCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDNSCollector(context.Background(), testLogger, testErrors, Options{}, nil, nil)
+
+	cases := []struct {
+		name           string
+		in             platform.DNSClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "collector returns error",
+			in: &dummyDNSClient{
+				findErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list DNS zones" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			in:          &dummyDNSClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a DNS zone",
+			in: &dummyDNSClient{
+				find: []*iaas.DNS{
+					{
+						ID:             101,
+						Name:           "example.com",
+						Tags:           types.Tags{"tag1", "tag2"},
+						Description:    "desc",
+						DNSZone:        "ns1.gslb1.sakura.ne.jp",
+						DNSNameServers: []string{"ns1.gslb1.sakura.ne.jp", "ns2.gslb1.sakura.ne.jp"},
+						Records: iaas.DNSRecords{
+							{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.1", TTL: 300},
+							{Name: "www", Type: types.DNSRecordTypes.A, RData: "192.0.2.2", TTL: 300},
+							{Name: "@", Type: types.DNSRecordTypes.MX, RData: "10 mail.example.com.", TTL: 300},
+							{Name: "@", Type: types.DNSRecordTypes.TXT, RData: "v=spf1 -all", TTL: 300},
+						},
+					},
+				},
+			},
+			wantMetrics: append([]*collectedMetric{
+				{
+					desc: c.DNSInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":           "101",
+						"name":         "example.com",
+						"dns_zone":     "ns1.gslb1.sakura.ne.jp",
+						"name_servers": ",ns1.gslb1.sakura.ne.jp,ns2.gslb1.sakura.ne.jp,",
+						"tags":         ",tag1,tag2,",
+						"description":  "desc",
+					}),
+				},
+			}, dnsRecordCountMetrics(c, "101", "example.com", map[string]float64{
+				"A":   2,
+				"MX":  1,
+				"TXT": 1,
+			})...),
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = tc.in
+
+		collected, err := collectMetrics(c, "dns")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
+
+func TestDNSCollector_Delegation(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDNSCollector(context.Background(), testLogger, testErrors, Options{}, &dummyDNSClient{
+		find: []*iaas.DNS{
+			{
+				ID:             101,
+				Name:           "example.com",
+				DNSZone:        "example.com",
+				DNSNameServers: []string{"ns1.gslb1.sakura.ne.jp", "ns2.gslb1.sakura.ne.jp"},
+			},
+		},
+	}, nil)
+
+	cases := []struct {
+		name           string
+		resolver       *dummyNSResolver
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "NS records match the name servers",
+			resolver: &dummyNSResolver{
+				ns: []*net.NS{{Host: "NS2.gslb1.sakura.ne.jp."}, {Host: "ns1.gslb1.sakura.ne.jp."}},
+			},
+			wantMetrics: []*collectedMetric{
+				{desc: c.DelegationOK, metric: createGaugeMetric(1, map[string]string{"dns_zone": "example.com"})},
+			},
+		},
+		{
+			name: "NS records point at other name servers",
+			resolver: &dummyNSResolver{
+				ns: []*net.NS{{Host: "ns1.gslb1.sakura.ne.jp."}, {Host: "ns1.example.net."}},
+			},
+			wantMetrics: []*collectedMetric{
+				{desc: c.DelegationOK, metric: createGaugeMetric(0, map[string]string{"dns_zone": "example.com"})},
+			},
+		},
+		{
+			name: "NS records lack a name server",
+			resolver: &dummyNSResolver{
+				ns: []*net.NS{{Host: "ns1.gslb1.sakura.ne.jp."}},
+			},
+			wantMetrics: []*collectedMetric{
+				{desc: c.DelegationOK, metric: createGaugeMetric(0, map[string]string{"dns_zone": "example.com"})},
+			},
+		},
+		{
+			name: "zone is not delegated",
+			resolver: &dummyNSResolver{
+				err: &net.DNSError{Err: "no such host", Name: "example.com", IsNotFound: true},
+			},
+			wantMetrics: []*collectedMetric{
+				{desc: c.DelegationOK, metric: createGaugeMetric(0, map[string]string{"dns_zone": "example.com"})},
+			},
+		},
+		{
+			name: "lookup fails",
+			resolver: &dummyNSResolver{
+				err: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't look up NS records: DNSZone=example.com" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.resolver = tc.resolver
+
+		collected, err := collectMetrics(c, "dns")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged, tc.name)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value, tc.name)
+
+		delegations := collected.filter(c.DelegationOK)
+		requireMetricsEqual(t, tc.wantMetrics, delegations)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// DuplicateIPCollector collects metrics about IP addresses assigned to multiple resources on the same switch.
+//
+// Only the IP addresses on switches are checked, because the shared segment assigns unique addresses.
+type DuplicateIPCollector struct {
+	ctx    context.Context
+	logger *slog.Logger
+	errors *prometheus.CounterVec
+	opts   Options
+
+	serverClient       platform.ServerClient
+	loadBalancerClient platform.LoadBalancerClient
+	vpcRouterClient    platform.VPCRouterClient
+	nfsClient          platform.NFSClient
+	databaseClient     platform.DatabaseClient
+
+	DuplicateIP *prometheus.Desc
+}
+
+// NewDuplicateIPCollector returns a new DuplicateIPCollector.
+func NewDuplicateIPCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options,
+	serverClient platform.ServerClient, loadBalancerClient platform.LoadBalancerClient, vpcRouterClient platform.VPCRouterClient,
+	nfsClient platform.NFSClient, databaseClient platform.DatabaseClient) *DuplicateIPCollector {
+	errors.WithLabelValues("duplicate_ip").Add(0)
+
+	return &DuplicateIPCollector{
+		ctx:                ctx,
+		logger:             logger,
+		errors:             errors,
+		opts:               opts,
+		serverClient:       serverClient,
+		loadBalancerClient: loadBalancerClient,
+		vpcRouterClient:    vpcRouterClient,
+		nfsClient:          nfsClient,
+		databaseClient:     databaseClient,
+		DuplicateIP: opts.newDesc(
+			"sakuracloud_duplicate_ip",
+			"The number of resources sharing the IP address on the same switch. Only duplicated addresses are reported",
+			[]string{"ipaddress", "zone", "switch_id"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *DuplicateIPCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.DuplicateIP
+}
+
+type switchIPAddress struct {
+	zone      string
+	switchID  types.ID
+	ipAddress string
+}
+
+// switchIPAddressCounter counts the resources for each IP address on switches
+type switchIPAddressCounter map[switchIPAddress]int
+
+// add counts the addresses of a resource. The same address in a resource is counted once.
+func (counter switchIPAddressCounter) add(addresses []switchIPAddress) {
+	seen := make(map[switchIPAddress]struct{})
+	for _, v := range addresses {
+		if v.switchID.IsEmpty() || v.ipAddress == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		counter[v]++
+	}
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *DuplicateIPCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *DuplicateIPCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	counter := make(switchIPAddressCounter)
+
+	if servers, err := c.serverClient.Find(ctx); err != nil {
+		c.handleError("can't list servers", err)
+	} else {
+		for _, server := range servers {
+			var addresses []switchIPAddress
+			for _, nic := range server.Interfaces {
+				addresses = append(addresses, switchIPAddress{zone: server.ZoneName, switchID: nic.SwitchID, ipAddress: nic.UserIPAddress})
+			}
+			counter.add(addresses)
+		}
+	}
+
+	if loadBalancers, err := c.loadBalancerClient.Find(ctx); err != nil {
+		c.handleError("can't list loadbalancers", err)
+	} else {
+		for _, lb := range loadBalancers {
+			var addresses []switchIPAddress
+			for _, ip := range lb.IPAddresses {
+				addresses = append(addresses, switchIPAddress{zone: lb.ZoneName, switchID: lb.SwitchID, ipAddress: ip})
+			}
+			counter.add(addresses)
+		}
+	}
+
+	if vpcRouters, err := c.vpcRouterClient.Find(ctx); err != nil {
+		c.handleError("can't list vpc routers", err)
+	} else {
+		for _, vpcRouter := range vpcRouters {
+			if vpcRouter.Settings == nil {
+				continue
+			}
+			var addresses []switchIPAddress
+			for _, nic := range vpcRouter.Interfaces {
+				setting := getInterfaceByIndex(vpcRouter.Settings.Interfaces, nic.Index)
+				if setting == nil {
+					continue
+				}
+				for _, ip := range setting.IPAddress {
+					addresses = append(addresses, switchIPAddress{zone: vpcRouter.ZoneName, switchID: nic.SwitchID, ipAddress: ip})
+				}
+				addresses = append(addresses, switchIPAddress{zone: vpcRouter.ZoneName, switchID: nic.SwitchID, ipAddress: setting.VirtualIPAddress})
+			}
+			counter.add(addresses)
+		}
+	}
+
+	if nfsList, err := c.nfsClient.Find(ctx); err != nil {
+		c.handleError("can't list nfs", err)
+	} else {
+		for _, nfs := range nfsList {
+			var addresses []switchIPAddress
+			for _, ip := range nfs.IPAddresses {
+				addresses = append(addresses, switchIPAddress{zone: nfs.ZoneName, switchID: nfs.SwitchID, ipAddress: ip})
+			}
+			counter.add(addresses)
+		}
+	}
+
+	if databases, err := c.databaseClient.Find(ctx); err != nil {
+		c.handleError("can't list databases", err)
+	} else {
+		for _, database := range databases {
+			var addresses []switchIPAddress
+			for _, ip := range database.IPAddresses {
+				addresses = append(addresses, switchIPAddress{zone: database.ZoneName, switchID: database.SwitchID, ipAddress: ip})
+			}
+			counter.add(addresses)
+		}
+	}
+
+	for address, count := range counter {
+		if count < 2 {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.DuplicateIP,
+			prometheus.GaugeValue,
+			float64(count),
+			address.ipAddress, address.zone, address.switchID.String(),
+		)
+	}
+}
+
+func (c *DuplicateIPCollector) handleError(msg string, err error) {
+	c.errors.WithLabelValues("duplicate_ip").Add(1)
+	c.logger.Warn(
+		msg,
+		slog.Any("err", err),
+	)
+}
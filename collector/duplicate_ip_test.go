@@ -0,0 +1,108 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateIPCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDuplicateIPCollector(context.Background(), testLogger, testErrors, Options{},
+		&dummyServerClient{}, &dummyLoadBalancerClient{}, &dummyVPCRouterClient{}, &dummyNFSClient{}, &dummyDatabaseClient{})
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.DuplicateIP,
+	}))
+}
+
+func TestDuplicateIPCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewDuplicateIPCollector(context.Background(), testLogger, testErrors, Options{},
+		&dummyServerClient{
+			find: []*platform.Server{
+				{
+					ZoneName: "is1a",
+					Server: &iaas.Server{
+						ID: 101,
+						Interfaces: []*iaas.InterfaceView{
+							{ID: 201, IPAddress: "192.0.2.11"},
+							{ID: 202, SwitchID: 301, UserIPAddress: "192.168.0.11"},
+						},
+					},
+				},
+				{
+					ZoneName: "is1a",
+					Server: &iaas.Server{
+						ID: 102,
+						Interfaces: []*iaas.InterfaceView{
+							{ID: 203, SwitchID: 302, UserIPAddress: "192.168.0.12"},
+						},
+					},
+				},
+			},
+		},
+		&dummyLoadBalancerClient{
+			find: []*platform.LoadBalancer{
+				{
+					ZoneName: "is1a",
+					LoadBalancer: &iaas.LoadBalancer{
+						ID:          401,
+						SwitchID:    301,
+						IPAddresses: []string{"192.168.0.11", "192.168.0.13"},
+					},
+				},
+			},
+		},
+		&dummyVPCRouterClient{findErr: errors.New("dummy")},
+		&dummyNFSClient{
+			find: []*platform.NFS{
+				{
+					ZoneName: "is1a",
+					NFS: &iaas.NFS{
+						ID: 501,
+						// same address on another switch
+						SwitchID:    302,
+						IPAddresses: []string{"192.168.0.11"},
+					},
+				},
+			},
+		},
+		&dummyDatabaseClient{},
+	)
+
+	collected, err := collectMetrics(c, "duplicate_ip")
+	require.NoError(t, err)
+	require.Equal(t, []string{`level=WARN msg="can't list vpc routers" err=dummy`}, collected.logged)
+	require.Equal(t, float64(1), *collected.errors.Counter.Value)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.DuplicateIP,
+			metric: createGaugeMetric(2, map[string]string{
+				"ipaddress": "192.168.0.11",
+				"zone":      "is1a",
+				"switch_id": "301",
+			}),
+		},
+	}, collected.collected)
+}
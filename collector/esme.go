@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
@@ -30,35 +33,54 @@ type ESMECollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.ESMEClient
 
+	sendRateWindows []time.Duration
+
 	ESMEInfo     *prometheus.Desc
 	MessageCount *prometheus.Desc
+	SendRate     *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewESMECollector returns a new ESMECollector.
-func NewESMECollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.ESMEClient) *ESMECollector {
+func NewESMECollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.ESMEClient, sendRateWindows []time.Duration) *ESMECollector {
 	errors.WithLabelValues("esme").Add(0)
 
 	labels := []string{"id", "name"}
 	infoLabels := append(labels, "tags", "description")
 	messageLabels := append(labels, "status")
+	sendRateLabels := append(labels, "window")
 
 	return &ESMECollector{
-		ctx:    ctx,
-		logger: logger,
-		errors: errors,
-		client: client,
-		ESMEInfo: prometheus.NewDesc(
+		ctx:             ctx,
+		logger:          logger,
+		errors:          errors,
+		opts:            opts,
+		client:          client,
+		sendRateWindows: sendRateWindows,
+		ESMEInfo: opts.newDesc(
 			"sakuracloud_esme_info",
 			"A metric with a constant '1' value labeled by ESME information",
 			infoLabels, nil,
 		),
-		MessageCount: prometheus.NewDesc(
+		MessageCount: opts.newDesc(
 			"sakuracloud_esme_message_count",
 			"A count of messages handled by ESME",
 			messageLabels, nil,
 		),
+		SendRate: opts.newDesc(
+			"sakuracloud_esme_send_rate",
+			"Messages sent by ESME per second over the window",
+			sendRateLabels, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("esme"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("esme"),
+		CountByTag:      opts.newResourceCountByTagDesc("esme"),
 	}
 }
 
@@ -67,11 +89,20 @@ func NewESMECollector(ctx context.Context, logger *slog.Logger, errors *promethe
 func (c *ESMECollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.ESMEInfo
 	ch <- c.MessageCount
+	ch <- c.SendRate
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *ESMECollector) Collect(ch chan<- prometheus.Metric) {
-	searched, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *ESMECollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	searched, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("esme").Add(1)
 		c.logger.Warn(
@@ -80,18 +111,26 @@ func (c *ESMECollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(searched))
+	for _, esme := range searched {
+		tags = append(tags, esme.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
 	var wg sync.WaitGroup
 	wg.Add(len(searched))
 
 	for i := range searched {
 		func(esme *iaas.ESME) {
 			defer wg.Done()
+			collectResourceUnnamed(ch, c.Unnamed, esme.ID, "", esme.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, esme.ID, "", esme.CreatedAt)
 
 			c.collectESMEInfo(ch, esme)
 
 			wg.Add(1)
 			go func() {
-				c.collectLogs(ch, esme)
+				c.collectLogs(ctx, ch, esme)
 				wg.Done()
 			}()
 		}(searched[i])
@@ -103,14 +142,14 @@ func (c *ESMECollector) Collect(ch chan<- prometheus.Metric) {
 func (c *ESMECollector) esmeLabels(esme *iaas.ESME) []string {
 	return []string{
 		esme.ID.String(),
-		esme.Name,
+		c.opts.truncateLabelValue(esme.Name),
 	}
 }
 
 func (c *ESMECollector) collectESMEInfo(ch chan<- prometheus.Metric, esme *iaas.ESME) {
 	labels := append(c.esmeLabels(esme),
-		flattenStringSlice(esme.Tags),
-		esme.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(esme.Tags)),
+		c.opts.truncateLabelValue(esme.Description),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -121,8 +160,8 @@ func (c *ESMECollector) collectESMEInfo(ch chan<- prometheus.Metric, esme *iaas.
 	)
 }
 
-func (c *ESMECollector) collectLogs(ch chan<- prometheus.Metric, esme *iaas.ESME) {
-	logs, err := c.client.Logs(c.ctx, esme.ID)
+func (c *ESMECollector) collectLogs(ctx context.Context, ch chan<- prometheus.Metric, esme *iaas.ESME) {
+	logs, err := c.client.Logs(ctx, esme.ID)
 	if err != nil {
 		c.errors.WithLabelValues("esme").Add(1)
 		c.logger.Warn(
@@ -132,6 +171,8 @@ func (c *ESMECollector) collectLogs(ch chan<- prometheus.Metric, esme *iaas.ESME
 		return
 	}
 
+	c.collectSendRate(ch, esme, logs, time.Now())
+
 	labels := c.esmeLabels(esme)
 	labelsForAll := append(labels, "All")
 
@@ -161,3 +202,40 @@ func (c *ESMECollector) collectLogs(ch chan<- prometheus.Metric, esme *iaas.ESME
 		)
 	}
 }
+
+func (c *ESMECollector) collectSendRate(ch chan<- prometheus.Metric, esme *iaas.ESME, logs []*iaas.ESMELogs, now time.Time) {
+	// The logs API doesn't support filtering by time, so the logs are filtered by SentAt here.
+	for _, window := range c.sendRateWindows {
+		if window <= 0 {
+			continue
+		}
+		from := now.Add(-window)
+
+		count := 0
+		for _, l := range logs {
+			if l.SentAt.After(from) && !l.SentAt.After(now) {
+				count++
+			}
+		}
+
+		labels := append(c.esmeLabels(esme), formatWindow(window))
+		ch <- prometheus.MustNewConstMetric(
+			c.SendRate,
+			prometheus.GaugeValue,
+			float64(count)/window.Seconds(),
+			labels...,
+		)
+	}
+}
+
+// formatWindow returns the duration without trailing zero units, e.g. "1h" instead of "1h0m0s"
+func formatWindow(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sacloud/iaas-api-go"
@@ -43,18 +44,23 @@ func (d *dummyESMEClient) Logs(ctx context.Context, esmeID types.ID) ([]*iaas.ES
 
 func TestESMECollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewESMECollector(context.Background(), testLogger, testErrors, &dummyESMEClient{})
+	c := NewESMECollector(context.Background(), testLogger, testErrors, Options{}, &dummyESMEClient{}, nil)
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.ESMEInfo,
 		c.MessageCount,
+		c.SendRate,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestESMECollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewESMECollector(context.Background(), testLogger, testErrors, nil)
+	c := NewESMECollector(context.Background(), testLogger, testErrors, Options{}, nil, []time.Duration{time.Hour, 24 * time.Hour})
+	now := time.Now()
 
 	cases := []struct {
 		name           string
@@ -77,6 +83,77 @@ func TestESMECollector_Collect(t *testing.T) {
 			in:          &dummyESMEClient{},
 			wantMetrics: nil,
 		},
+		{
+			name: "esme with logs across the send rate windows",
+			in: &dummyESMEClient{
+				esme: []*iaas.ESME{
+					{
+						ID:          101,
+						Name:        "ESME",
+						Tags:        types.Tags{"tag1", "tag2"},
+						Description: "desc",
+					},
+				},
+				logs: []*iaas.ESMELogs{
+					{MessageID: "1", Status: "Done", SentAt: now.Add(-10 * time.Minute)},
+					{MessageID: "2", Status: "Done", SentAt: now.Add(-59 * time.Minute)},
+					{MessageID: "3", Status: "Error", SentAt: now.Add(-61 * time.Minute)},
+					{MessageID: "4", Status: "Done", SentAt: now.Add(-23 * time.Hour)},
+					{MessageID: "5", Status: "Done", SentAt: now.Add(-25 * time.Hour)},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.ESMEInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "ESME",
+						"tags":        ",tag1,tag2,",
+						"description": "desc",
+					}),
+				},
+				{
+					desc: c.MessageCount,
+					metric: createGaugeMetric(5, map[string]string{
+						"id":     "101",
+						"name":   "ESME",
+						"status": "All",
+					}),
+				},
+				{
+					desc: c.MessageCount,
+					metric: createGaugeMetric(4, map[string]string{
+						"id":     "101",
+						"name":   "ESME",
+						"status": "Done",
+					}),
+				},
+				{
+					desc: c.MessageCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "ESME",
+						"status": "Error",
+					}),
+				},
+				{
+					desc: c.SendRate,
+					metric: createGaugeMetric(2.0/3600, map[string]string{
+						"id":     "101",
+						"name":   "ESME",
+						"window": "1h",
+					}),
+				},
+				{
+					desc: c.SendRate,
+					metric: createGaugeMetric(4.0/86400, map[string]string{
+						"id":     "101",
+						"name":   "ESME",
+						"window": "24h",
+					}),
+				},
+			},
+		},
 		{
 			name: "esme: collecting ESME logs is failed ",
 			in: &dummyESMEClient{
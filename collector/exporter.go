@@ -20,44 +20,53 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
 // ExporterCollector collects metrics, mostly runtime, about this exporter in general.
 type ExporterCollector struct {
 	ctx       context.Context
 	logger    *slog.Logger
+	opts      Options
 	version   string
 	revision  string
 	goVersion string
 	startTime time.Time
 
-	StartTime *prometheus.Desc
-	BuildInfo *prometheus.Desc
+	StartTime  *prometheus.Desc
+	BuildInfo  *prometheus.Desc
+	ClientInfo *prometheus.Desc
 }
 
 // logger, Version, Revision, BuildDate, GoVersion, StartTime
 
 // NewExporterCollector returns a new ExporterCollector.
-func NewExporterCollector(ctx context.Context, logger *slog.Logger, version string, revision string, goVersion string, startTime time.Time) *ExporterCollector {
+func NewExporterCollector(ctx context.Context, logger *slog.Logger, opts Options, version string, revision string, goVersion string, startTime time.Time) *ExporterCollector {
 	return &ExporterCollector{
 		ctx:    ctx,
 		logger: logger,
+		opts:   opts,
 
 		version:   version,
 		revision:  revision,
 		goVersion: goVersion,
 		startTime: startTime,
 
-		StartTime: prometheus.NewDesc(
+		StartTime: opts.newDesc(
 			"sakuracloud_exporter_start_time",
 			"Unix timestamp of the start time",
 			nil, nil,
 		),
-		BuildInfo: prometheus.NewDesc(
+		BuildInfo: opts.newDesc(
 			"sakuracloud_exporter_build_info",
 			"A metric with a constant '1' value labeled by version, revision, and branch from which the node_exporter was built.",
 			[]string{"version", "revision", "goversion"}, nil,
 		),
+		ClientInfo: opts.newDesc(
+			"sakuracloud_exporter_client_info",
+			"A metric with a constant '1' value labeled by version, user-agent and SDK version of the SakuraCloud API client",
+			[]string{"version", "user_agent", "sdk_version"}, nil,
+		),
 	}
 }
 
@@ -65,6 +74,7 @@ func NewExporterCollector(ctx context.Context, logger *slog.Logger, version stri
 // collected by this Collector.
 func (c *ExporterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.StartTime
+	ch <- c.ClientInfo
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
@@ -89,4 +99,10 @@ func (c *ExporterCollector) Collect(ch chan<- prometheus.Metric) {
 		1.0,
 		c.version, c.revision, c.goVersion,
 	)
+	ch <- prometheus.MustNewConstMetric(
+		c.ClientInfo,
+		prometheus.GaugeValue,
+		1.0,
+		c.version, platform.UserAgent(c.version), platform.SDKVersion,
+	)
 }
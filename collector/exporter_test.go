@@ -0,0 +1,44 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExporterCollector_ClientInfo(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewExporterCollector(context.Background(), testLogger, Options{}, "1.2.3", "abcdef", "go1.21", time.Unix(1, 0))
+
+	collected, err := collectMetrics(c, "exporter")
+	require.NoError(t, err)
+
+	got := collected.filter(c.ClientInfo)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.ClientInfo,
+			metric: createGaugeMetric(1, map[string]string{
+				"version":     "1.2.3",
+				"user_agent":  "sakuracloud_exporter/1.2.3",
+				"sdk_version": iaas.Version,
+			}),
+		},
+	}, got)
+}
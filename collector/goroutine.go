@@ -0,0 +1,50 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewGoroutineWarning returns a gauge for Options.GoroutineWarning
+func NewGoroutineWarning() *prometheus.GaugeVec {
+	return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "sakuracloud_collector_goroutine_warning",
+		Help: "If 1 the last collect of the collector was estimated to spawn more goroutines than the threshold, 0 otherwise",
+	}, []string{"collector"})
+}
+
+// warnGoroutines reports a warning if the estimated number of goroutines spawned by the Collect exceeds GoroutineWarnThreshold
+func (o Options) warnGoroutines(logger *slog.Logger, collector string, estimated int) {
+	if o.GoroutineWarnThreshold <= 0 {
+		return
+	}
+
+	var warning float64
+	if estimated > o.GoroutineWarnThreshold {
+		warning = 1.0
+		logger.Warn(
+			fmt.Sprintf("collector %q spawns too many goroutines per collect", collector),
+			slog.Int("estimated", estimated),
+			slog.Int("threshold", o.GoroutineWarnThreshold),
+		)
+	}
+	if o.GoroutineWarning != nil {
+		o.GoroutineWarning.WithLabelValues(collector).Set(warning)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoroutineWarning(t *testing.T) {
+	// 2 disks and 1 NIC: 2*2 + 1 + 2 = 7 goroutines per server
+	server := &platform.Server{
+		ZoneName: "is1a",
+		Server: &iaas.Server{
+			ID:   101,
+			Name: "server",
+			Disks: []*iaas.ServerConnectedDisk{
+				{ID: 201},
+				{ID: 202},
+			},
+			Interfaces: []*iaas.InterfaceView{
+				{ID: 301, UpstreamType: types.UpstreamNetworkTypes.Shared},
+			},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		threshold int
+		servers   []*platform.Server
+		want      float64
+	}{
+		{
+			name:      "below the threshold",
+			threshold: 14,
+			servers:   []*platform.Server{server, server},
+			want:      0,
+		},
+		{
+			name:      "crossing the threshold",
+			threshold: 14,
+			servers:   []*platform.Server{server, server, server},
+			want:      1,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		warning := NewGoroutineWarning()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{GoroutineWarnThreshold: tc.threshold, GoroutineWarning: warning}, &dummyServerClient{find: tc.servers}, false, 0)
+		_, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		require.Equal(t, tc.want, testutil.ToFloat64(warning.WithLabelValues("server")), tc.name)
+	}
+}
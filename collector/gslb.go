@@ -0,0 +1,227 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// GSLBCollector collects metrics about all GSLBs.
+type GSLBCollector struct {
+	ctx    context.Context
+	logger *slog.Logger
+	errors *prometheus.CounterVec
+	opts   Options
+	client platform.GSLBClient
+
+	GSLBInfo     *prometheus.Desc
+	ServerUp     *prometheus.Desc
+	ServerWeight *prometheus.Desc
+
+	EndpointHealthyBackends *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
+}
+
+// NewGSLBCollector returns a new GSLBCollector.
+func NewGSLBCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.GSLBClient) *GSLBCollector {
+	errors.WithLabelValues("gslb").Add(0)
+
+	gslbLabels := []string{"id", "name"}
+	gslbInfoLabels := append(gslbLabels, "fqdn", "protocol", "path", "port", "tags", "description")
+	serverLabels := append(gslbLabels, "server_index", "ipaddress")
+
+	return &GSLBCollector{
+		ctx:    ctx,
+		logger: logger,
+		errors: errors,
+		opts:   opts,
+		client: client,
+		GSLBInfo: opts.newDesc(
+			"sakuracloud_gslb_info",
+			"A metric with a constant '1' value labeled by GSLB information",
+			gslbInfoLabels, nil,
+		),
+		ServerUp: opts.newDesc(
+			"sakuracloud_gslb_server_up",
+			"If 1 the destination server is enabled, 0 otherwise",
+			append(serverLabels, "enabled"), nil,
+		),
+		ServerWeight: opts.newDesc(
+			"sakuracloud_gslb_server_weight",
+			"Weight of the destination server for the weighted balancing",
+			serverLabels, nil,
+		),
+		EndpointHealthyBackends: opts.newDesc(
+			"sakuracloud_endpoint_healthy_backends",
+			"The number of the destination servers which are up per FQDN of GSLBs",
+			[]string{"fqdn"}, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("gslb"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("gslb"),
+		CountByTag:      opts.newResourceCountByTagDesc("gslb"),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *GSLBCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.GSLBInfo
+	ch <- c.ServerUp
+	ch <- c.ServerWeight
+	ch <- c.EndpointHealthyBackends
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *GSLBCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *GSLBCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	gslbs, err := c.client.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("gslb").Add(1)
+		c.logger.Warn(
+			"can't list GSLBs",
+			slog.Any("err", err),
+		)
+	}
+
+	tags := make([]types.Tags, 0, len(gslbs))
+	for _, gslb := range gslbs {
+		tags = append(tags, gslb.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	for _, gslb := range gslbs {
+		collectResourceUnnamed(ch, c.Unnamed, gslb.ID, "", gslb.Name)
+		c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, gslb.ID, "", gslb.CreatedAt)
+
+		c.collectGSLBInfo(ch, gslb)
+		for i := range gslb.DestinationServers {
+			c.collectServerInfo(ch, gslb, i)
+		}
+	}
+
+	c.collectEndpointHealthyBackends(ch, gslbs)
+}
+
+func (c *GSLBCollector) gslbLabels(gslb *iaas.GSLB) []string {
+	return []string{
+		gslb.ID.String(),
+		c.opts.truncateLabelValue(gslb.Name),
+	}
+}
+
+func (c *GSLBCollector) collectGSLBInfo(ch chan<- prometheus.Metric, gslb *iaas.GSLB) {
+	var protocol, path, port string
+	if gslb.HealthCheck != nil {
+		protocol = gslb.HealthCheck.Protocol.String()
+		path = gslb.HealthCheck.Path
+		port = gslb.HealthCheck.Port.String()
+	}
+
+	labels := append(c.gslbLabels(gslb),
+		gslb.FQDN,
+		protocol,
+		path,
+		port,
+		c.opts.truncateLabelValue(flattenStringSlice(gslb.Tags)),
+		c.opts.truncateLabelValue(gslb.Description),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.GSLBInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		labels...,
+	)
+}
+
+// collectServerInfo reports the state of the destination server.
+// The GSLB API doesn't provide the result of the health checks, so the up is based on the configured Enabled flag.
+func (c *GSLBCollector) collectServerInfo(ch chan<- prometheus.Metric, gslb *iaas.GSLB, index int) {
+	server := gslb.DestinationServers[index]
+	if server == nil {
+		return
+	}
+	labels := append(c.gslbLabels(gslb),
+		fmt.Sprintf("%d", index),
+		server.IPAddress,
+	)
+
+	var up float64
+	enabled := "0"
+	if server.Enabled.Bool() {
+		up = 1.0
+		enabled = "1"
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.ServerUp,
+		prometheus.GaugeValue,
+		up,
+		append(labels, enabled)...,
+	)
+
+	if gslb.Weighted.Bool() {
+		ch <- prometheus.MustNewConstMetric(
+			c.ServerWeight,
+			prometheus.GaugeValue,
+			server.Weight.Float64(),
+			labels...,
+		)
+	}
+}
+
+// collectEndpointHealthyBackends reports the number of the destination servers which are up per FQDN,
+// so that a single alert can detect the loss of redundancy of a site.
+// The up is the same as sakuracloud_gslb_server_up.
+func (c *GSLBCollector) collectEndpointHealthyBackends(ch chan<- prometheus.Metric, gslbs []*iaas.GSLB) {
+	var fqdns []string
+	healthy := make(map[string]int)
+	for _, gslb := range gslbs {
+		if _, ok := healthy[gslb.FQDN]; !ok {
+			fqdns = append(fqdns, gslb.FQDN)
+			healthy[gslb.FQDN] = 0
+		}
+		for _, server := range gslb.DestinationServers {
+			if server != nil && server.Enabled.Bool() {
+				healthy[gslb.FQDN]++
+			}
+		}
+	}
+
+	for _, fqdn := range fqdns {
+		ch <- prometheus.MustNewConstMetric(
+			c.EndpointHealthyBackends,
+			prometheus.GaugeValue,
+			float64(healthy[fqdn]),
+			fqdn,
+		)
+	}
+}
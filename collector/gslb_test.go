@@ -0,0 +1,259 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyGSLBClient struct {
+	find    []*iaas.GSLB
+	findErr error
+}
+
+func (d *dummyGSLBClient) Find(ctx context.Context) ([]*iaas.GSLB, error) {
+	return d.find, d.findErr
+}
+
+func TestGSLBCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewGSLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyGSLBClient{})
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.GSLBInfo,
+		c.ServerUp,
+		c.ServerWeight,
+		c.EndpointHealthyBackends,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
+	}))
+}
+
+func TestGSLBCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewGSLBCollector(context.Background(), testLogger, testErrors, Options{}, nil)
+
+	cases := []struct {
+		name           string
+		in             platform.GSLBClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "collector returns error",
+			in: &dummyGSLBClient{
+				findErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list GSLBs" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			in:          &dummyGSLBClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a GSLB",
+			in: &dummyGSLBClient{
+				find: []*iaas.GSLB{
+					{
+						ID:          101,
+						Name:        "gslb",
+						Tags:        types.Tags{"tag1", "tag2"},
+						Description: "desc",
+						FQDN:        "site-101.gslb7.example.ne.jp",
+						HealthCheck: &iaas.GSLBHealthCheck{
+							Protocol:     types.GSLBHealthCheckProtocols.HTTP,
+							Path:         "/healthz",
+							ResponseCode: 200,
+							Port:         80,
+						},
+						DestinationServers: iaas.GSLBServers{
+							{IPAddress: "192.0.2.1", Enabled: types.StringTrue},
+							{IPAddress: "192.0.2.2", Enabled: types.StringFalse},
+						},
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.GSLBInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "gslb",
+						"fqdn":        "site-101.gslb7.example.ne.jp",
+						"protocol":    "http",
+						"path":        "/healthz",
+						"port":        "80",
+						"tags":        ",tag1,tag2,",
+						"description": "desc",
+					}),
+				},
+				{
+					desc: c.ServerUp,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":           "101",
+						"name":         "gslb",
+						"server_index": "0",
+						"ipaddress":    "192.0.2.1",
+						"enabled":      "1",
+					}),
+				},
+				{
+					desc: c.ServerUp,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":           "101",
+						"name":         "gslb",
+						"server_index": "1",
+						"ipaddress":    "192.0.2.2",
+						"enabled":      "0",
+					}),
+				},
+				{
+					desc: c.EndpointHealthyBackends,
+					metric: createGaugeMetric(1, map[string]string{
+						"fqdn": "site-101.gslb7.example.ne.jp",
+					}),
+				},
+			},
+		},
+		{
+			name: "a weighted GSLB",
+			in: &dummyGSLBClient{
+				find: []*iaas.GSLB{
+					{
+						ID:       101,
+						Name:     "gslb",
+						FQDN:     "site-101.gslb7.example.ne.jp",
+						Weighted: types.StringTrue,
+						HealthCheck: &iaas.GSLBHealthCheck{
+							Protocol: types.GSLBHealthCheckProtocols.Ping,
+						},
+						DestinationServers: iaas.GSLBServers{
+							{IPAddress: "192.0.2.1", Enabled: types.StringTrue, Weight: 10},
+						},
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.GSLBInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "gslb",
+						"fqdn":        "site-101.gslb7.example.ne.jp",
+						"protocol":    "ping",
+						"path":        "",
+						"port":        "",
+						"tags":        "",
+						"description": "",
+					}),
+				},
+				{
+					desc: c.ServerUp,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":           "101",
+						"name":         "gslb",
+						"server_index": "0",
+						"ipaddress":    "192.0.2.1",
+						"enabled":      "1",
+					}),
+				},
+				{
+					desc: c.ServerWeight,
+					metric: createGaugeMetric(10, map[string]string{
+						"id":           "101",
+						"name":         "gslb",
+						"server_index": "0",
+						"ipaddress":    "192.0.2.1",
+					}),
+				},
+				{
+					desc: c.EndpointHealthyBackends,
+					metric: createGaugeMetric(1, map[string]string{
+						"fqdn": "site-101.gslb7.example.ne.jp",
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = tc.in
+
+		collected, err := collectMetrics(c, "gslb")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
+
+func TestGSLBCollector_EndpointHealthyBackends(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewGSLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyGSLBClient{
+		find: []*iaas.GSLB{
+			{
+				ID:   101,
+				Name: "partial",
+				FQDN: "site-101.gslb7.example.ne.jp",
+				DestinationServers: iaas.GSLBServers{
+					{IPAddress: "192.0.2.1", Enabled: types.StringTrue},
+					{IPAddress: "192.0.2.2", Enabled: types.StringFalse},
+					{IPAddress: "192.0.2.3", Enabled: types.StringTrue},
+				},
+			},
+			{
+				ID:   102,
+				Name: "down",
+				FQDN: "site-102.gslb7.example.ne.jp",
+				DestinationServers: iaas.GSLBServers{
+					{IPAddress: "192.0.2.4", Enabled: types.StringFalse},
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "gslb")
+	require.NoError(t, err)
+
+	endpoints := collected.filter(c.EndpointHealthyBackends)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc:   c.EndpointHealthyBackends,
+			metric: createGaugeMetric(2, map[string]string{"fqdn": "site-101.gslb7.example.ne.jp"}),
+		},
+		{
+			// the site without any healthy backends is reported as 0
+			desc:   c.EndpointHealthyBackends,
+			metric: createGaugeMetric(0, map[string]string{"fqdn": "site-102.gslb7.example.ne.jp"}),
+		},
+	}, endpoints)
+}
@@ -0,0 +1,276 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// HelpLanguageEnglish reports metric help strings in English
+	HelpLanguageEnglish = "en"
+	// HelpLanguageJapanese reports metric help strings in Japanese
+	HelpLanguageJapanese = "ja"
+)
+
+// helpTexts holds the translated help strings of each metric keyed by the metric name and the language.
+//
+// English help strings are written where each Desc is created, and are used when no translation is found.
+var helpTexts = map[string]map[string]string{
+	"sakuracloud_auto_backup_info":                           {HelpLanguageJapanese: "AutoBackupの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_auto_backup_count":                          {HelpLanguageJapanese: "AutoBackupにより作成されたアーカイブの数"},
+	"sakuracloud_auto_backup_last_time":                      {HelpLanguageJapanese: "最終バックアップ日時(1970年からの経過秒数)"},
+	"sakuracloud_auto_backup_archive_info":                   {HelpLanguageJapanese: "バックアップされたアーカイブの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_bill_amount":                                {HelpLanguageJapanese: "当月の請求金額"},
+	"sakuracloud_bridge_info":                                {HelpLanguageJapanese: "ブリッジの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_bridge_switch_count":                        {HelpLanguageJapanese: "ブリッジに接続されたスイッチの数"},
+	"sakuracloud_bridge_zone_switch_count":                   {HelpLanguageJapanese: "ゾーンごとのブリッジに接続されたスイッチの数"},
+	"sakuracloud_certificate_expire_timestamp":               {HelpLanguageJapanese: "証明書の有効期限(1970年からの経過秒数)"},
+	"sakuracloud_coupon_discount":                            {HelpLanguageJapanese: "クーポンの残高"},
+	"sakuracloud_coupon_remaining_days":                      {HelpLanguageJapanese: "クーポンの残り日数"},
+	"sakuracloud_coupon_exp_date":                            {HelpLanguageJapanese: "クーポンの有効期限(1970年からの経過秒数)"},
+	"sakuracloud_coupon_usable":                              {HelpLanguageJapanese: "クーポンが利用可能な場合は1"},
+	"sakuracloud_database_up":                                {HelpLanguageJapanese: "データベースが起動中の場合は1、それ以外は0"},
+	"sakuracloud_database_info":                              {HelpLanguageJapanese: "データベースの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_database_cpus":                              {HelpLanguageJapanese: "データベースのvCPUコア数"},
+	"sakuracloud_database_cpu_time":                          {HelpLanguageJapanese: "データベースのCPU時間(単位:ms)"},
+	"sakuracloud_database_memory_used":                       {HelpLanguageJapanese: "データベースのメモリ使用量(単位:GB)"},
+	"sakuracloud_database_memory_total":                      {HelpLanguageJapanese: "データベースのメモリ総量(単位:GB)"},
+	"sakuracloud_database_memory_utilization":                {HelpLanguageJapanese: "データベースのメモリ総量に対する使用量の割合"},
+	"sakuracloud_database_nic_info":                          {HelpLanguageJapanese: "NICの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_database_nic_receive":                       {HelpLanguageJapanese: "NICの受信トラフィック(単位: %s)"},
+	"sakuracloud_database_nic_send":                          {HelpLanguageJapanese: "NICの送信トラフィック(単位: %s)"},
+	"sakuracloud_database_monitor_sample_timestamp_seconds":  {HelpLanguageJapanese: "モニタAPIから返された最新のCPU時間のサンプルの日時(1970年からの経過秒数)"},
+	"sakuracloud_database_disk_system_used":                  {HelpLanguageJapanese: "データベースのシステムディスク使用量(単位:GB)"},
+	"sakuracloud_database_disk_system_total":                 {HelpLanguageJapanese: "データベースのシステムディスク総量(単位:GB)"},
+	"sakuracloud_database_disk_backup_used":                  {HelpLanguageJapanese: "データベースのバックアップディスク使用量(単位:GB)"},
+	"sakuracloud_database_disk_backup_total":                 {HelpLanguageJapanese: "データベースのバックアップディスク総量(単位:GB)"},
+	"sakuracloud_database_disk_system_utilization":           {HelpLanguageJapanese: "データベースのシステムディスク総量に対する使用量の割合"},
+	"sakuracloud_database_disk_backup_utilization":           {HelpLanguageJapanese: "データベースのバックアップディスク総量に対する使用量の割合"},
+	"sakuracloud_database_binlog_used":                       {HelpLanguageJapanese: "データベースのバイナリログ使用量(単位:GB)"},
+	"sakuracloud_database_binlog_growth_bytes_per_sec":       {HelpLanguageJapanese: "連続する2つのサンプルから算出したデータベースのバイナリログ使用量の増加速度(単位: bytes/sec)"},
+	"sakuracloud_database_disk_read":                         {HelpLanguageJapanese: "ディスクの読み込み量(単位: KBps)"},
+	"sakuracloud_database_disk_write":                        {HelpLanguageJapanese: "ディスクの書き込み量(単位: KBps)"},
+	"sakuracloud_database_replication_delay":                 {HelpLanguageJapanese: "レプリケーションの遅延時間(単位:秒)"},
+	"sakuracloud_database_maintenance_scheduled":             {HelpLanguageJapanese: "データベースにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_database_maintenance_info":                  {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_database_maintenance_start":                 {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_database_maintenance_end":                   {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"sakuracloud_disk_feature":                               {HelpLanguageJapanese: "ディスクで機能が有効な場合は1、それ以外は0"},
+	"sakuracloud_disk_size_ratio":                            {HelpLanguageJapanese: "ディスクプランの最大サイズに対するディスクサイズの割合"},
+	"sakuracloud_disk_autobackup_configured":                 {HelpLanguageJapanese: "ディスクがAutoBackupの対象の場合は1、それ以外は0"},
+	"sakuracloud_duplicate_ip":                               {HelpLanguageJapanese: "同一スイッチ上で同じIPアドレスを持つリソースの数。重複しているアドレスのみ出力されます"},
+	"sakuracloud_dns_info":                                   {HelpLanguageJapanese: "DNSゾーンの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_dns_record_count":                           {HelpLanguageJapanese: "DNSゾーンのレコード種別ごとのレコード数"},
+	"sakuracloud_dns_delegation_ok":                          {HelpLanguageJapanese: "DNSゾーンのNSレコードがさくらのクラウドのネームサーバと一致している場合は1、それ以外は0"},
+	"sakuracloud_esme_info":                                  {HelpLanguageJapanese: "ESMEの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_esme_message_count":                         {HelpLanguageJapanese: "ESMEで処理されたメッセージの数"},
+	"sakuracloud_esme_send_rate":                             {HelpLanguageJapanese: "ウィンドウ期間におけるESMEの1秒あたりの送信メッセージ数"},
+	"sakuracloud_exporter_start_time":                        {HelpLanguageJapanese: "起動日時のUnixタイムスタンプ"},
+	"sakuracloud_exporter_build_info":                        {HelpLanguageJapanese: "ビルド元のバージョン、リビジョン、ブランチをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_exporter_client_info":                       {HelpLanguageJapanese: "SakuraCloud APIクライアントのバージョン、ユーザーエージェント、SDKバージョンをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_gslb_info":                                  {HelpLanguageJapanese: "GSLBの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_gslb_server_up":                             {HelpLanguageJapanese: "実サーバが有効な場合は1、それ以外は0"},
+	"sakuracloud_gslb_server_weight":                         {HelpLanguageJapanese: "重み付け応答における実サーバの重み"},
+	"sakuracloud_endpoint_healthy_backends":                  {HelpLanguageJapanese: "GSLBのFQDNごとの有効な実サーバの数"},
+	"sakuracloud_internet_info":                              {HelpLanguageJapanese: "スイッチ+ルータの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_internet_bandwidth_mbps":                    {HelpLanguageJapanese: "スイッチ+ルータの帯域幅(単位: Mbps)"},
+	"sakuracloud_internet_subnet_count":                      {HelpLanguageJapanese: "スイッチ+ルータに割り当てられたサブネットの数"},
+	"sakuracloud_internet_ipv6_enabled":                      {HelpLanguageJapanese: "スイッチ+ルータでIPv6が有効な場合は1、それ以外は0"},
+	"sakuracloud_internet_ipv6_prefix_info":                  {HelpLanguageJapanese: "IPv6プレフィックスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_internet_receive":                           {HelpLanguageJapanese: "NICの受信量(単位: Kbps)"},
+	"sakuracloud_internet_send":                              {HelpLanguageJapanese: "NICの送信量(単位: Kbps)"},
+	"sakuracloud_loadbalancer_up":                            {HelpLanguageJapanese: "ロードバランサが起動中の場合は1、それ以外は0"},
+	"sakuracloud_loadbalancer_info":                          {HelpLanguageJapanese: "ロードバランサの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_loadbalancer_vrid":                          {HelpLanguageJapanese: "VRIDと接続先スイッチをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_loadbalancer_ha_enabled":                    {HelpLanguageJapanese: "ロードバランサが冗長化されたHA構成の場合は1、それ以外は0"},
+	"sakuracloud_loadbalancer_receive":                       {HelpLanguageJapanese: "ロードバランサの受信トラフィック(単位: %s)"},
+	"sakuracloud_loadbalancer_send":                          {HelpLanguageJapanese: "ロードバランサの送信トラフィック(単位: %s)"},
+	"sakuracloud_loadbalancer_vip_info":                      {HelpLanguageJapanese: "VIPの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_loadbalancer_vip_cps":                       {HelpLanguageJapanese: "1秒あたりのコネクション数"},
+	"sakuracloud_loadbalancer_vip_healthcheck_configured":    {HelpLanguageJapanese: "VIPの全ての実サーバにヘルスチェックが設定されている場合は1、それ以外は0"},
+	"sakuracloud_loadbalancer_server_info":                   {HelpLanguageJapanese: "実サーバの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_loadbalancer_server_up":                     {HelpLanguageJapanese: "実サーバが稼働中の場合は1、それ以外は0"},
+	"sakuracloud_loadbalancer_server_connection":             {HelpLanguageJapanese: "現在のコネクション数"},
+	"sakuracloud_loadbalancer_server_cps":                    {HelpLanguageJapanese: "1秒あたりのコネクション数"},
+	"sakuracloud_loadbalancer_server_expected_response_code": {HelpLanguageJapanese: "実サーバのヘルスチェックで期待するレスポンスコード"},
+	"sakuracloud_loadbalancer_maintenance_scheduled":         {HelpLanguageJapanese: "ロードバランサにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_loadbalancer_maintenance_info":              {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_loadbalancer_maintenance_start":             {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_loadbalancer_maintenance_end":               {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"sakuracloud_local_router_up":                            {HelpLanguageJapanese: "ローカルルータが利用可能な場合は1、それ以外は0"},
+	"sakuracloud_local_router_info":                          {HelpLanguageJapanese: "ローカルルータの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_local_router_switch_info":                   {HelpLanguageJapanese: "ローカルルータの接続先スイッチの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_local_router_network_info":                  {HelpLanguageJapanese: "ローカルルータのネットワーク情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_local_router_peer_info":                     {HelpLanguageJapanese: "ピアの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_local_router_peer_up":                       {HelpLanguageJapanese: "ピアが利用可能な場合は1、それ以外は0"},
+	"sakuracloud_local_router_peer_route_count":              {HelpLanguageJapanese: "ピアから広告されている経路の数"},
+	"sakuracloud_local_router_static_route_info":             {HelpLanguageJapanese: "スタティックルートの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_local_router_receive_per_sec":               {HelpLanguageJapanese: "1秒あたりの受信バイト数"},
+	"sakuracloud_local_router_send_per_sec":                  {HelpLanguageJapanese: "1秒あたりの送信バイト数"},
+	"sakuracloud_mobile_gateway_up":                          {HelpLanguageJapanese: "モバイルゲートウェイが起動中の場合は1、それ以外は0"},
+	"sakuracloud_mobile_gateway_info":                        {HelpLanguageJapanese: "モバイルゲートウェイの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_mobile_gateway_nic_receive":                 {HelpLanguageJapanese: "モバイルゲートウェイの受信トラフィック(単位: %s)"},
+	"sakuracloud_mobile_gateway_nic_send":                    {HelpLanguageJapanese: "モバイルゲートウェイの送信トラフィック(単位: %s)"},
+	"sakuracloud_mobile_gateway_traffic_control_info":        {HelpLanguageJapanese: "トラフィックコントロールの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_mobile_gateway_traffic_uplink":              {HelpLanguageJapanese: "モバイルゲートウェイの上り通信量(単位: KB)"},
+	"sakuracloud_mobile_gateway_traffic_downlink":            {HelpLanguageJapanese: "モバイルゲートウェイの下り通信量(単位: KB)"},
+	"sakuracloud_mobile_gateway_traffic_shaping":             {HelpLanguageJapanese: "通信が帯域制限されている場合は1、それ以外は0"},
+	"sakuracloud_mobile_gateway_quota_exceeded":              {HelpLanguageJapanese: "上り/下りの合計通信量が通信量の上限を超えている場合は1、それ以外は0"},
+	"sakuracloud_mobile_gateway_quota_utilization":           {HelpLanguageJapanese: "通信量の上限に対する上り/下りの合計通信量の割合"},
+	"sakuracloud_mobile_gateway_connected_device_count":      {HelpLanguageJapanese: "モバイルゲートウェイに接続しセッションが確立しているSIMの数"},
+	"sakuracloud_mobile_gateway_maintenance_scheduled":       {HelpLanguageJapanese: "モバイルゲートウェイにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_mobile_gateway_maintenance_info":            {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_mobile_gateway_maintenance_start":           {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_mobile_gateway_maintenance_end":             {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"sakuracloud_nfs_up":                                     {HelpLanguageJapanese: "NFSが起動中の場合は1、それ以外は0"},
+	"sakuracloud_nfs_instance_status":                        {HelpLanguageJapanese: "NFSのインスタンスがそのステータスの場合は1、それ以外は0"},
+	"sakuracloud_nfs_info":                                   {HelpLanguageJapanese: "NFSの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_nfs_free_disk_size":                         {HelpLanguageJapanese: "NFSの空きディスク容量(単位: GB)"},
+	"sakuracloud_nfs_disk_full_eta_seconds":                  {HelpLanguageJapanese: "直近の空きディスク容量の減少から推定したNFSのディスクが一杯になるまでの時間(単位: 秒)"},
+	"sakuracloud_nfs_nic_info":                               {HelpLanguageJapanese: "NICの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_nfs_receive":                                {HelpLanguageJapanese: "NICの受信トラフィック(単位: %s)"},
+	"sakuracloud_nfs_send":                                   {HelpLanguageJapanese: "NICの送信トラフィック(単位: %s)"},
+	"sakuracloud_nfs_monitor_sample_timestamp_seconds":       {HelpLanguageJapanese: "モニタAPIから返された最新のNICのサンプルの日時(1970年からの経過秒数)"},
+	"sakuracloud_nfs_maintenance_scheduled":                  {HelpLanguageJapanese: "NFSにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_nfs_maintenance_info":                       {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_nfs_maintenance_start":                      {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_nfs_maintenance_end":                        {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"sakuracloud_proxylb_up":                                 {HelpLanguageJapanese: "エンハンスドロードバランサが利用可能な場合は1、それ以外は0"},
+	"sakuracloud_proxylb_info":                               {HelpLanguageJapanese: "エンハンスドロードバランサの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_advanced_info":                      {HelpLanguageJapanese: "エンハンスドロードバランサの詳細設定をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_region_info":                        {HelpLanguageJapanese: "エンハンスドロードバランサのリージョンとVIPをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_bind_port_info":                     {HelpLanguageJapanese: "ポート設定の情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_vip_reachable":                      {HelpLanguageJapanese: "VIPのポートへのTCP接続に成功した場合は1、それ以外は0"},
+	"sakuracloud_proxylb_tls_policy":                         {HelpLanguageJapanese: "HTTPSのポート設定のTLSポリシーをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_server_info":                        {HelpLanguageJapanese: "実サーバの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_server_count":                       {HelpLanguageJapanese: "実サーバの数"},
+	"sakuracloud_proxylb_port_mapping":                       {HelpLanguageJapanese: "ポート設定と異なるポートで待ち受ける実サーバの数"},
+	"sakuracloud_proxylb_enabled_server_count":               {HelpLanguageJapanese: "有効な実サーバの数"},
+	"sakuracloud_proxylb_cert_info":                          {HelpLanguageJapanese: "証明書の情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_cert_expire":                        {HelpLanguageJapanese: "証明書の有効期限(1970年からの経過秒数)"},
+	"sakuracloud_proxylb_cert_san":                           {HelpLanguageJapanese: "証明書のサブジェクト代替名をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_proxylb_additional_cert_count":              {HelpLanguageJapanese: "追加証明書の数"},
+	"sakuracloud_proxylb_active_connections":                 {HelpLanguageJapanese: "アクティブなコネクション数"},
+	"sakuracloud_proxylb_connection_per_sec":                 {HelpLanguageJapanese: "1秒あたりのコネクション数"},
+	"sakuracloud_proxylb_plan_cps_capacity":                  {HelpLanguageJapanese: "エンハンスドロードバランサのプランで許容される1秒あたりのコネクション数"},
+	"sakuracloud_proxylb_cps_capacity_ratio":                 {HelpLanguageJapanese: "エンハンスドロードバランサのプランの上限に対する1秒あたりのコネクション数の割合"},
+	"sakuracloud_resource_unnamed":                           {HelpLanguageJapanese: "名前が空または未設定を表す名前のリソースに対する値が常に1のメトリクス"},
+	"sakuracloud_resource_recently_created":                  {HelpLanguageJapanese: "直近の作成期間内に作成されたリソースに対する値が常に1のメトリクス"},
+	"sakuracloud_resource_count_by_tag":                      {HelpLanguageJapanese: "--count-by-tagsで指定したタグキーの値ごとのリソース数"},
+	"sakuracloud_server_hygiene_issues":                      {HelpLanguageJapanese: "サーバのガバナンス上の問題の数(タグなし、自動バックアップなし、名前なし)"},
+	"sakuracloud_server_up":                                  {HelpLanguageJapanese: "サーバが起動中の場合は1、それ以外は0"},
+	"sakuracloud_server_info":                                {HelpLanguageJapanese: "サーバの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_plan_info":                           {HelpLanguageJapanese: "サーバプランの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_transition_stuck":                    {HelpLanguageJapanese: "サーバが閾値を超えて遷移中の状態(cleaningなど)に留まっている場合は1、それ以外は0"},
+	"sakuracloud_server_cpus":                                {HelpLanguageJapanese: "サーバのvCPUコア数"},
+	"sakuracloud_server_cpu_time":                            {HelpLanguageJapanese: "サーバのCPU時間(単位: ms)"},
+	"sakuracloud_server_cdrom_inserted":                      {HelpLanguageJapanese: "サーバに挿入されたISOイメージのIDをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_memories":                            {HelpLanguageJapanese: "サーバのメモリサイズ(単位: GB)"},
+	"sakuracloud_server_disk_info":                           {HelpLanguageJapanese: "ディスクの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_disk_read":                           {HelpLanguageJapanese: "ディスクの読み込み量(単位: KBps)"},
+	"sakuracloud_server_disk_write":                          {HelpLanguageJapanese: "ディスクの書き込み量(単位: KBps)"},
+	"sakuracloud_server_nic_info":                            {HelpLanguageJapanese: "NICの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_nic_bandwidth":                       {HelpLanguageJapanese: "NICの帯域幅(単位: Mbps)"},
+	"sakuracloud_server_nic_receive":                         {HelpLanguageJapanese: "NICの受信トラフィック(単位: %s)"},
+	"sakuracloud_server_nic_send":                            {HelpLanguageJapanese: "NICの送信トラフィック(単位: %s)"},
+	"sakuracloud_server_nic_upstream":                        {HelpLanguageJapanese: "接続先の種別ごとのサーバのNICの数"},
+	"sakuracloud_server_sample_interval_seconds":             {HelpLanguageJapanese: "アクティビティモニタが返すCPU-TIMEの連続するサンプル間の間隔(単位: 秒)"},
+	"sakuracloud_server_monitor_sample_timestamp_seconds":    {HelpLanguageJapanese: "モニタAPIから返された最新のCPU時間のサンプルの日時(1970年からの経過秒数)"},
+	"sakuracloud_server_maintenance_scheduled":               {HelpLanguageJapanese: "サーバにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_server_maintenance_info":                    {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_server_maintenance_start":                   {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_server_maintenance_end":                     {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"sakuracloud_storage_disk_count":                         {HelpLanguageJapanese: "ストレージ上のディスクの数"},
+	"sakuracloud_storage_attached_disk_count":                {HelpLanguageJapanese: "ストレージ上のサーバに接続されたディスクの数"},
+	"sakuracloud_server_mixed_storage_generation":            {HelpLanguageJapanese: "サーバに接続されたディスクが異なる世代のストレージ上にある場合は1、それ以外は0"},
+	"sakuracloud_simplemonitor_up":                           {HelpLanguageJapanese: "シンプル監視の最新のヘルスチェックが成功している場合は1、それ以外は0"},
+	"sakuracloud_simplemonitor_info":                         {HelpLanguageJapanese: "シンプル監視の情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_simplemonitor_latency_seconds":              {HelpLanguageJapanese: "ヘルスチェックの応答時間(単位: 秒)"},
+	"sakuracloud_simplemonitor_availability_ratio":           {HelpLanguageJapanese: "ウィンドウ内で観測したヘルスチェックのうち成功していたものの割合"},
+	"sakuracloud_sim_session_up":                             {HelpLanguageJapanese: "セッションが確立している場合は1、それ以外は0"},
+	"sakuracloud_sim_info":                                   {HelpLanguageJapanese: "SIMの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_sim_uplink":                                 {HelpLanguageJapanese: "上りトラフィック(単位: Kbps)"},
+	"sakuracloud_sim_downlink":                               {HelpLanguageJapanese: "下りトラフィック(単位: Kbps)"},
+	"sakuracloud_sim_session_duration_seconds":               {HelpLanguageJapanese: "現在のデータセッションの開始からの経過時間(単位: 秒)"},
+	"sakuracloud_vpc_router_up":                              {HelpLanguageJapanese: "VPCルータが起動中の場合は1、それ以外は0"},
+	"sakuracloud_vpc_router_session_headroom_ratio":          {HelpLanguageJapanese: "プランのセッション数上限に対する残りセッション数の割合"},
+	"sakuracloud_vpc_router_session":                         {HelpLanguageJapanese: "現在のセッション数"},
+	"sakuracloud_vpc_router_info":                            {HelpLanguageJapanese: "VPCルータの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_software_info":                   {HelpLanguageJapanese: "VPCルータのソフトウェアのバージョンをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_vrid":                            {HelpLanguageJapanese: "VRIDと接続先スイッチをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_cpu_time":                        {HelpLanguageJapanese: "VPCルータのCPU時間(単位: ms)"},
+	"sakuracloud_vpc_router_dhcp_lease":                      {HelpLanguageJapanese: "現在のDHCPサーバのリース数"},
+	"sakuracloud_vpc_router_l2tp_session":                    {HelpLanguageJapanese: "現在のL2TP/IPsecのセッション数"},
+	"sakuracloud_vpc_router_pptp_session":                    {HelpLanguageJapanese: "現在のPPTPのセッション数"},
+	"sakuracloud_vpc_router_l2tp_session_info":               {HelpLanguageJapanese: "L2TP/IPsecのセッションのユーザーとIPアドレスをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_pptp_session_info":               {HelpLanguageJapanese: "PPTPのセッションのユーザーとIPアドレスをラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_s2s_peer_up":                     {HelpLanguageJapanese: "VPCルータのサイト間VPNのピアが接続中の場合は1、それ以外は0"},
+	"sakuracloud_vpc_router_receive":                         {HelpLanguageJapanese: "VPCルータの受信トラフィック(単位: %s)"},
+	"sakuracloud_vpc_router_send":                            {HelpLanguageJapanese: "VPCルータの送信トラフィック(単位: %s)"},
+	"sakuracloud_vpc_router_nic_bandwidth":                   {HelpLanguageJapanese: "プランに応じたNICの帯域幅(単位: Mbps)"},
+	"sakuracloud_vpc_router_static_route_count":              {HelpLanguageJapanese: "スタティックルートの数"},
+	"sakuracloud_vpc_router_firewall_rule_count":             {HelpLanguageJapanese: "全インターフェースの方向ごとのファイアウォールルール数"},
+	"sakuracloud_vpc_router_port_forwarding_count":           {HelpLanguageJapanese: "ポートフォワーディングのルールの数"},
+	"sakuracloud_vpc_router_session_analysis":                {HelpLanguageJapanese: "VPCルータのセッション統計"},
+	"sakuracloud_vpc_router_maintenance_scheduled":           {HelpLanguageJapanese: "VPCルータにメンテナンス予定がある場合は1、それ以外は0"},
+	"sakuracloud_vpc_router_maintenance_info":                {HelpLanguageJapanese: "メンテナンスの情報をラベルに持つ値が常に1のメトリクス"},
+	"sakuracloud_vpc_router_maintenance_start":               {HelpLanguageJapanese: "メンテナンスの開始予定日時(1970年からの経過秒数)"},
+	"sakuracloud_vpc_router_maintenance_end":                 {HelpLanguageJapanese: "メンテナンスの終了予定日時(1970年からの経過秒数)"},
+	"webaccel_site_info":                                     {HelpLanguageJapanese: "id、name、domain_type、domain、subdomainをラベルに持つ値が常に1のメトリクス"},
+	"webaccel_cert_expire":                                   {HelpLanguageJapanese: "証明書の有効期限(1970年からの経過秒数)"},
+	"sakuracloud_zone_info":                                  {HelpLanguageJapanese: "id、name、description、region_id、region_nameをラベルに持つ値が常に1のメトリクス"},
+}
+
+// localizedHelp returns the help string of the metric in HelpLanguage
+func (o Options) localizedHelp(fqName, help string) string {
+	if text, ok := helpTexts[fqName][o.HelpLanguage]; ok {
+		return text
+	}
+	return help
+}
+
+// newDesc is a wrapper of prometheus.NewDesc that selects the help string in HelpLanguage
+func (o Options) newDesc(fqName, help string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	return prometheus.NewDesc(fqName, o.localizedHelp(fqName, help), variableLabels, constLabels)
+}
+
+// newNetworkTrafficDesc is the same as newDesc, but the help string is a format that takes the unit of NIC traffic
+func (o Options) newNetworkTrafficDesc(fqName, helpFormat string, variableLabels []string, constLabels prometheus.Labels) *prometheus.Desc {
+	return prometheus.NewDesc(fqName, fmt.Sprintf(o.localizedHelp(fqName, helpFormat), o.networkTrafficUnit()), variableLabels, constLabels)
+}
+
+// descFQName returns the fully-qualified metric name of the desc, or an empty string if it can't be parsed.
+//
+// prometheus.Desc doesn't expose the name, so it is parsed from the string representation of the desc.
+func descFQName(desc *prometheus.Desc) string {
+	s, ok := strings.CutPrefix(desc.String(), "Desc{fqName: ")
+	if !ok {
+		return ""
+	}
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return ""
+	}
+	name, err := strconv.Unquote(quoted)
+	if err != nil {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,83 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelpLanguage(t *testing.T) {
+	cases := []struct {
+		language    string
+		wantUp      string
+		wantReceive string
+	}{
+		{
+			language:    HelpLanguageEnglish,
+			wantUp:      `help: "If 1 the nfs is up and running, 0 otherwise"`,
+			wantReceive: `help: "NIC's receive traffic(unit: Kbps)"`,
+		},
+		{
+			language:    HelpLanguageJapanese,
+			wantUp:      `help: "NFSが起動中の場合は1、それ以外は0"`,
+			wantReceive: `help: "NICの受信トラフィック(単位: Kbps)"`,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewNFSCollector(context.Background(), testLogger, testErrors, Options{HelpLanguage: tc.language}, &dummyNFSClient{})
+		require.True(t, strings.Contains(c.Up.String(), tc.wantUp), c.Up.String())
+		require.True(t, strings.Contains(c.NICReceive.String(), tc.wantReceive), c.NICReceive.String())
+	}
+}
+
+func TestLocalizedHelp_fallback(t *testing.T) {
+	opts := Options{HelpLanguage: HelpLanguageJapanese}
+	require.Equal(t, "untranslated help", opts.localizedHelp("sakuracloud_untranslated", "untranslated help"))
+}
+
+func TestDescFQName(t *testing.T) {
+	opts := Options{HelpLanguage: HelpLanguageJapanese}
+	cases := []struct {
+		name string
+		desc *prometheus.Desc
+		want string
+	}{
+		{
+			name: "created by newDesc",
+			desc: opts.newDesc("sakuracloud_nfs_up", "help", []string{"id"}, nil),
+			want: "sakuracloud_nfs_up",
+		},
+		{
+			name: "created by prometheus.NewDesc",
+			desc: prometheus.NewDesc("webaccel_site_info", `help with "quotes"`, nil, prometheus.Labels{"resource_type": "site"}),
+			want: "webaccel_site_info",
+		},
+		{
+			name: "invalid desc",
+			desc: prometheus.NewDesc("0invalid", "help", nil, nil),
+			want: "0invalid",
+		},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, descFQName(tc.desc), tc.name)
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Instrumentation collects metrics about the scrapes of wrapped collectors.
+type Instrumentation struct {
+	staleResetThreshold int
+
+	ScrapeGoroutines     *prometheus.GaugeVec
+	ScrapePeakGoroutines *prometheus.GaugeVec
+	ScrapePeakHeapBytes  *prometheus.GaugeVec
+	CollectorReady       *prometheus.GaugeVec
+	StaleScrapes         *prometheus.GaugeVec
+	LastError            *prometheus.GaugeVec
+
+	// mu guards LastError to keep a single message per collector
+	mu sync.Mutex
+}
+
+// resetter is implemented by collectors holding state that can be discarded, such as cached metrics
+type resetter interface {
+	Reset()
+}
+
+// NewInstrumentation returns a new Instrumentation.
+//
+// When a collector has returned no monitor samples for staleResetThreshold consecutive scrapes,
+// its state is reset if it implements Reset(). 0 means never reset.
+func NewInstrumentation(staleResetThreshold int) *Instrumentation {
+	return &Instrumentation{
+		staleResetThreshold: staleResetThreshold,
+
+		ScrapeGoroutines: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_scrape_goroutines",
+			Help: "Peak number of goroutines started during the last scrape of the collector, relative to the start of the scrape",
+		}, []string{"collector"}),
+		ScrapePeakGoroutines: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_scrape_peak_goroutines",
+			Help: "Peak number of goroutines of the exporter sampled during the last scrape of the collector",
+		}, []string{"collector"}),
+		ScrapePeakHeapBytes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_scrape_peak_heap_bytes",
+			Help: "Peak bytes of the heap objects of the exporter sampled during the last scrape of the collector",
+		}, []string{"collector"}),
+		CollectorReady: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_collector_ready",
+			Help: "If 1 the collector has completed its first scrape, 0 otherwise",
+		}, []string{"collector"}),
+		StaleScrapes: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_collector_stale_scrapes",
+			Help: "The number of consecutive scrapes in which the collector returned no monitor samples",
+		}, []string{"collector"}),
+		LastError: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_collector_last_error",
+			Help: "Time of the last error logged by the collector in seconds since epoch (1970), labeled by the truncated error message",
+		}, []string{"collector", "message"}),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (i *Instrumentation) Describe(ch chan<- *prometheus.Desc) {
+	i.ScrapeGoroutines.Describe(ch)
+	i.ScrapePeakGoroutines.Describe(ch)
+	i.ScrapePeakHeapBytes.Describe(ch)
+	i.CollectorReady.Describe(ch)
+	i.StaleScrapes.Describe(ch)
+	i.LastError.Describe(ch)
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (i *Instrumentation) Collect(ch chan<- prometheus.Metric) {
+	i.ScrapeGoroutines.Collect(ch)
+	i.ScrapePeakGoroutines.Collect(ch)
+	i.ScrapePeakHeapBytes.Collect(ch)
+	i.CollectorReady.Collect(ch)
+	i.StaleScrapes.Collect(ch)
+	i.LastError.Collect(ch)
+}
+
+// Wrap returns a collector that records the scrape of the given collector with the name label
+func (i *Instrumentation) Wrap(name string, collector prometheus.Collector) prometheus.Collector {
+	i.ScrapeGoroutines.WithLabelValues(name).Set(0)
+	i.ScrapePeakGoroutines.WithLabelValues(name).Set(0)
+	i.ScrapePeakHeapBytes.WithLabelValues(name).Set(0)
+	i.CollectorReady.WithLabelValues(name).Set(0)
+	i.StaleScrapes.WithLabelValues(name).Set(0)
+	return &instrumentedCollector{
+		name:            name,
+		collector:       collector,
+		instrumentation: i,
+	}
+}
+
+// maxLastErrorMessageLength is the maximum length(in characters) of the message label of sakuracloud_collector_last_error
+const maxLastErrorMessageLength = 128
+
+// Logger returns the logger for the collector with the name label, which records the last error logged by the collector.
+// Records with the warning level or higher are treated as errors.
+func (i *Instrumentation) Logger(name string, logger *slog.Logger) *slog.Logger {
+	return slog.New(&errorRecordingHandler{
+		Handler:         logger.Handler(),
+		name:            name,
+		instrumentation: i,
+	})
+}
+
+func (i *Instrumentation) observeError(name, message string, t time.Time) {
+	runes := []rune(message)
+	if len(runes) > maxLastErrorMessageLength {
+		message = string(runes[:maxLastErrorMessageLength])
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.LastError.DeletePartialMatch(prometheus.Labels{"collector": name})
+	i.LastError.WithLabelValues(name, message).Set(float64(t.Unix()))
+}
+
+// errorRecordingHandler is a slog.Handler which records the error logs to the Instrumentation
+type errorRecordingHandler struct {
+	slog.Handler
+	name            string
+	instrumentation *Instrumentation
+}
+
+func (h *errorRecordingHandler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level >= slog.LevelWarn {
+		message := r.Message
+		r.Attrs(func(attr slog.Attr) bool {
+			if attr.Key == "err" {
+				message = fmt.Sprintf("%s: %s", message, attr.Value)
+				return false
+			}
+			return true
+		})
+		t := r.Time
+		if t.IsZero() {
+			t = time.Now()
+		}
+		h.instrumentation.observeError(h.name, message, t)
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *errorRecordingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &errorRecordingHandler{Handler: h.Handler.WithAttrs(attrs), name: h.name, instrumentation: h.instrumentation}
+}
+
+func (h *errorRecordingHandler) WithGroup(name string) slog.Handler {
+	return &errorRecordingHandler{Handler: h.Handler.WithGroup(name), name: h.name, instrumentation: h.instrumentation}
+}
+
+type instrumentedCollector struct {
+	name            string
+	collector       prometheus.Collector
+	instrumentation *Instrumentation
+
+	mu sync.Mutex
+	// hasSamples is set once the collector returned monitor samples, so that
+	// collectors without monitor metrics(e.g. bill) are never reported as stale
+	hasSamples   bool
+	staleScrapes int
+}
+
+func (c *instrumentedCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+func (c *instrumentedCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, c.collector.Collect)
+}
+
+func (c *instrumentedCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	c.collect(ch, collectFunc(ctx, c.collector))
+}
+
+func (c *instrumentedCollector) collect(ch chan<- prometheus.Metric, collect func(chan<- prometheus.Metric)) {
+	baseline := runtime.NumGoroutine()
+	peak := newScrapePeak()
+
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collect(metrics)
+		close(metrics)
+	}()
+
+	var samples int
+	for m := range metrics {
+		peak.observe()
+		if isMonitorSample(m) {
+			samples++
+		}
+		ch <- m
+	}
+	peak.observe()
+
+	c.instrumentation.ScrapeGoroutines.WithLabelValues(c.name).Set(float64(peak.goroutines - baseline))
+	c.instrumentation.ScrapePeakGoroutines.WithLabelValues(c.name).Set(float64(peak.goroutines))
+	c.instrumentation.ScrapePeakHeapBytes.WithLabelValues(c.name).Set(float64(peak.heapBytes))
+	c.instrumentation.CollectorReady.WithLabelValues(c.name).Set(1)
+	c.observeSamples(samples)
+}
+
+// heapObjectsMetric is the runtime/metrics name of the bytes occupied by the live and not-yet-swept heap objects.
+// Unlike runtime.ReadMemStats, reading it doesn't stop the world, so it can be sampled for each metric.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// scrapePeak records the peak number of goroutines and heap bytes sampled during a scrape
+type scrapePeak struct {
+	goroutines int
+	heapBytes  uint64
+	samples    []metrics.Sample
+}
+
+func newScrapePeak() *scrapePeak {
+	p := &scrapePeak{samples: []metrics.Sample{{Name: heapObjectsMetric}}}
+	p.observe()
+	return p
+}
+
+func (p *scrapePeak) observe() {
+	if n := runtime.NumGoroutine(); n > p.goroutines {
+		p.goroutines = n
+	}
+	metrics.Read(p.samples)
+	if v := p.samples[0].Value; v.Kind() == metrics.KindUint64 && v.Uint64() > p.heapBytes {
+		p.heapBytes = v.Uint64()
+	}
+}
+
+func (c *instrumentedCollector) observeSamples(samples int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if samples > 0 {
+		c.hasSamples = true
+		c.staleScrapes = 0
+	} else if c.hasSamples {
+		c.staleScrapes++
+	}
+	c.instrumentation.StaleScrapes.WithLabelValues(c.name).Set(float64(c.staleScrapes))
+
+	threshold := c.instrumentation.staleResetThreshold
+	if threshold > 0 && c.staleScrapes > 0 && c.staleScrapes%threshold == 0 {
+		if r, ok := c.collector.(resetter); ok {
+			r.Reset()
+		}
+	}
+}
+
+// isMonitorSample reports whether the metric is a sample of the monitor API, which has its own timestamp
+func isMonitorSample(m prometheus.Metric) bool {
+	var pb dto.Metric
+	if err := m.Write(&pb); err != nil {
+		return false
+	}
+	return pb.TimestampMs != nil
+}
@@ -0,0 +1,198 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyFanOutCollector struct {
+	desc  *prometheus.Desc
+	count int
+}
+
+func (d *dummyFanOutCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.desc
+}
+
+func (d *dummyFanOutCollector) Collect(ch chan<- prometheus.Metric) {
+	var wg sync.WaitGroup
+	wg.Add(d.count)
+	for i := 0; i < d.count; i++ {
+		go func() {
+			defer wg.Done()
+			ch <- prometheus.MustNewConstMetric(d.desc, prometheus.GaugeValue, 1)
+		}()
+	}
+	wg.Wait()
+}
+
+func TestInstrumentation_ScrapeGoroutines(t *testing.T) {
+	instrumentation := NewInstrumentation(0)
+	c := instrumentation.Wrap("dummy", &dummyFanOutCollector{
+		desc:  prometheus.NewDesc("sakuracloud_dummy", "dummy", nil, nil),
+		count: 10,
+	})
+	require.Equal(t, float64(0), testutil.ToFloat64(instrumentation.ScrapeGoroutines.WithLabelValues("dummy")))
+
+	initLoggerAndErrors()
+	collected, err := collectMetrics(c, "dummy")
+	require.NoError(t, err)
+	require.Len(t, collected.collected, 10)
+
+	require.Greater(t, testutil.ToFloat64(instrumentation.ScrapeGoroutines.WithLabelValues("dummy")), float64(0))
+}
+
+// dummyHeavyCollector allocates heapBytes and fans out goroutines while collecting
+type dummyHeavyCollector struct {
+	dummyFanOutCollector
+	heapBytes int
+}
+
+func (d *dummyHeavyCollector) Collect(ch chan<- prometheus.Metric) {
+	buf := make([]byte, d.heapBytes)
+	d.dummyFanOutCollector.Collect(ch)
+	runtime.KeepAlive(buf)
+}
+
+func TestInstrumentation_ScrapePeak(t *testing.T) {
+	instrumentation := NewInstrumentation(0)
+	desc := prometheus.NewDesc("sakuracloud_dummy", "dummy", nil, nil)
+	light := instrumentation.Wrap("light", &dummyHeavyCollector{
+		dummyFanOutCollector: dummyFanOutCollector{desc: desc, count: 1},
+	})
+	heavy := instrumentation.Wrap("heavy", &dummyHeavyCollector{
+		dummyFanOutCollector: dummyFanOutCollector{desc: desc, count: 100},
+		heapBytes:            64 << 20,
+	})
+	require.Equal(t, float64(0), testutil.ToFloat64(instrumentation.ScrapePeakGoroutines.WithLabelValues("heavy")))
+	require.Equal(t, float64(0), testutil.ToFloat64(instrumentation.ScrapePeakHeapBytes.WithLabelValues("heavy")))
+
+	initLoggerAndErrors()
+	_, err := collectMetrics(light, "light")
+	require.NoError(t, err)
+	runtime.GC()
+	_, err = collectMetrics(heavy, "heavy")
+	require.NoError(t, err)
+
+	lightGoroutines := testutil.ToFloat64(instrumentation.ScrapePeakGoroutines.WithLabelValues("light"))
+	heavyGoroutines := testutil.ToFloat64(instrumentation.ScrapePeakGoroutines.WithLabelValues("heavy"))
+	require.Greater(t, lightGoroutines, float64(0))
+	require.GreaterOrEqual(t, heavyGoroutines, lightGoroutines+50)
+
+	lightHeap := testutil.ToFloat64(instrumentation.ScrapePeakHeapBytes.WithLabelValues("light"))
+	heavyHeap := testutil.ToFloat64(instrumentation.ScrapePeakHeapBytes.WithLabelValues("heavy"))
+	require.Greater(t, lightHeap, float64(0))
+	require.GreaterOrEqual(t, heavyHeap, float64(64<<20))
+}
+
+func TestInstrumentation_CollectorReady(t *testing.T) {
+	instrumentation := NewInstrumentation(0)
+	c := instrumentation.Wrap("dummy", &dummyFanOutCollector{
+		desc:  prometheus.NewDesc("sakuracloud_dummy", "dummy", nil, nil),
+		count: 1,
+	})
+	require.Equal(t, float64(0), testutil.ToFloat64(instrumentation.CollectorReady.WithLabelValues("dummy")))
+
+	initLoggerAndErrors()
+	_, err := collectMetrics(c, "dummy")
+	require.NoError(t, err)
+
+	require.Equal(t, float64(1), testutil.ToFloat64(instrumentation.CollectorReady.WithLabelValues("dummy")))
+}
+
+// dummyMonitorCollector returns a monitor sample only while hasSample is true
+type dummyMonitorCollector struct {
+	desc      *prometheus.Desc
+	hasSample bool
+	resets    int
+}
+
+func (d *dummyMonitorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- d.desc
+}
+
+func (d *dummyMonitorCollector) Collect(ch chan<- prometheus.Metric) {
+	if !d.hasSample {
+		return
+	}
+	m := prometheus.MustNewConstMetric(d.desc, prometheus.GaugeValue, 1)
+	ch <- prometheus.NewMetricWithTimestamp(time.Unix(1, 0), m)
+}
+
+func (d *dummyMonitorCollector) Reset() {
+	d.resets++
+}
+
+func TestInstrumentation_StaleScrapes(t *testing.T) {
+	instrumentation := NewInstrumentation(2)
+	dummy := &dummyMonitorCollector{
+		desc: prometheus.NewDesc("sakuracloud_dummy", "dummy", nil, nil),
+	}
+	c := instrumentation.Wrap("dummy", NewSkipMetricsCollector(dummy, nil))
+	stale := instrumentation.StaleScrapes.WithLabelValues("dummy")
+
+	initLoggerAndErrors()
+
+	// collectors which have never returned monitor samples are not stale
+	_, err := collectMetrics(c, "dummy")
+	require.NoError(t, err)
+	require.Equal(t, float64(0), testutil.ToFloat64(stale))
+
+	dummy.hasSample = true
+	_, err = collectMetrics(c, "dummy")
+	require.NoError(t, err)
+	require.Equal(t, float64(0), testutil.ToFloat64(stale))
+
+	dummy.hasSample = false
+	for i := 1; i <= 4; i++ {
+		_, err = collectMetrics(c, "dummy")
+		require.NoError(t, err)
+		require.Equal(t, float64(i), testutil.ToFloat64(stale))
+	}
+	require.Equal(t, 2, dummy.resets)
+
+	dummy.hasSample = true
+	_, err = collectMetrics(c, "dummy")
+	require.NoError(t, err)
+	require.Equal(t, float64(0), testutil.ToFloat64(stale))
+}
+
+func TestInstrumentation_LastError(t *testing.T) {
+	i := NewInstrumentation(0)
+	logger := i.Logger("server", slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	logger.Info("not an error")
+	require.Equal(t, 0, testutil.CollectAndCount(i.LastError))
+
+	logger.Warn("can't list servers", slog.Any("err", errors.New("first")))
+	logger.Warn("can't get server's cpu time", slog.Any("err", errors.New(strings.Repeat("x", 200))))
+
+	// only the last error is kept with the truncated message
+	message := "can't get server's cpu time: " + strings.Repeat("x", maxLastErrorMessageLength-len("can't get server's cpu time: "))
+	require.Equal(t, 1, testutil.CollectAndCount(i.LastError))
+	require.InDelta(t, float64(time.Now().Unix()), testutil.ToFloat64(i.LastError.WithLabelValues("server", message)), 1)
+}
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
@@ -30,16 +32,26 @@ type InternetCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.InternetClient
 
-	Info *prometheus.Desc
+	Info        *prometheus.Desc
+	Bandwidth   *prometheus.Desc
+	SubnetCount *prometheus.Desc
+
+	IPv6Enabled    *prometheus.Desc
+	IPv6PrefixInfo *prometheus.Desc
 
 	In  *prometheus.Desc
 	Out *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewInternetCollector returns a new InternetCollector.
-func NewInternetCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.InternetClient) *InternetCollector {
+func NewInternetCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.InternetClient) *InternetCollector {
 	errors.WithLabelValues("internet").Add(0)
 
 	labels := []string{"id", "name", "zone", "switch_id"}
@@ -49,22 +61,46 @@ func NewInternetCollector(ctx context.Context, logger *slog.Logger, errors *prom
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Info: prometheus.NewDesc(
+		Info: opts.newDesc(
 			"sakuracloud_internet_info",
 			"A metric with a constant '1' value labeled by internet information",
-			infoLabels, nil,
+			opts.withRegionLabel(infoLabels), nil,
+		),
+		Bandwidth: opts.newDesc(
+			"sakuracloud_internet_bandwidth_mbps",
+			"Bandwidth of the internet(unit: Mbps)",
+			labels, nil,
+		),
+		SubnetCount: opts.newDesc(
+			"sakuracloud_internet_subnet_count",
+			"The number of subnets attached to the internet",
+			labels, nil,
+		),
+		IPv6Enabled: opts.newDesc(
+			"sakuracloud_internet_ipv6_enabled",
+			"If 1 the internet has IPv6 enabled, 0 otherwise",
+			labels, nil,
 		),
-		In: prometheus.NewDesc(
+		IPv6PrefixInfo: opts.newDesc(
+			"sakuracloud_internet_ipv6_prefix_info",
+			"A metric with a constant '1' value labeled by IPv6 prefix information",
+			append(labels, "prefix", "prefix_len"), nil,
+		),
+		In: opts.newDesc(
 			"sakuracloud_internet_receive",
 			"NIC's receive bytes(unit: Kbps)",
 			labels, nil,
 		),
-		Out: prometheus.NewDesc(
+		Out: opts.newDesc(
 			"sakuracloud_internet_send",
 			"NIC's send bytes(unit: Kbps)",
 			labels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("internet"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("internet"),
+		CountByTag:      opts.newResourceCountByTagDesc("internet"),
 	}
 }
 
@@ -72,13 +108,25 @@ func NewInternetCollector(ctx context.Context, logger *slog.Logger, errors *prom
 // collected by this Collector.
 func (c *InternetCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Info
+	ch <- c.Bandwidth
+	ch <- c.SubnetCount
+	ch <- c.IPv6Enabled
+	ch <- c.IPv6PrefixInfo
 	ch <- c.In
 	ch <- c.Out
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *InternetCollector) Collect(ch chan<- prometheus.Metric) {
-	internets, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *InternetCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	internets, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("internet").Add(1)
 		c.logger.Warn(
@@ -87,24 +135,45 @@ func (c *InternetCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(internets))
+	for _, internet := range internets {
+		tags = append(tags, internet.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
 	var wg sync.WaitGroup
 	wg.Add(len(internets))
 
 	for i := range internets {
 		func(internet *platform.Internet) {
 			defer wg.Done()
+			collectResourceUnnamed(ch, c.Unnamed, internet.ID, internet.ZoneName, internet.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, internet.ID, internet.ZoneName, internet.CreatedAt)
 
 			ch <- prometheus.MustNewConstMetric(
 				c.Info,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.internetInfoLabels(internet)...,
+				c.opts.withRegionLabelValue(c.internetInfoLabels(internet), internet.ZoneName)...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				c.Bandwidth,
+				prometheus.GaugeValue,
+				float64(internet.BandWidthMbps),
+				c.internetLabels(internet)...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				c.SubnetCount,
+				prometheus.GaugeValue,
+				float64(internetSubnetCount(internet)),
+				c.internetLabels(internet)...,
 			)
+			c.collectIPv6Info(ch, internet)
 
 			now := time.Now()
 			wg.Add(1)
 			go func() {
-				c.collectRouterMetrics(ch, internet, now)
+				c.collectRouterMetrics(ctx, ch, internet, now)
 				wg.Done()
 			}()
 		}(internets[i])
@@ -114,25 +183,68 @@ func (c *InternetCollector) Collect(ch chan<- prometheus.Metric) {
 }
 
 func (c *InternetCollector) internetLabels(internet *platform.Internet) []string {
+	switchID := ""
+	if internet.Switch != nil {
+		switchID = internet.Switch.ID.String()
+	}
 	return []string{
 		internet.ID.String(),
-		internet.Name,
+		c.opts.truncateLabelValue(internet.Name),
 		internet.ZoneName,
-		internet.Switch.ID.String(),
+		switchID,
 	}
 }
 
+func internetSubnetCount(internet *platform.Internet) int {
+	if internet.Switch == nil {
+		return 0
+	}
+	return len(internet.Switch.Subnets)
+}
+
 func (c *InternetCollector) internetInfoLabels(internet *platform.Internet) []string {
 	labels := c.internetLabels(internet)
 
 	return append(labels,
 		fmt.Sprintf("%d", internet.BandWidthMbps),
-		flattenStringSlice(internet.Tags),
-		internet.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(internet.Tags)),
+		c.opts.truncateLabelValue(internet.Description),
+	)
+}
+
+func (c *InternetCollector) collectIPv6Info(ch chan<- prometheus.Metric, internet *platform.Internet) {
+	var ipv6Nets []*iaas.IPv6NetInfo
+	if internet.Switch != nil {
+		ipv6Nets = internet.Switch.IPv6Nets
+	}
+
+	var enabled float64
+	if len(ipv6Nets) > 0 {
+		enabled = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.IPv6Enabled,
+		prometheus.GaugeValue,
+		enabled,
+		c.internetLabels(internet)...,
 	)
+
+	for _, ipv6Net := range ipv6Nets {
+		labels := append(c.internetLabels(internet),
+			ipv6Net.IPv6Prefix,
+			fmt.Sprintf("%d", ipv6Net.IPv6PrefixLen),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.IPv6PrefixInfo,
+			prometheus.GaugeValue,
+			float64(1.0),
+			labels...,
+		)
+	}
 }
-func (c *InternetCollector) collectRouterMetrics(ch chan<- prometheus.Metric, internet *platform.Internet, now time.Time) {
-	values, err := c.client.MonitorTraffic(c.ctx, internet.ZoneName, internet.ID, now)
+
+func (c *InternetCollector) collectRouterMetrics(ctx context.Context, ch chan<- prometheus.Metric, internet *platform.Internet, now time.Time) {
+	values, err := c.client.MonitorTraffic(ctx, internet.ZoneName, internet.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("internet").Add(1)
 		c.logger.Warn(
@@ -142,7 +254,10 @@ func (c *InternetCollector) collectRouterMetrics(ch chan<- prometheus.Metric, in
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorRouterValue{Time: now}
 	}
 
 	in := values.In
@@ -44,19 +44,26 @@ func (d *dummyInternetClient) MonitorTraffic(ctx context.Context, zone string, i
 
 func TestInternetCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewInternetCollector(context.Background(), testLogger, testErrors, &dummyInternetClient{})
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{}, &dummyInternetClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Info,
+		c.Bandwidth,
+		c.SubnetCount,
+		c.IPv6Enabled,
+		c.IPv6PrefixInfo,
 		c.In,
 		c.Out,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestInternetCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewInternetCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -118,6 +125,33 @@ func TestInternetCollector_Collect(t *testing.T) {
 						"description": "desc",
 					}),
 				},
+				{
+					desc: c.Bandwidth,
+					metric: createGaugeMetric(100, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.SubnetCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.IPv6Enabled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
 				{
 					desc: c.In,
 					metric: createGaugeWithTimestamp(1, map[string]string{
@@ -138,6 +172,84 @@ func TestInternetCollector_Collect(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "an IPv6 enabled internet router",
+			in: &dummyInternetClient{
+				find: []*platform.Internet{
+					{
+						ZoneName: "is1a",
+						Internet: &iaas.Internet{
+							ID:   101,
+							Name: "internet",
+							Switch: &iaas.SwitchInfo{
+								ID:   201,
+								Name: "switch",
+								IPv6Nets: []*iaas.IPv6NetInfo{
+									{
+										ID:            301,
+										IPv6Prefix:    "2001:db8:11aa:22bb::",
+										IPv6PrefixLen: 64,
+									},
+								},
+							},
+							BandWidthMbps: 100,
+						},
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Info,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "internet",
+						"zone":        "is1a",
+						"switch_id":   "201",
+						"bandwidth":   "100",
+						"tags":        "",
+						"description": "",
+					}),
+				},
+				{
+					desc: c.Bandwidth,
+					metric: createGaugeMetric(100, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.SubnetCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.IPv6Enabled,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "internet",
+						"zone":      "is1a",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.IPv6PrefixInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "internet",
+						"zone":       "is1a",
+						"switch_id":  "201",
+						"prefix":     "2001:db8:11aa:22bb::",
+						"prefix_len": "64",
+					}),
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -153,3 +265,111 @@ func TestInternetCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestInternetCollector_Bandwidth(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{}, &dummyInternetClient{
+		find: []*platform.Internet{
+			{
+				ZoneName: "is1a",
+				Internet: &iaas.Internet{
+					ID:   101,
+					Name: "internet",
+					Switch: &iaas.SwitchInfo{
+						ID:   201,
+						Name: "switch",
+					},
+					BandWidthMbps: 500,
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "internet")
+	require.NoError(t, err)
+
+	got := collected.filter(c.Bandwidth)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.Bandwidth,
+			metric: createGaugeMetric(500, map[string]string{
+				"id":        "101",
+				"name":      "internet",
+				"zone":      "is1a",
+				"switch_id": "201",
+			}),
+		},
+	}, got)
+}
+
+func TestInternetCollector_SubnetCount(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{}, &dummyInternetClient{
+		find: []*platform.Internet{
+			{
+				ZoneName: "is1a",
+				Internet: &iaas.Internet{
+					ID:   101,
+					Name: "internet",
+					Switch: &iaas.SwitchInfo{
+						ID:   201,
+						Name: "switch",
+						Subnets: []*iaas.InternetSubnet{
+							{ID: 301, NetworkAddress: "192.0.2.0", NetworkMaskLen: 28},
+							{ID: 302, NetworkAddress: "198.51.100.0", NetworkMaskLen: 28},
+						},
+					},
+					BandWidthMbps: 100,
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "internet")
+	require.NoError(t, err)
+
+	got := collected.filter(c.SubnetCount)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.SubnetCount,
+			metric: createGaugeMetric(2, map[string]string{
+				"id":        "101",
+				"name":      "internet",
+				"zone":      "is1a",
+				"switch_id": "201",
+			}),
+		},
+	}, got)
+}
+
+func TestInternetCollector_WithoutSwitch(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{}, &dummyInternetClient{
+		find: []*platform.Internet{
+			{
+				ZoneName: "is1a",
+				Internet: &iaas.Internet{
+					ID:            101,
+					Name:          "internet",
+					BandWidthMbps: 100,
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "internet")
+	require.NoError(t, err)
+
+	got := collected.filter(c.SubnetCount)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.SubnetCount,
+			metric: createGaugeMetric(0, map[string]string{
+				"id":        "101",
+				"name":      "internet",
+				"zone":      "is1a",
+				"switch_id": "",
+			}),
+		},
+	}, got)
+}
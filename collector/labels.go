@@ -0,0 +1,70 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// NewLabelsTruncated returns a counter for Options.LabelsTruncated
+func NewLabelsTruncated() prometheus.Counter {
+	return prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "sakuracloud_exporter_labels_truncated_total",
+		Help: "The total number of label values truncated by the maximum label length",
+	})
+}
+
+// truncateLabelValue truncates the label value to MaxLabelLength characters
+func (o Options) truncateLabelValue(v string) string {
+	if o.MaxLabelLength <= 0 {
+		return v
+	}
+	runes := []rune(v)
+	if len(runes) <= o.MaxLabelLength {
+		return v
+	}
+	if o.LabelsTruncated != nil {
+		o.LabelsTruncated.Inc()
+	}
+	return string(runes[:o.MaxLabelLength])
+}
+
+// zoneToRegion returns the region of the zone by stripping the trailing letter. e.g. is1a -> is1
+func zoneToRegion(zone string) string {
+	runes := []rune(zone)
+	if len(runes) < 2 || !unicode.IsLetter(runes[len(runes)-1]) {
+		return zone
+	}
+	return string(runes[:len(runes)-1])
+}
+
+// withRegionLabel appends the region label name if AddRegionLabel is set
+func (o Options) withRegionLabel(labels []string) []string {
+	if !o.AddRegionLabel {
+		return labels
+	}
+	return append(slices.Clip(labels), "region")
+}
+
+// withRegionLabelValue appends the region of the zone to the label values if AddRegionLabel is set
+func (o Options) withRegionLabelValue(values []string, zone string) []string {
+	if !o.AddRegionLabel {
+		return values
+	}
+	return append(values, zoneToRegion(zone))
+}
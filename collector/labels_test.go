@@ -0,0 +1,165 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTruncateLabelValue(t *testing.T) {
+	cases := []struct {
+		name          string
+		maxLength     int
+		in            string
+		want          string
+		wantTruncated float64
+	}{
+		{
+			name:      "unlimited",
+			maxLength: 0,
+			in:        strings.Repeat("a", 100),
+			want:      strings.Repeat("a", 100),
+		},
+		{
+			name:      "shorter than the limit",
+			maxLength: 5,
+			in:        "abc",
+			want:      "abc",
+		},
+		{
+			name:          "over-length",
+			maxLength:     5,
+			in:            "abcdefg",
+			want:          "abcde",
+			wantTruncated: 1,
+		},
+		{
+			name:          "multibyte characters",
+			maxLength:     2,
+			in:            "さくら",
+			want:          "さく",
+			wantTruncated: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		truncated := NewLabelsTruncated()
+		opts := Options{MaxLabelLength: tc.maxLength, LabelsTruncated: truncated}
+
+		require.Equal(t, tc.want, opts.truncateLabelValue(tc.in), tc.name)
+		require.Equal(t, tc.wantTruncated, testutil.ToFloat64(truncated), tc.name)
+	}
+}
+
+func TestMaxLabelLength_Description(t *testing.T) {
+	initLoggerAndErrors()
+	truncated := NewLabelsTruncated()
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{MaxLabelLength: 10, LabelsTruncated: truncated}, &dummyInternetClient{
+		find: []*platform.Internet{
+			{
+				ZoneName: "is1a",
+				Internet: &iaas.Internet{
+					ID:            101,
+					Name:          "internet",
+					Description:   strings.Repeat("long description ", 10),
+					Tags:          types.Tags{"tag1"},
+					Switch:        &iaas.SwitchInfo{ID: 201},
+					BandWidthMbps: 100,
+				},
+			},
+		},
+	})
+	collected, err := collectMetrics(c, "internet")
+	require.NoError(t, err)
+
+	infoMetrics := collected.filter(c.Info)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.Info,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":          "101",
+				"name":        "internet",
+				"zone":        "is1a",
+				"switch_id":   "201",
+				"bandwidth":   "100",
+				"tags":        ",tag1,",
+				"description": "long descr",
+			}),
+		},
+	}, infoMetrics)
+	require.Equal(t, float64(1), testutil.ToFloat64(truncated))
+}
+
+func TestZoneToRegion(t *testing.T) {
+	cases := []struct {
+		zone string
+		want string
+	}{
+		{zone: "is1a", want: "is1"},
+		{zone: "is1b", want: "is1"},
+		{zone: "tk1v", want: "tk1"},
+		{zone: "is1", want: "is1"},
+		{zone: "", want: ""},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, zoneToRegion(tc.zone), tc.zone)
+	}
+}
+
+func TestAddRegionLabel_Info(t *testing.T) {
+	initLoggerAndErrors()
+	// the Descs are built with the region label at the construction
+	c := NewInternetCollector(context.Background(), testLogger, testErrors, Options{AddRegionLabel: true}, &dummyInternetClient{
+		find: []*platform.Internet{
+			{
+				ZoneName: "is1a",
+				Internet: &iaas.Internet{
+					ID:            101,
+					Name:          "internet",
+					Switch:        &iaas.SwitchInfo{ID: 201},
+					BandWidthMbps: 100,
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "internet")
+	require.NoError(t, err)
+
+	infoMetrics := collected.filter(c.Info)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.Info,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":          "101",
+				"name":        "internet",
+				"zone":        "is1a",
+				"switch_id":   "201",
+				"bandwidth":   "100",
+				"tags":        "",
+				"description": "",
+				"region":      "is1",
+			}),
+		},
+	}, infoMetrics)
+}
@@ -34,29 +34,39 @@ type LoadBalancerCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.LoadBalancerClient
 
 	Up               *prometheus.Desc
 	LoadBalancerInfo *prometheus.Desc
+	VRID             *prometheus.Desc
+	HAEnabled        *prometheus.Desc
 	Receive          *prometheus.Desc
 	Send             *prometheus.Desc
 
-	VIPInfo *prometheus.Desc
-	VIPCPS  *prometheus.Desc
+	VIPInfo                  *prometheus.Desc
+	VIPCPS                   *prometheus.Desc
+	VIPHealthCheckConfigured *prometheus.Desc
 
 	ServerInfo       *prometheus.Desc
 	ServerUp         *prometheus.Desc
 	ServerConnection *prometheus.Desc
 	ServerCPS        *prometheus.Desc
 
+	ServerExpectedResponseCode *prometheus.Desc
+
 	MaintenanceScheduled *prometheus.Desc
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewLoadBalancerCollector returns a new LoadBalancerCollector.
-func NewLoadBalancerCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.LoadBalancerClient) *LoadBalancerCollector {
+func NewLoadBalancerCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.LoadBalancerClient) *LoadBalancerCollector {
 	errors.WithLabelValues("loadbalancer").Add(0)
 
 	lbLabels := []string{"id", "name", "zone"}
@@ -70,77 +80,101 @@ func NewLoadBalancerCollector(ctx context.Context, logger *slog.Logger, errors *
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+		Up: opts.newDesc(
 			"sakuracloud_loadbalancer_up",
 			"If 1 the loadbalancer is up and running, 0 otherwise",
 			lbLabels, nil,
 		),
-		LoadBalancerInfo: prometheus.NewDesc(
+		LoadBalancerInfo: opts.newDesc(
 			"sakuracloud_loadbalancer_info",
 			"A metric with a constant '1' value labeled by loadbalancer information",
-			lbInfoLabels, nil,
+			opts.withRegionLabel(lbInfoLabels), nil,
 		),
-		Receive: prometheus.NewDesc(
+		VRID: opts.newDesc(
+			"sakuracloud_loadbalancer_vrid",
+			"A metric with a constant '1' value labeled by VRID and the connected switch",
+			append(lbLabels, "vrid", "switch_id"), nil,
+		),
+		HAEnabled: opts.newDesc(
+			"sakuracloud_loadbalancer_ha_enabled",
+			"If 1 the loadbalancer is a redundant HA pair, 0 otherwise",
+			lbLabels, nil,
+		),
+		Receive: opts.newNetworkTrafficDesc(
 			"sakuracloud_loadbalancer_receive",
-			"Loadbalancer's receive bytes(unit: Kbps)",
+			"Loadbalancer's receive traffic(unit: %s)",
 			lbLabels, nil,
 		),
-		Send: prometheus.NewDesc(
+		Send: opts.newNetworkTrafficDesc(
 			"sakuracloud_loadbalancer_send",
-			"Loadbalancer's receive bytes(unit: Kbps)",
+			"Loadbalancer's send traffic(unit: %s)",
 			lbLabels, nil,
 		),
-		VIPInfo: prometheus.NewDesc(
+		VIPInfo: opts.newDesc(
 			"sakuracloud_loadbalancer_vip_info",
 			"A metric with a constant '1' value labeld by vip information",
 			vipInfoLabels, nil,
 		),
-		VIPCPS: prometheus.NewDesc(
+		VIPCPS: opts.newDesc(
 			"sakuracloud_loadbalancer_vip_cps",
 			"Connection count per second",
 			vipLabels, nil,
 		),
-		ServerInfo: prometheus.NewDesc(
+		VIPHealthCheckConfigured: opts.newDesc(
+			"sakuracloud_loadbalancer_vip_healthcheck_configured",
+			"If 1 all real-servers of the vip have a health check, 0 otherwise",
+			vipLabels, nil,
+		),
+		ServerInfo: opts.newDesc(
 			"sakuracloud_loadbalancer_server_info",
 			"A metric with a constant '1' value labeld by real-server information",
 			serverInfoLabels, nil,
 		),
-		ServerUp: prometheus.NewDesc(
+		ServerUp: opts.newDesc(
 			"sakuracloud_loadbalancer_server_up",
 			"If 1 the server is up and running, 0 otherwise",
 			serverLabels, nil,
 		),
-		ServerConnection: prometheus.NewDesc(
+		ServerConnection: opts.newDesc(
 			"sakuracloud_loadbalancer_server_connection",
 			"Current connection count",
 			serverLabels, nil,
 		),
-		ServerCPS: prometheus.NewDesc(
+		ServerCPS: opts.newDesc(
 			"sakuracloud_loadbalancer_server_cps",
 			"Connection count per second",
 			serverLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		ServerExpectedResponseCode: opts.newDesc(
+			"sakuracloud_loadbalancer_server_expected_response_code",
+			"Expected response code of the real-server's health check",
+			serverLabels, nil,
+		),
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_loadbalancer_maintenance_scheduled",
 			"If 1 the loadbalancer has scheduled maintenance info, 0 otherwise",
 			lbLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_loadbalancer_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			append(lbLabels, "info_url", "info_title", "description", "start_date", "end_date"), nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_loadbalancer_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			lbLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_loadbalancer_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			lbLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("loadbalancer"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("loadbalancer"),
+		CountByTag:      opts.newResourceCountByTagDesc("loadbalancer"),
 	}
 }
 
@@ -149,24 +183,36 @@ func NewLoadBalancerCollector(ctx context.Context, logger *slog.Logger, errors *
 func (c *LoadBalancerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
 	ch <- c.LoadBalancerInfo
+	ch <- c.VRID
+	ch <- c.HAEnabled
 	ch <- c.Receive
 	ch <- c.Send
 	ch <- c.VIPInfo
 	ch <- c.VIPCPS
+	ch <- c.VIPHealthCheckConfigured
 	ch <- c.ServerInfo
 	ch <- c.ServerUp
 	ch <- c.ServerConnection
 	ch <- c.ServerCPS
+	ch <- c.ServerExpectedResponseCode
 
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
-	lbs, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *LoadBalancerCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	lbs, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("loadbalancer").Add(1)
 		c.logger.Warn(
@@ -175,6 +221,15 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(lbs))
+	for _, lb := range lbs {
+		tags = append(tags, lb.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	// status, NICs and maintenance info for each loadbalancer
+	c.opts.warnGoroutines(c.logger, "loadbalancer", len(lbs)*3)
+
 	var wg sync.WaitGroup
 	wg.Add(len(lbs))
 
@@ -183,6 +238,8 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			lbLabels := c.lbLabels(lb)
+			collectResourceUnnamed(ch, c.Unnamed, lb.ID, lb.ZoneName, lb.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, lb.ID, lb.ZoneName, lb.CreatedAt)
 
 			var up float64
 			if lb.InstanceStatus.IsUp() {
@@ -198,7 +255,23 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 				c.LoadBalancerInfo,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.lbInfoLabels(lb)...,
+				c.opts.withRegionLabelValue(c.lbInfoLabels(lb), lb.ZoneName)...,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				c.VRID,
+				prometheus.GaugeValue,
+				float64(1.0),
+				c.vridLabels(lb)...,
+			)
+			var haEnabled float64
+			if isHALoadBalancer(lb) {
+				haEnabled = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				c.HAEnabled,
+				prometheus.GaugeValue,
+				haEnabled,
+				lbLabels...,
 			)
 			for vipIndex := range lb.VirtualIPAddresses {
 				ch <- prometheus.MustNewConstMetric(
@@ -207,6 +280,29 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 					float64(1.0),
 					c.vipInfoLabels(lb, vipIndex)...,
 				)
+
+				healthCheckConfigured := float64(0.0)
+				if isVIPHealthCheckConfigured(lb.VirtualIPAddresses[vipIndex]) {
+					healthCheckConfigured = 1.0
+				}
+				ch <- prometheus.MustNewConstMetric(
+					c.VIPHealthCheckConfigured,
+					prometheus.GaugeValue,
+					healthCheckConfigured,
+					c.vipLabels(lb, vipIndex)...,
+				)
+
+				for serverIndex, server := range lb.VirtualIPAddresses[vipIndex].Servers {
+					// only http/https health checks have the response code
+					if server.HealthCheck != nil && server.HealthCheck.ResponseCode.Int() > 0 {
+						ch <- prometheus.MustNewConstMetric(
+							c.ServerExpectedResponseCode,
+							prometheus.GaugeValue,
+							server.HealthCheck.ResponseCode.Float64(),
+							c.serverLabels(lb, vipIndex, serverIndex)...,
+						)
+					}
+				}
 			}
 
 			if lb.Availability.IsAvailable() && lb.InstanceStatus.IsUp() {
@@ -215,14 +311,14 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 				// NIC(Receive/Send)
 				wg.Add(1)
 				go func() {
-					c.collectNICMetrics(ch, lb, now)
+					c.collectNICMetrics(ctx, ch, lb, now)
 					wg.Done()
 				}()
 
 				// VIP/Server status
 				wg.Add(1)
 				go func() {
-					c.collectLBStatus(ch, lb)
+					c.collectLBStatus(ctx, ch, lb)
 					wg.Done()
 				}()
 
@@ -252,7 +348,7 @@ func (c *LoadBalancerCollector) Collect(ch chan<- prometheus.Metric) {
 func (c *LoadBalancerCollector) lbLabels(lb *platform.LoadBalancer) []string {
 	return []string{
 		lb.ID.String(),
-		lb.Name,
+		c.opts.truncateLabelValue(lb.Name),
 		lb.ZoneName,
 	}
 }
@@ -262,11 +358,17 @@ var loadBalancerPlanMapping = map[types.ID]string{
 	types.LoadBalancerPlans.HighSpec: "highspec",
 }
 
+// isHALoadBalancer reports whether the loadbalancer is a HA pair.
+// The active/standby state of each node is not exposed by the API, so this is inferred from the plan.
+func isHALoadBalancer(lb *platform.LoadBalancer) bool {
+	return lb.PlanID == types.LoadBalancerPlans.HighSpec
+}
+
 func (c *LoadBalancerCollector) lbInfoLabels(lb *platform.LoadBalancer) []string {
 	labels := c.lbLabels(lb)
 
 	isHA := "0"
-	if lb.PlanID == types.LoadBalancerPlans.HighSpec {
+	if isHALoadBalancer(lb) {
 		isHA = "1"
 	}
 
@@ -283,8 +385,16 @@ func (c *LoadBalancerCollector) lbInfoLabels(lb *platform.LoadBalancer) []string
 		ipaddress2,
 		lb.DefaultRoute,
 		fmt.Sprintf("%d", lb.NetworkMaskLen),
-		flattenStringSlice(lb.Tags),
-		lb.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(lb.Tags)),
+		c.opts.truncateLabelValue(lb.Description),
+	)
+}
+
+func (c *LoadBalancerCollector) vridLabels(lb *platform.LoadBalancer) []string {
+	labels := c.lbLabels(lb)
+	return append(labels,
+		fmt.Sprintf("%d", lb.VRID),
+		lb.SwitchID.String(),
 	)
 }
 
@@ -309,10 +419,23 @@ func (c *LoadBalancerCollector) vipInfoLabels(lb *platform.LoadBalancer, index i
 		vip.Port.String(),
 		vip.DelayLoop.String(),
 		vip.SorryServer,
-		vip.Description,
+		c.opts.truncateLabelValue(vip.Description),
 	)
 }
 
+// isVIPHealthCheckConfigured returns true if the vip has real-servers and all of them have a health check
+func isVIPHealthCheckConfigured(vip *iaas.LoadBalancerVirtualIPAddress) bool {
+	if len(vip.Servers) == 0 {
+		return false
+	}
+	for _, server := range vip.Servers {
+		if server.HealthCheck == nil || server.HealthCheck.Protocol == "" {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *LoadBalancerCollector) serverLabels(lb *platform.LoadBalancer, vipIndex int, serverIndex int) []string {
 	if len(lb.VirtualIPAddresses) < vipIndex {
 		return nil
@@ -341,6 +464,9 @@ func (c *LoadBalancerCollector) serverInfoLabels(lb *platform.LoadBalancer, vipI
 	server := vip.Servers[serverIndex]
 
 	labels := c.serverLabels(lb, vipIndex, serverIndex)
+	if server.HealthCheck == nil {
+		return append(labels, "", "", "")
+	}
 	return append(labels,
 		string(server.HealthCheck.Protocol),
 		server.HealthCheck.Path,
@@ -348,8 +474,8 @@ func (c *LoadBalancerCollector) serverInfoLabels(lb *platform.LoadBalancer, vipI
 	)
 }
 
-func (c *LoadBalancerCollector) collectNICMetrics(ch chan<- prometheus.Metric, lb *platform.LoadBalancer, now time.Time) {
-	values, err := c.client.MonitorNIC(c.ctx, lb.ZoneName, lb.ID, now)
+func (c *LoadBalancerCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, lb *platform.LoadBalancer, now time.Time) {
+	values, err := c.client.MonitorNIC(ctx, lb.ZoneName, lb.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("loadbalancer").Add(1)
 		c.logger.Warn(
@@ -359,12 +485,15 @@ func (c *LoadBalancerCollector) collectNICMetrics(ch chan<- prometheus.Metric, l
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
 	}
 
 	receive := values.Receive
 	if receive > 0 {
-		receive = receive * 8 / 1000
+		receive = c.opts.convertNetworkTraffic(receive)
 	}
 	m := prometheus.MustNewConstMetric(
 		c.Receive,
@@ -376,7 +505,7 @@ func (c *LoadBalancerCollector) collectNICMetrics(ch chan<- prometheus.Metric, l
 
 	send := values.Send
 	if send > 0 {
-		send = send * 8 / 1000
+		send = c.opts.convertNetworkTraffic(send)
 	}
 	m = prometheus.MustNewConstMetric(
 		c.Send,
@@ -405,8 +534,8 @@ func getServerStatus(status []*iaas.LoadBalancerServerStatus, ip string) *iaas.L
 	return nil
 }
 
-func (c *LoadBalancerCollector) collectLBStatus(ch chan<- prometheus.Metric, lb *platform.LoadBalancer) {
-	status, err := c.client.Status(c.ctx, lb.ZoneName, lb.ID)
+func (c *LoadBalancerCollector) collectLBStatus(ctx context.Context, ch chan<- prometheus.Metric, lb *platform.LoadBalancer) {
+	status, err := c.client.Status(ctx, lb.ZoneName, lb.ID)
 	if err != nil {
 		c.errors.WithLabelValues("loadbalancer").Add(1)
 		c.logger.Warn(
@@ -424,7 +553,7 @@ func (c *LoadBalancerCollector) collectLBStatus(ch chan<- prometheus.Metric, lb
 	}
 	for vipIndex, vip := range lb.VirtualIPAddresses {
 		if vip.Servers == nil {
-			return
+			continue
 		}
 		vipStatus := getVIPStatus(status, vip.VirtualIPAddress)
 		if vipStatus == nil {
@@ -483,7 +612,7 @@ func (c *LoadBalancerCollector) maintenanceInfoLabels(resource *platform.LoadBal
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
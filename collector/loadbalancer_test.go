@@ -55,30 +55,37 @@ func (d *dummyLoadBalancerClient) MaintenanceInfo(infoURL string) (*newsfeed.Fee
 
 func TestLoadBalancerCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, &dummyLoadBalancerClient{})
+	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyLoadBalancerClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
 		c.LoadBalancerInfo,
+		c.VRID,
+		c.HAEnabled,
 		c.Receive,
 		c.Send,
 		c.VIPInfo,
 		c.VIPCPS,
+		c.VIPHealthCheckConfigured,
 		c.ServerInfo,
 		c.ServerUp,
 		c.ServerConnection,
 		c.ServerCPS,
+		c.ServerExpectedResponseCode,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestLoadBalancerCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -115,6 +122,7 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 							Description:    "desc",
 							PlanID:         types.LoadBalancerPlans.Standard,
 							VRID:           1,
+							SwitchID:       201,
 							IPAddresses:    []string{"192.168.0.11"},
 							DefaultRoute:   "192.168.0.1",
 							NetworkMaskLen: 24,
@@ -150,6 +158,24 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description": "desc",
 					}),
 				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.HAEnabled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -173,6 +199,7 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 							Description:    "desc",
 							PlanID:         types.LoadBalancerPlans.HighSpec,
 							VRID:           1,
+							SwitchID:       201,
 							IPAddresses:    []string{"192.168.0.11", "192.168.0.12"},
 							DefaultRoute:   "192.168.0.1",
 							NetworkMaskLen: 24,
@@ -250,6 +277,24 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description": "desc",
 					}),
 				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.HAEnabled,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.VIPInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -264,6 +309,16 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description":  "vip-desc",
 					}),
 				},
+				{
+					desc: c.VIPHealthCheckConfigured,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vip_index": "0",
+						"vip":       "192.168.0.101",
+					}),
+				},
 				{
 					desc: c.Receive,
 					metric: createGaugeWithTimestamp(float64(100)*8/1000, map[string]string{
@@ -341,6 +396,234 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"ipaddress":    "192.168.0.201",
 					}),
 				},
+				{
+					desc: c.ServerExpectedResponseCode,
+					metric: createGaugeMetric(200, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"server_index": "0",
+						"ipaddress":    "192.168.0.201",
+					}),
+				},
+				{
+					desc: c.MaintenanceScheduled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
+			},
+		},
+		{
+			name: "a load balancer with a real-server without health check",
+			in: &dummyLoadBalancerClient{
+				find: []*platform.LoadBalancer{
+					{
+						ZoneName: "is1a",
+						LoadBalancer: &iaas.LoadBalancer{
+							ID:             101,
+							Name:           "loadbalancer",
+							Tags:           types.Tags{"tag1", "tag2"},
+							Description:    "desc",
+							PlanID:         types.LoadBalancerPlans.HighSpec,
+							VRID:           1,
+							SwitchID:       201,
+							IPAddresses:    []string{"192.168.0.11", "192.168.0.12"},
+							DefaultRoute:   "192.168.0.1",
+							NetworkMaskLen: 24,
+							Availability:   types.Availabilities.Available,
+							InstanceStatus: types.ServerInstanceStatuses.Up,
+							VirtualIPAddresses: []*iaas.LoadBalancerVirtualIPAddress{
+								{
+									VirtualIPAddress: "192.168.0.101",
+									Port:             80,
+									DelayLoop:        100,
+									SorryServer:      "192.168.0.21",
+									Description:      "vip-desc",
+									Servers: []*iaas.LoadBalancerServer{
+										{
+											IPAddress:   "192.168.0.201",
+											Port:        80,
+											Enabled:     true,
+											HealthCheck: nil,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				status: []*iaas.LoadBalancerStatus{
+					{
+						VirtualIPAddress: "192.168.0.101",
+						Port:             80,
+						CPS:              100,
+						Servers: []*iaas.LoadBalancerServerStatus{
+							{
+								IPAddress:  "192.168.0.201",
+								Port:       80,
+								Status:     types.ServerInstanceStatuses.Up,
+								CPS:        200,
+								ActiveConn: 300,
+							},
+						},
+					},
+				},
+				monitor: &iaas.MonitorInterfaceValue{
+					Time:    monitorTime,
+					Receive: 100,
+					Send:    200,
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Up,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.LoadBalancerInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "loadbalancer",
+						"zone":        "is1a",
+						"plan":        "highspec",
+						"ha":          "1",
+						"vrid":        "1",
+						"ipaddress1":  "192.168.0.11",
+						"ipaddress2":  "192.168.0.12",
+						"gateway":     "192.168.0.1",
+						"nw_mask_len": "24",
+						"tags":        ",tag1,tag2,",
+						"description": "desc",
+					}),
+				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.HAEnabled,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.VIPInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"port":         "80",
+						"interval":     "100",
+						"sorry_server": "192.168.0.21",
+						"description":  "vip-desc",
+					}),
+				},
+				{
+					desc: c.VIPHealthCheckConfigured,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vip_index": "0",
+						"vip":       "192.168.0.101",
+					}),
+				},
+				{
+					desc: c.Receive,
+					metric: createGaugeWithTimestamp(float64(100)*8/1000, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}, monitorTime),
+				},
+				{
+					desc: c.Send,
+					metric: createGaugeWithTimestamp(float64(200)*8/1000, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}, monitorTime),
+				},
+				{
+					desc: c.VIPCPS,
+					metric: createGaugeMetric(100, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vip_index": "0",
+						"vip":       "192.168.0.101",
+					}),
+				},
+				{
+					desc: c.ServerInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":            "101",
+						"name":          "loadbalancer",
+						"zone":          "is1a",
+						"vip_index":     "0",
+						"vip":           "192.168.0.101",
+						"server_index":  "0",
+						"ipaddress":     "192.168.0.201",
+						"monitor":       "",
+						"path":          "",
+						"response_code": "",
+					}),
+				},
+				{
+					desc: c.ServerUp,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"server_index": "0",
+						"ipaddress":    "192.168.0.201",
+					}),
+				},
+				{
+					desc: c.ServerCPS,
+					metric: createGaugeMetric(200, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"server_index": "0",
+						"ipaddress":    "192.168.0.201",
+					}),
+				},
+				{
+					desc: c.ServerConnection,
+					metric: createGaugeMetric(300, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"server_index": "0",
+						"ipaddress":    "192.168.0.201",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -364,6 +647,7 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 							Description:    "desc",
 							PlanID:         types.LoadBalancerPlans.HighSpec,
 							VRID:           1,
+							SwitchID:       201,
 							IPAddresses:    []string{"192.168.0.11", "192.168.0.12"},
 							DefaultRoute:   "192.168.0.1",
 							NetworkMaskLen: 24,
@@ -422,6 +706,24 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description": "desc",
 					}),
 				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.HAEnabled,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.VIPInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -436,6 +738,28 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description":  "vip-desc",
 					}),
 				},
+				{
+					desc: c.VIPHealthCheckConfigured,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vip_index": "0",
+						"vip":       "192.168.0.101",
+					}),
+				},
+				{
+					desc: c.ServerExpectedResponseCode,
+					metric: createGaugeMetric(200, map[string]string{
+						"id":           "101",
+						"name":         "loadbalancer",
+						"zone":         "is1a",
+						"vip_index":    "0",
+						"vip":          "192.168.0.101",
+						"server_index": "0",
+						"ipaddress":    "192.168.0.201",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -464,6 +788,7 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 							Description:         "desc",
 							PlanID:              types.LoadBalancerPlans.Standard,
 							VRID:                1,
+							SwitchID:            201,
 							IPAddresses:         []string{"192.168.0.11"},
 							DefaultRoute:        "192.168.0.1",
 							NetworkMaskLen:      24,
@@ -508,6 +833,24 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 						"description": "desc",
 					}),
 				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "loadbalancer",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "201",
+					}),
+				},
+				{
+					desc: c.HAEnabled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "loadbalancer",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(1, map[string]string{
@@ -562,3 +905,209 @@ func TestLoadBalancerCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestLoadBalancerCollector_VIPHealthCheckConfigured(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyLoadBalancerClient{
+		find: []*platform.LoadBalancer{
+			{
+				ZoneName: "is1a",
+				LoadBalancer: &iaas.LoadBalancer{
+					ID:          101,
+					Name:        "loadbalancer",
+					IPAddresses: []string{"192.168.0.11"},
+					VirtualIPAddresses: []*iaas.LoadBalancerVirtualIPAddress{
+						{
+							VirtualIPAddress: "192.168.0.101",
+							Servers: []*iaas.LoadBalancerServer{
+								{
+									IPAddress: "192.168.0.201",
+									HealthCheck: &iaas.LoadBalancerServerHealthCheck{
+										Protocol: types.LoadBalancerHealthCheckProtocols.TCP,
+									},
+								},
+								{
+									IPAddress:   "192.168.0.202",
+									HealthCheck: nil,
+								},
+							},
+						},
+						{
+							VirtualIPAddress: "192.168.0.102",
+							Servers: []*iaas.LoadBalancerServer{
+								{
+									IPAddress: "192.168.0.203",
+									HealthCheck: &iaas.LoadBalancerServerHealthCheck{
+										Protocol: types.LoadBalancerHealthCheckProtocols.Ping,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "loadbalancer")
+	require.NoError(t, err)
+
+	got := collected.filter(c.VIPHealthCheckConfigured)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.VIPHealthCheckConfigured,
+			metric: createGaugeMetric(0, map[string]string{
+				"id":        "101",
+				"name":      "loadbalancer",
+				"zone":      "is1a",
+				"vip_index": "0",
+				"vip":       "192.168.0.101",
+			}),
+		},
+		{
+			desc: c.VIPHealthCheckConfigured,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":        "101",
+				"name":      "loadbalancer",
+				"zone":      "is1a",
+				"vip_index": "1",
+				"vip":       "192.168.0.102",
+			}),
+		},
+	}, got)
+}
+
+func TestLoadBalancerCollector_HAEnabled(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyLoadBalancerClient{
+		find: []*platform.LoadBalancer{
+			{
+				ZoneName: "is1a",
+				LoadBalancer: &iaas.LoadBalancer{
+					ID:          101,
+					Name:        "standard",
+					PlanID:      types.LoadBalancerPlans.Standard,
+					IPAddresses: []string{"192.168.0.11"},
+				},
+			},
+			{
+				ZoneName: "is1a",
+				LoadBalancer: &iaas.LoadBalancer{
+					ID:          102,
+					Name:        "highspec",
+					PlanID:      types.LoadBalancerPlans.HighSpec,
+					IPAddresses: []string{"192.168.0.12", "192.168.0.13"},
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "loadbalancer")
+	require.NoError(t, err)
+
+	got := collected.filter(c.HAEnabled)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.HAEnabled,
+			metric: createGaugeMetric(0, map[string]string{
+				"id":   "101",
+				"name": "standard",
+				"zone": "is1a",
+			}),
+		},
+		{
+			desc: c.HAEnabled,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":   "102",
+				"name": "highspec",
+				"zone": "is1a",
+			}),
+		},
+	}, got)
+}
+
+func TestLoadBalancerCollector_VIPWithoutServers(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewLoadBalancerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyLoadBalancerClient{
+		find: []*platform.LoadBalancer{
+			{
+				ZoneName: "is1a",
+				LoadBalancer: &iaas.LoadBalancer{
+					ID:             101,
+					Name:           "loadbalancer",
+					PlanID:         types.LoadBalancerPlans.Standard,
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+					IPAddresses:    []string{"192.168.0.11"},
+					VirtualIPAddresses: []*iaas.LoadBalancerVirtualIPAddress{
+						{
+							VirtualIPAddress: "192.168.0.101",
+							Port:             80,
+						},
+						{
+							VirtualIPAddress: "192.168.0.102",
+							Port:             80,
+							Servers: []*iaas.LoadBalancerServer{
+								{
+									IPAddress: "192.168.0.201",
+									Port:      80,
+									Enabled:   true,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		status: []*iaas.LoadBalancerStatus{
+			{
+				VirtualIPAddress: "192.168.0.101",
+				Port:             80,
+				CPS:              100,
+			},
+			{
+				VirtualIPAddress: "192.168.0.102",
+				Port:             80,
+				CPS:              200,
+				Servers: []*iaas.LoadBalancerServerStatus{
+					{
+						IPAddress:  "192.168.0.201",
+						Port:       80,
+						Status:     types.ServerInstanceStatuses.Up,
+						CPS:        300,
+						ActiveConn: 400,
+					},
+				},
+			},
+		},
+	})
+
+	collected, err := collectMetrics(c, "loadbalancer")
+	require.NoError(t, err)
+
+	got := collected.filter(c.VIPCPS, c.ServerUp)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.VIPCPS,
+			metric: createGaugeMetric(200, map[string]string{
+				"id":        "101",
+				"name":      "loadbalancer",
+				"zone":      "is1a",
+				"vip_index": "1",
+				"vip":       "192.168.0.102",
+			}),
+		},
+		{
+			desc: c.ServerUp,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":           "101",
+				"name":         "loadbalancer",
+				"zone":         "is1a",
+				"vip_index":    "1",
+				"vip":          "192.168.0.102",
+				"server_index": "0",
+				"ipaddress":    "192.168.0.201",
+			}),
+		},
+	}, got)
+}
@@ -33,6 +33,7 @@ type LocalRouterCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.LocalRouterClient
 
 	Up              *prometheus.Desc
@@ -41,14 +42,19 @@ type LocalRouterCollector struct {
 	NetworkInfo     *prometheus.Desc
 	PeerInfo        *prometheus.Desc
 	PeerUp          *prometheus.Desc
+	PeerRouteCount  *prometheus.Desc
 	StaticRouteInfo *prometheus.Desc
 
 	ReceiveBytesPerSec *prometheus.Desc
 	SendBytesPerSec    *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewLocalRouterCollector returns a new LocalRouterCollector.
-func NewLocalRouterCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.LocalRouterClient) *LocalRouterCollector {
+func NewLocalRouterCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.LocalRouterClient) *LocalRouterCollector {
 	errors.WithLabelValues("local_router").Add(0)
 
 	localRouterLabels := []string{"id", "name"}
@@ -63,52 +69,61 @@ func NewLocalRouterCollector(ctx context.Context, logger *slog.Logger, errors *p
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+		Up: opts.newDesc(
 			"sakuracloud_local_router_up",
 			"If 1 the LocalRouter is available, 0 otherwise",
 			localRouterLabels, nil,
 		),
-		LocalRouterInfo: prometheus.NewDesc(
+		LocalRouterInfo: opts.newDesc(
 			"sakuracloud_local_router_info",
 			"A metric with a constant '1' value labeled by localRouter information",
 			localRouterInfoLabels, nil,
 		),
-		SwitchInfo: prometheus.NewDesc(
+		SwitchInfo: opts.newDesc(
 			"sakuracloud_local_router_switch_info",
 			"A metric with a constant '1' value labeled by localRouter connected switch information",
 			localRouterSwitchInfoLabels, nil,
 		),
-		NetworkInfo: prometheus.NewDesc(
+		NetworkInfo: opts.newDesc(
 			"sakuracloud_local_router_network_info",
 			"A metric with a constant '1' value labeled by network information of the localRouter",
 			localRouterServerNetworkInfoLabels, nil,
 		),
-		PeerInfo: prometheus.NewDesc(
+		PeerInfo: opts.newDesc(
 			"sakuracloud_local_router_peer_info",
 			"A metric with a constant '1' value labeled by peer information",
 			localRouterPeerInfoLabels, nil,
 		),
-		PeerUp: prometheus.NewDesc(
+		PeerUp: opts.newDesc(
 			"sakuracloud_local_router_peer_up",
 			"If 1 the Peer is available, 0 otherwise",
 			localRouterPeerLabels, nil,
 		),
-		StaticRouteInfo: prometheus.NewDesc(
+		PeerRouteCount: opts.newDesc(
+			"sakuracloud_local_router_peer_route_count",
+			"The number of routes advertised by the Peer",
+			localRouterPeerLabels, nil,
+		),
+		StaticRouteInfo: opts.newDesc(
 			"sakuracloud_local_router_static_route_info",
 			"A metric with a constant '1' value labeled by static route information",
 			localRouterStaticRouteInfoLabels, nil,
 		),
-		ReceiveBytesPerSec: prometheus.NewDesc(
+		ReceiveBytesPerSec: opts.newDesc(
 			"sakuracloud_local_router_receive_per_sec",
 			"Receive bytes per seconds",
 			localRouterLabels, nil,
 		),
-		SendBytesPerSec: prometheus.NewDesc(
+		SendBytesPerSec: opts.newDesc(
 			"sakuracloud_local_router_send_per_sec",
 			"Send bytes per seconds",
 			localRouterLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("local_router"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("local_router"),
+		CountByTag:      opts.newResourceCountByTagDesc("local_router"),
 	}
 }
 
@@ -121,14 +136,23 @@ func (c *LocalRouterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.NetworkInfo
 	ch <- c.PeerInfo
 	ch <- c.PeerUp
+	ch <- c.PeerRouteCount
 	ch <- c.StaticRouteInfo
 	ch <- c.ReceiveBytesPerSec
 	ch <- c.SendBytesPerSec
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
-	localRouters, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *LocalRouterCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	localRouters, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("local_router").Add(1)
 		c.logger.Warn(
@@ -137,6 +161,12 @@ func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(localRouters))
+	for _, localRouter := range localRouters {
+		tags = append(tags, localRouter.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
 	var wg sync.WaitGroup
 	wg.Add(len(localRouters))
 
@@ -145,6 +175,8 @@ func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			localRouterLabels := c.localRouterLabels(localRouter)
+			collectResourceUnnamed(ch, c.Unnamed, localRouter.ID, "", localRouter.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, localRouter.ID, "", localRouter.CreatedAt)
 
 			var up float64
 			if localRouter.Availability.IsAvailable() {
@@ -167,7 +199,7 @@ func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
 
 			wg.Add(1)
 			go func() {
-				c.collectPeerInfo(ch, localRouter)
+				c.collectPeerInfo(ctx, ch, localRouter)
 				wg.Done()
 			}()
 
@@ -180,7 +212,7 @@ func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
 
 				wg.Add(1)
 				go func() {
-					c.collectLocalRouterMetrics(ch, localRouter, now)
+					c.collectLocalRouterMetrics(ctx, ch, localRouter, now)
 					wg.Done()
 				}()
 			}
@@ -193,14 +225,14 @@ func (c *LocalRouterCollector) Collect(ch chan<- prometheus.Metric) {
 func (c *LocalRouterCollector) localRouterLabels(localRouter *iaas.LocalRouter) []string {
 	return []string{
 		localRouter.ID.String(),
-		localRouter.Name,
+		c.opts.truncateLabelValue(localRouter.Name),
 	}
 }
 
 func (c *LocalRouterCollector) collectLocalRouterInfo(ch chan<- prometheus.Metric, localRouter *iaas.LocalRouter) {
 	labels := append(c.localRouterLabels(localRouter),
-		flattenStringSlice(localRouter.Tags),
-		localRouter.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(localRouter.Tags)),
+		c.opts.truncateLabelValue(localRouter.Description),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -243,11 +275,11 @@ func (c *LocalRouterCollector) collectNetworkInfo(ch chan<- prometheus.Metric, l
 	)
 }
 
-func (c *LocalRouterCollector) collectPeerInfo(ch chan<- prometheus.Metric, localRouter *iaas.LocalRouter) {
+func (c *LocalRouterCollector) collectPeerInfo(ctx context.Context, ch chan<- prometheus.Metric, localRouter *iaas.LocalRouter) {
 	// localRouterPeerLabels := append(localRouterLabels, "peer_index", "peer_id")
 	// localRouterPeerInfoLabels := append(localRouterPeerLabels, "enabled", "description")
 
-	healthStatus, err := c.client.Health(c.ctx, localRouter.ID)
+	healthStatus, err := c.client.Health(ctx, localRouter.ID)
 	if err != nil {
 		c.errors.WithLabelValues("local_router").Add(1)
 		c.logger.Warn(
@@ -276,11 +308,18 @@ func (c *LocalRouterCollector) collectPeerInfo(ch chan<- prometheus.Metric, loca
 				labels...,
 			)
 
+			ch <- prometheus.MustNewConstMetric(
+				c.PeerRouteCount,
+				prometheus.GaugeValue,
+				float64(len(peerStatus.Routes)),
+				labels...,
+			)
+
 			enabled := "0"
 			if peer.Enabled {
 				enabled = "1"
 			}
-			infoLabels := append(labels, enabled, peer.Description)
+			infoLabels := append(labels, enabled, c.opts.truncateLabelValue(peer.Description))
 			ch <- prometheus.MustNewConstMetric(
 				c.PeerInfo,
 				prometheus.GaugeValue,
@@ -315,8 +354,8 @@ func (c *LocalRouterCollector) collectStaticRouteInfo(ch chan<- prometheus.Metri
 	)
 }
 
-func (c *LocalRouterCollector) collectLocalRouterMetrics(ch chan<- prometheus.Metric, localRouter *iaas.LocalRouter, now time.Time) {
-	values, err := c.client.Monitor(c.ctx, localRouter.ID, now)
+func (c *LocalRouterCollector) collectLocalRouterMetrics(ctx context.Context, ch chan<- prometheus.Metric, localRouter *iaas.LocalRouter, now time.Time) {
+	values, err := c.client.Monitor(ctx, localRouter.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("local_router").Add(1)
 		c.logger.Warn(
@@ -326,7 +365,10 @@ func (c *LocalRouterCollector) collectLocalRouterMetrics(ch chan<- prometheus.Me
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorLocalRouterValue{Time: now}
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -50,7 +50,7 @@ func (d *dummyLocalRouterClient) Monitor(ctx context.Context, id types.ID, end t
 
 func TestLocalRouterCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewLocalRouterCollector(context.Background(), testLogger, testErrors, &dummyLocalRouterClient{})
+	c := NewLocalRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyLocalRouterClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -60,15 +60,19 @@ func TestLocalRouterCollector_Describe(t *testing.T) {
 		c.NetworkInfo,
 		c.PeerInfo,
 		c.PeerUp,
+		c.PeerRouteCount,
 		c.StaticRouteInfo,
 		c.ReceiveBytesPerSec,
 		c.SendBytesPerSec,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestLocalRouterCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewLocalRouterCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewLocalRouterCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -263,6 +267,24 @@ func TestLocalRouterCollector_Collect(t *testing.T) {
 						"peer_id":    "202",
 					}),
 				},
+				{
+					desc: c.PeerRouteCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "local-router",
+						"peer_index": "0",
+						"peer_id":    "201",
+					}),
+				},
+				{
+					desc: c.PeerRouteCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "local-router",
+						"peer_index": "1",
+						"peer_id":    "202",
+					}),
+				},
 				{
 					desc: c.PeerInfo,
 					metric: createGaugeMetric(1, map[string]string{
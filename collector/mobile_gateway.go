@@ -18,10 +18,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/packages-go/newsfeed"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
@@ -31,6 +34,7 @@ type MobileGatewayCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.MobileGatewayClient
 
 	Up                *prometheus.Desc
@@ -44,14 +48,23 @@ type MobileGatewayCollector struct {
 	TrafficDownlink *prometheus.Desc
 	TrafficShaping  *prometheus.Desc
 
+	QuotaExceeded    *prometheus.Desc
+	QuotaUtilization *prometheus.Desc
+
+	ConnectedDeviceCount *prometheus.Desc
+
 	MaintenanceScheduled *prometheus.Desc
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewMobileGatewayCollector returns a new MobileGatewayCollector.
-func NewMobileGatewayCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.MobileGatewayClient) *MobileGatewayCollector {
+func NewMobileGatewayCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.MobileGatewayClient) *MobileGatewayCollector {
 	errors.WithLabelValues("mobile_gateway").Add(0)
 
 	mobileGatewayLabels := []string{"id", "name", "zone"}
@@ -63,67 +76,86 @@ func NewMobileGatewayCollector(ctx context.Context, logger *slog.Logger, errors
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+		Up: opts.newDesc(
 			"sakuracloud_mobile_gateway_up",
 			"If 1 the mobile_gateway is up and running, 0 otherwise",
 			mobileGatewayLabels, nil,
 		),
-		MobileGatewayInfo: prometheus.NewDesc(
+		MobileGatewayInfo: opts.newDesc(
 			"sakuracloud_mobile_gateway_info",
 			"A metric with a constant '1' value labeled by mobile_gateway information",
-			mobileGatewayInfoLabels, nil,
+			opts.withRegionLabel(mobileGatewayInfoLabels), nil,
 		),
-		Receive: prometheus.NewDesc(
+		Receive: opts.newNetworkTrafficDesc(
 			"sakuracloud_mobile_gateway_nic_receive",
-			"MobileGateway's receive bytes(unit: Kbps)",
+			"MobileGateway's receive traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		Send: prometheus.NewDesc(
+		Send: opts.newNetworkTrafficDesc(
 			"sakuracloud_mobile_gateway_nic_send",
-			"MobileGateway's send bytes(unit: Kbps)",
+			"MobileGateway's send traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		TrafficControlInfo: prometheus.NewDesc(
+		TrafficControlInfo: opts.newDesc(
 			"sakuracloud_mobile_gateway_traffic_control_info",
 			"A metric with a constant '1' value labeled by traffic-control information",
 			trafficControlInfoLabel, nil,
 		),
-		TrafficUplink: prometheus.NewDesc(
+		TrafficUplink: opts.newDesc(
 			"sakuracloud_mobile_gateway_traffic_uplink",
 			"MobileGateway's uplink bytes(unit: KB)",
 			mobileGatewayLabels, nil,
 		),
-		TrafficDownlink: prometheus.NewDesc(
+		TrafficDownlink: opts.newDesc(
 			"sakuracloud_mobile_gateway_traffic_downlink",
 			"MobileGateway's downlink bytes(unit: KB)",
 			mobileGatewayLabels, nil,
 		),
-		TrafficShaping: prometheus.NewDesc(
+		TrafficShaping: opts.newDesc(
 			"sakuracloud_mobile_gateway_traffic_shaping",
 			"If 1 the traffic is shaped, 0 otherwise",
 			mobileGatewayLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		QuotaExceeded: opts.newDesc(
+			"sakuracloud_mobile_gateway_quota_exceeded",
+			"If 1 the total uplink/downlink traffic exceeds the traffic quota, 0 otherwise",
+			mobileGatewayLabels, nil,
+		),
+		QuotaUtilization: opts.newDesc(
+			"sakuracloud_mobile_gateway_quota_utilization",
+			"Ratio of the total uplink/downlink traffic to the traffic quota",
+			mobileGatewayLabels, nil,
+		),
+		ConnectedDeviceCount: opts.newDesc(
+			"sakuracloud_mobile_gateway_connected_device_count",
+			"The number of SIMs connected to the mobile gateway with an active session",
+			mobileGatewayLabels, nil,
+		),
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_mobile_gateway_maintenance_scheduled",
 			"If 1 the mobile gateway has scheduled maintenance info, 0 otherwise",
 			mobileGatewayLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_mobile_gateway_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			append(mobileGatewayLabels, "info_url", "info_title", "description", "start_date", "end_date"), nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_mobile_gateway_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			mobileGatewayLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_mobile_gateway_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			mobileGatewayLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("mobile_gateway"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("mobile_gateway"),
+		CountByTag:      opts.newResourceCountByTagDesc("mobile_gateway"),
 	}
 }
 
@@ -138,16 +170,27 @@ func (c *MobileGatewayCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.TrafficUplink
 	ch <- c.TrafficDownlink
 	ch <- c.TrafficShaping
+	ch <- c.QuotaExceeded
+	ch <- c.QuotaUtilization
+	ch <- c.ConnectedDeviceCount
 
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
-	mobileGateways, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *MobileGatewayCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	mobileGateways, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("mobile_gateway").Add(1)
 		c.logger.Warn(
@@ -156,6 +199,14 @@ func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(mobileGateways))
+	for _, mobileGateway := range mobileGateways {
+		tags = append(tags, mobileGateway.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	c.opts.warnGoroutines(c.logger, "mobile_gateway", estimateMobileGatewayGoroutines(mobileGateways))
+
 	var wg sync.WaitGroup
 	wg.Add(len(mobileGateways))
 
@@ -164,6 +215,8 @@ func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			mobileGatewayLabels := c.mobileGatewayLabels(mobileGateway)
+			collectResourceUnnamed(ch, c.Unnamed, mobileGateway.ID, mobileGateway.ZoneName, mobileGateway.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, mobileGateway.ID, mobileGateway.ZoneName, mobileGateway.CreatedAt)
 
 			var up float64
 			if mobileGateway.InstanceStatus.IsUp() {
@@ -179,20 +232,20 @@ func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
 				c.MobileGatewayInfo,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.mobileGatewayInfoLabels(mobileGateway)...,
+				c.opts.withRegionLabelValue(c.mobileGatewayInfoLabels(mobileGateway), mobileGateway.ZoneName)...,
 			)
 			if mobileGateway.Availability.IsAvailable() && mobileGateway.InstanceStatus.IsUp() {
-				// TrafficControlInfo
+				// TrafficControlInfo/TrafficStatus
 				wg.Add(1)
 				go func() {
-					c.collectTrafficControlInfo(ch, mobileGateway)
+					c.collectTraffic(ctx, ch, mobileGateway)
 					wg.Done()
 				}()
 
-				// TrafficStatus
+				// SIMs
 				wg.Add(1)
 				go func() {
-					c.collectTrafficStatus(ch, mobileGateway)
+					c.collectConnectedDeviceCount(ctx, ch, mobileGateway)
 					wg.Done()
 				}()
 
@@ -203,7 +256,7 @@ func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
 					// NIC(Receive/Send)
 					wg.Add(1)
 					go func(i int) {
-						c.collectNICMetrics(ch, mobileGateway, i, now)
+						c.collectNICMetrics(ctx, ch, mobileGateway, i, now)
 						wg.Done()
 					}(i)
 				}
@@ -231,10 +284,20 @@ func (c *MobileGatewayCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
+// estimateMobileGatewayGoroutines returns the upper bound of the number of goroutines spawned per Collect:
+// traffic, SIMs and maintenance info for each mobile gateway and NIC metrics for each NIC
+func estimateMobileGatewayGoroutines(mobileGateways []*platform.MobileGateway) int {
+	n := 0
+	for _, mobileGateway := range mobileGateways {
+		n += len(mobileGateway.Interfaces) + 3
+	}
+	return n
+}
+
 func (c *MobileGatewayCollector) mobileGatewayLabels(mobileGateway *platform.MobileGateway) []string {
 	return []string{
 		mobileGateway.ID.String(),
-		mobileGateway.Name,
+		c.opts.truncateLabelValue(mobileGateway.Name),
 		mobileGateway.ZoneName,
 	}
 }
@@ -255,8 +318,8 @@ func (c *MobileGatewayCollector) mobileGatewayInfoLabels(mobileGateway *platform
 	return append(labels,
 		internetConnection,
 		interDeviceCommunication,
-		flattenStringSlice(mobileGateway.Tags),
-		mobileGateway.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(mobileGateway.Tags)),
+		c.opts.truncateLabelValue(mobileGateway.Description),
 	)
 }
 
@@ -279,18 +342,62 @@ func (c *MobileGatewayCollector) nicLabels(mobileGateway *platform.MobileGateway
 	)
 }
 
-func (c *MobileGatewayCollector) collectTrafficControlInfo(ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) {
-	info, err := c.client.TrafficControl(c.ctx, mobileGateway.ZoneName, mobileGateway.ID)
+func (c *MobileGatewayCollector) collectTraffic(ctx context.Context, ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) {
+	var info *iaas.MobileGatewayTrafficControl
+	var status *iaas.MobileGatewayTrafficStatus
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		info = c.collectTrafficControlInfo(ctx, ch, mobileGateway)
+		wg.Done()
+	}()
+	go func() {
+		status = c.collectTrafficStatus(ctx, ch, mobileGateway)
+		wg.Done()
+	}()
+	wg.Wait()
+
+	c.collectTrafficQuota(ch, mobileGateway, info, status)
+}
+
+func (c *MobileGatewayCollector) collectConnectedDeviceCount(ctx context.Context, ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) {
+	sims, err := c.client.ListSIM(ctx, mobileGateway.ZoneName, mobileGateway.ID)
 	if err != nil {
 		c.errors.WithLabelValues("mobile_gateway").Add(1)
 		c.logger.Warn(
-			fmt.Sprintf("can't get mobile_gateway's traffic control config: ID=%d", mobileGateway.ID),
+			fmt.Sprintf("can't list mobile_gateway's SIMs: ID=%d", mobileGateway.ID),
 			slog.Any("err", err),
 		)
 		return
 	}
+
+	var count float64
+	for _, sim := range sims {
+		if strings.ToLower(sim.SessionStatus) == "up" {
+			count++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.ConnectedDeviceCount,
+		prometheus.GaugeValue,
+		count,
+		c.mobileGatewayLabels(mobileGateway)...,
+	)
+}
+
+func (c *MobileGatewayCollector) collectTrafficControlInfo(ctx context.Context, ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) *iaas.MobileGatewayTrafficControl {
+	info, err := c.client.TrafficControl(ctx, mobileGateway.ZoneName, mobileGateway.ID)
+	if err != nil {
+		c.errors.WithLabelValues("mobile_gateway").Add(1)
+		c.logger.Warn(
+			fmt.Sprintf("can't get mobile_gateway's traffic control config: ID=%d", mobileGateway.ID),
+			slog.Any("err", err),
+		)
+		return nil
+	}
 	if info == nil {
-		return
+		return nil
 	}
 
 	enableEmail := "0"
@@ -325,20 +432,21 @@ func (c *MobileGatewayCollector) collectTrafficControlInfo(ch chan<- prometheus.
 		float64(1.0),
 		labels...,
 	)
+	return info
 }
 
-func (c *MobileGatewayCollector) collectTrafficStatus(ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) {
-	status, err := c.client.TrafficStatus(c.ctx, mobileGateway.ZoneName, mobileGateway.ID)
+func (c *MobileGatewayCollector) collectTrafficStatus(ctx context.Context, ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway) *iaas.MobileGatewayTrafficStatus {
+	status, err := c.client.TrafficStatus(ctx, mobileGateway.ZoneName, mobileGateway.ID)
 	if err != nil {
 		c.errors.WithLabelValues("mobile_gateway").Add(1)
 		c.logger.Warn(
 			fmt.Sprintf("can't get mobile_gateway's traffic status: ID=%d", mobileGateway.ID),
 			slog.Any("err", err),
 		)
-		return
+		return nil
 	}
 	if status == nil {
-		return
+		return nil
 	}
 
 	labels := c.mobileGatewayLabels(mobileGateway)
@@ -365,10 +473,41 @@ func (c *MobileGatewayCollector) collectTrafficStatus(ch chan<- prometheus.Metri
 		float64(trafficShaping),
 		labels...,
 	)
+	return status
 }
 
-func (c *MobileGatewayCollector) collectNICMetrics(ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway, index int, now time.Time) {
-	values, err := c.client.MonitorNIC(c.ctx, mobileGateway.ZoneName, mobileGateway.ID, index, now)
+// collectTrafficQuota collects the traffic quota metrics from both the traffic-control config and the traffic status
+func (c *MobileGatewayCollector) collectTrafficQuota(ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway, info *iaas.MobileGatewayTrafficControl, status *iaas.MobileGatewayTrafficStatus) {
+	if info == nil || status == nil || info.TrafficQuotaInMB <= 0 {
+		return
+	}
+
+	// traffic status is reported in KB
+	quota := float64(info.TrafficQuotaInMB) * 1024
+	total := float64(status.UplinkBytes.Int64() + status.DownlinkBytes.Int64())
+
+	labels := c.mobileGatewayLabels(mobileGateway)
+
+	exceeded := 0.0
+	if total > quota {
+		exceeded = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.QuotaExceeded,
+		prometheus.GaugeValue,
+		exceeded,
+		labels...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.QuotaUtilization,
+		prometheus.GaugeValue,
+		total/quota,
+		labels...,
+	)
+}
+
+func (c *MobileGatewayCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, mobileGateway *platform.MobileGateway, index int, now time.Time) {
+	values, err := c.client.MonitorNIC(ctx, mobileGateway.ZoneName, mobileGateway.ID, index, now)
 	if err != nil {
 		c.errors.WithLabelValues("mobile_gateway").Add(1)
 		c.logger.Warn(
@@ -378,12 +517,15 @@ func (c *MobileGatewayCollector) collectNICMetrics(ch chan<- prometheus.Metric,
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
 	}
 
 	receive := values.Receive
 	if receive > 0 {
-		receive = receive * 8 / 1000
+		receive = c.opts.convertNetworkTraffic(receive)
 	}
 	m := prometheus.MustNewConstMetric(
 		c.Receive,
@@ -395,7 +537,7 @@ func (c *MobileGatewayCollector) collectNICMetrics(ch chan<- prometheus.Metric,
 
 	send := values.Send
 	if send > 0 {
-		send = send * 8 / 1000
+		send = c.opts.convertNetworkTraffic(send)
 	}
 	m = prometheus.MustNewConstMetric(
 		c.Send,
@@ -412,7 +554,7 @@ func (c *MobileGatewayCollector) maintenanceInfoLabels(resource *platform.Mobile
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
@@ -35,6 +35,8 @@ type dummyMobileGatewayClient struct {
 	trafficStatusErr  error
 	trafficControl    *iaas.MobileGatewayTrafficControl
 	trafficControlErr error
+	sims              iaas.MobileGatewaySIMs
+	simsErr           error
 	monitor           *iaas.MonitorInterfaceValue
 	monitorErr        error
 	maintenance       *newsfeed.FeedItem
@@ -50,6 +52,9 @@ func (d *dummyMobileGatewayClient) TrafficStatus(ctx context.Context, zone strin
 func (d *dummyMobileGatewayClient) TrafficControl(ctx context.Context, zone string, id types.ID) (*iaas.MobileGatewayTrafficControl, error) {
 	return d.trafficControl, d.trafficControlErr
 }
+func (d *dummyMobileGatewayClient) ListSIM(ctx context.Context, zone string, id types.ID) (iaas.MobileGatewaySIMs, error) {
+	return d.sims, d.simsErr
+}
 func (d *dummyMobileGatewayClient) MonitorNIC(ctx context.Context, zone string, id types.ID, index int, end time.Time) (*iaas.MonitorInterfaceValue, error) {
 	return d.monitor, d.monitorErr
 }
@@ -59,7 +64,7 @@ func (d *dummyMobileGatewayClient) MaintenanceInfo(infoURL string) (*newsfeed.Fe
 
 func TestMobileGatewayCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, &dummyMobileGatewayClient{})
+	c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, Options{}, &dummyMobileGatewayClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -71,16 +76,22 @@ func TestMobileGatewayCollector_Describe(t *testing.T) {
 		c.TrafficUplink,
 		c.TrafficDownlink,
 		c.TrafficShaping,
+		c.QuotaExceeded,
+		c.QuotaUtilization,
+		c.ConnectedDeviceCount,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestMobileGatewayCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -237,6 +248,30 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.QuotaExceeded,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.QuotaUtilization,
+					metric: createGaugeMetric(float64(300)/(1024*1024), map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.ConnectedDeviceCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -353,6 +388,22 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.QuotaExceeded,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.QuotaUtilization,
+					metric: createGaugeMetric(float64(300)/(1024*1024), map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.Receive,
 					metric: createGaugeWithTimestamp(float64(100)*8/1000, map[string]string{
@@ -397,6 +448,14 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 						"nw_mask_len": "28",
 					}, monitorTime),
 				},
+				{
+					desc: c.ConnectedDeviceCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -460,6 +519,14 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 						"description":                "desc",
 					}),
 				},
+				{
+					desc: c.ConnectedDeviceCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -526,6 +593,14 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 						"description":                "desc",
 					}),
 				},
+				{
+					desc: c.ConnectedDeviceCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "mobile-gateway",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(1, map[string]string{
@@ -580,3 +655,97 @@ func TestMobileGatewayCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestMobileGatewayCollector_TrafficQuota(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, Options{}, &dummyMobileGatewayClient{
+		find: []*platform.MobileGateway{
+			{
+				ZoneName: "is1a",
+				MobileGateway: &iaas.MobileGateway{
+					ID:             101,
+					Name:           "mobile-gateway",
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+				},
+			},
+		},
+		trafficControl: &iaas.MobileGatewayTrafficControl{
+			TrafficQuotaInMB: 1,
+		},
+		trafficStatus: &iaas.MobileGatewayTrafficStatus{
+			UplinkBytes:   1024,
+			DownlinkBytes: 512,
+		},
+	})
+
+	collected, err := collectMetrics(c, "mobile_gateway")
+	require.NoError(t, err)
+
+	got := collected.filter(c.QuotaExceeded, c.QuotaUtilization)
+	labels := map[string]string{
+		"id":   "101",
+		"name": "mobile-gateway",
+		"zone": "is1a",
+	}
+	requireMetricsEqual(t, []*collectedMetric{
+		{desc: c.QuotaExceeded, metric: createGaugeMetric(1, labels)},
+		{desc: c.QuotaUtilization, metric: createGaugeMetric(1.5, labels)},
+	}, got)
+}
+
+func TestMobileGatewayCollector_ConnectedDeviceCount(t *testing.T) {
+	find := []*platform.MobileGateway{
+		{
+			ZoneName: "is1a",
+			MobileGateway: &iaas.MobileGateway{
+				ID:             101,
+				Name:           "mobile-gateway",
+				InstanceStatus: types.ServerInstanceStatuses.Up,
+				Availability:   types.Availabilities.Available,
+			},
+		},
+	}
+	cases := []struct {
+		name           string
+		in             *dummyMobileGatewayClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantCounts     []float64
+	}{
+		{
+			name: "SIMs with and without session",
+			in: &dummyMobileGatewayClient{
+				find: find,
+				sims: iaas.MobileGatewaySIMs{
+					{ICCID: "1", SessionStatus: "UP"},
+					{ICCID: "2", SessionStatus: "DOWN"},
+					{ICCID: "3", SessionStatus: "UP"},
+				},
+			},
+			wantCounts: []float64{2},
+		},
+		{
+			name: "listing SIMs is failed",
+			in: &dummyMobileGatewayClient{
+				find:    find,
+				simsErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list mobile_gateway's SIMs: ID=101" err=dummy`},
+			wantErrCounter: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewMobileGatewayCollector(context.Background(), testLogger, testErrors, Options{}, tc.in)
+
+		collected, err := collectMetrics(c, "mobile_gateway")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged, tc.name)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value, tc.name)
+
+		counts := collected.values(c.ConnectedDeviceCount)
+		require.Equal(t, tc.wantCounts, counts, tc.name)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+func (o Options) collectMonitorSampleTimestamp(ch chan<- prometheus.Metric, desc *prometheus.Desc, sampleTime time.Time, labels ...string) {
+	if !o.ExposeSampleTimestamps {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		float64(sampleTime.Unix()),
+		labels...,
+	)
+}
+
+// monitorSampleIntervalTracker keeps the time of the last monitor sample of each resource across Collects
+// to compute the interval between consecutive samples
+type monitorSampleIntervalTracker struct {
+	mu      sync.Mutex
+	samples map[types.ID]*observedSampleInterval
+}
+
+type observedSampleInterval struct {
+	time        time.Time
+	interval    time.Duration
+	hasInterval bool
+}
+
+func newMonitorSampleIntervalTracker() *monitorSampleIntervalTracker {
+	return &monitorSampleIntervalTracker{
+		samples: make(map[types.ID]*observedSampleInterval),
+	}
+}
+
+// observe records the time of the monitor sample of the resource and returns the interval from the previous sample.
+// The interval can only be computed from the second sample, and the last interval is returned while the monitor API returns the same sample.
+func (t *monitorSampleIntervalTracker) observe(id types.ID, sampleTime time.Time) (time.Duration, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	prev, ok := t.samples[id]
+	if !ok {
+		t.samples[id] = &observedSampleInterval{time: sampleTime}
+		return 0, false
+	}
+	if sampleTime.After(prev.time) {
+		prev.interval = sampleTime.Sub(prev.time)
+		prev.hasInterval = true
+		prev.time = sampleTime
+	}
+	return prev.interval, prev.hasInterval
+}
+
+// retain removes the resources which no longer exist
+func (t *monitorSampleIntervalTracker) retain(ids []types.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exists := make(map[types.ID]bool)
+	for _, id := range ids {
+		exists[id] = true
+	}
+	for id := range t.samples {
+		if !exists[id] {
+			delete(t.samples, id)
+		}
+	}
+}
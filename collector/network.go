@@ -0,0 +1,38 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+const (
+	// NetworkUnitBits reports NIC traffic in kilobits per second(Kbps)
+	NetworkUnitBits = "bits"
+	// NetworkUnitBytes reports NIC traffic in kilobytes per second(KBps)
+	NetworkUnitBytes = "bytes"
+)
+
+// convertNetworkTraffic converts the traffic from the monitor API(unit: bytes per second) into NetworkUnit
+func (o Options) convertNetworkTraffic(bytesPerSec float64) float64 {
+	if o.NetworkUnit == NetworkUnitBytes {
+		return bytesPerSec / 1000
+	}
+	return bytesPerSec * 8 / 1000
+}
+
+// networkTrafficUnit returns the unit of NIC traffic metrics for the help text
+func (o Options) networkTrafficUnit() string {
+	if o.NetworkUnit == NetworkUnitBytes {
+		return "KBps"
+	}
+	return "Kbps"
+}
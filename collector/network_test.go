@@ -0,0 +1,87 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/helper/query"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkUnit(t *testing.T) {
+	monitorTime := time.Unix(1, 0)
+	client := &dummyNFSClient{
+		find: []*platform.NFS{
+			{
+				ZoneName: "is1a",
+				NFS: &iaas.NFS{
+					ID:             101,
+					Name:           "nfs",
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+					IPAddresses:    []string{"192.168.0.11"},
+				},
+				Plan: &query.NFSPlanInfo{
+					Size: types.NFSHDDSizes.Size100GB,
+				},
+			},
+		},
+		monitorNIC: &iaas.MonitorInterfaceValue{
+			Time:    monitorTime,
+			Receive: 1000,
+			Send:    2000,
+		},
+	}
+
+	cases := []struct {
+		unit        string
+		wantUnit    string
+		wantReceive float64
+		wantSend    float64
+	}{
+		{
+			unit:        NetworkUnitBits,
+			wantUnit:    "Kbps",
+			wantReceive: 8,
+			wantSend:    16,
+		},
+		{
+			unit:        NetworkUnitBytes,
+			wantUnit:    "KBps",
+			wantReceive: 1,
+			wantSend:    2,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewNFSCollector(context.Background(), testLogger, testErrors, Options{NetworkUnit: tc.unit}, client)
+		require.True(t, strings.Contains(c.NICReceive.String(), "(unit: "+tc.wantUnit+")"), tc.unit)
+		require.True(t, strings.Contains(c.NICSend.String(), "(unit: "+tc.wantUnit+")"), tc.unit)
+
+		collected, err := collectMetrics(c, "nfs")
+		require.NoError(t, err)
+
+		require.Equal(t, []float64{tc.wantReceive}, collected.values(c.NICReceive), tc.unit)
+		require.Equal(t, []float64{tc.wantSend}, collected.values(c.NICSend), tc.unit)
+	}
+}
@@ -22,6 +22,8 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/packages-go/newsfeed"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
@@ -31,25 +33,36 @@ type NFSCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.NFSClient
 
-	Up      *prometheus.Desc
-	NFSInfo *prometheus.Desc
+	freeDiskSizes *nfsFreeDiskSizeTracker
 
-	DiskFree *prometheus.Desc
+	Up             *prometheus.Desc
+	InstanceStatus *prometheus.Desc
+	NFSInfo        *prometheus.Desc
+
+	DiskFree    *prometheus.Desc
+	DiskFullETA *prometheus.Desc
 
 	NICInfo    *prometheus.Desc
 	NICReceive *prometheus.Desc
 	NICSend    *prometheus.Desc
 
+	MonitorSampleTimestamp *prometheus.Desc
+
 	MaintenanceScheduled *prometheus.Desc
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewNFSCollector returns a new NFSCollector.
-func NewNFSCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.NFSClient) *NFSCollector {
+func NewNFSCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.NFSClient) *NFSCollector {
 	errors.WithLabelValues("nfs").Add(0)
 
 	nfsLabels := []string{"id", "name", "zone"}
@@ -60,57 +73,79 @@ func NewNFSCollector(ctx context.Context, logger *slog.Logger, errors *prometheu
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+
+		freeDiskSizes: newNFSFreeDiskSizeTracker(),
+
+		Up: opts.newDesc(
 			"sakuracloud_nfs_up",
 			"If 1 the nfs is up and running, 0 otherwise",
 			nfsLabels, nil,
 		),
-		NFSInfo: prometheus.NewDesc(
+		InstanceStatus: opts.newDesc(
+			"sakuracloud_nfs_instance_status",
+			"If 1 the nfs's instance is in the status, 0 otherwise",
+			append(nfsLabels, "status"), nil,
+		),
+		NFSInfo: opts.newDesc(
 			"sakuracloud_nfs_info",
 			"A metric with a constant '1' value labeled by nfs information",
-			nfsInfoLabels, nil,
+			opts.withRegionLabel(nfsInfoLabels), nil,
 		),
-		DiskFree: prometheus.NewDesc(
+		DiskFree: opts.newDesc(
 			"sakuracloud_nfs_free_disk_size",
 			"NFS's Free Disk Size(unit: GB)",
 			nfsLabels, nil,
 		),
-		NICInfo: prometheus.NewDesc(
+		DiskFullETA: opts.newDesc(
+			"sakuracloud_nfs_disk_full_eta_seconds",
+			"Estimated time until NFS's disk becomes full, computed from the decrease of the recent free disk size samples(unit: second)",
+			nfsLabels, nil,
+		),
+		NICInfo: opts.newDesc(
 			"sakuracloud_nfs_nic_info",
 			"A metric with a constant '1' value labeled by nic information",
 			nicInfoLabels, nil,
 		),
-		NICReceive: prometheus.NewDesc(
+		NICReceive: opts.newNetworkTrafficDesc(
 			"sakuracloud_nfs_receive",
-			"NIC's receive bytes(unit: Kbps)",
+			"NIC's receive traffic(unit: %s)",
 			nfsLabels, nil,
 		),
-		NICSend: prometheus.NewDesc(
+		NICSend: opts.newNetworkTrafficDesc(
 			"sakuracloud_nfs_send",
-			"NIC's send bytes(unit: Kbps)",
+			"NIC's send traffic(unit: %s)",
+			nfsLabels, nil,
+		),
+		MonitorSampleTimestamp: opts.newDesc(
+			"sakuracloud_nfs_monitor_sample_timestamp_seconds",
+			"Time of the latest NIC sample returned by the monitor API in seconds since epoch (1970)",
 			nfsLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_nfs_maintenance_scheduled",
 			"If 1 the nfs has scheduled maintenance info, 0 otherwise",
 			nfsLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_nfs_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			append(nfsLabels, "info_url", "info_title", "description", "start_date", "end_date"), nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_nfs_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			nfsLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_nfs_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			nfsLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("nfs"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("nfs"),
+		CountByTag:      opts.newResourceCountByTagDesc("nfs"),
 	}
 }
 
@@ -118,29 +153,51 @@ func NewNFSCollector(ctx context.Context, logger *slog.Logger, errors *prometheu
 // collected by this Collector.
 func (c *NFSCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
+	ch <- c.InstanceStatus
 	ch <- c.NFSInfo
 	ch <- c.DiskFree
+	ch <- c.DiskFullETA
 	ch <- c.NICInfo
 	ch <- c.NICReceive
 	ch <- c.NICSend
 
+	ch <- c.MonitorSampleTimestamp
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *NFSCollector) Collect(ch chan<- prometheus.Metric) {
-	nfss, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *NFSCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	nfss, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("nfs").Add(1)
 		c.logger.Warn(
 			"can't list nfs",
 			slog.Any("err", err),
 		)
+	} else {
+		c.freeDiskSizes.retain(nfss)
 	}
 
+	tags := make([]types.Tags, 0, len(nfss))
+	for _, nfs := range nfss {
+		tags = append(tags, nfs.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	// free disk size, NICs and maintenance info for each nfs
+	c.opts.warnGoroutines(c.logger, "nfs", len(nfss)*3)
+
 	var wg sync.WaitGroup
 	wg.Add(len(nfss))
 
@@ -149,6 +206,8 @@ func (c *NFSCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			nfsLabels := c.nfsLabels(nfs)
+			collectResourceUnnamed(ch, c.Unnamed, nfs.ID, nfs.ZoneName, nfs.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, nfs.ID, nfs.ZoneName, nfs.CreatedAt)
 
 			var up float64
 			if nfs.InstanceStatus.IsUp() {
@@ -160,11 +219,12 @@ func (c *NFSCollector) Collect(ch chan<- prometheus.Metric) {
 				up,
 				nfsLabels...,
 			)
+			c.collectInstanceStatus(ch, nfs)
 			ch <- prometheus.MustNewConstMetric(
 				c.NFSInfo,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.nfsInfoLabels(nfs)...,
+				c.opts.withRegionLabelValue(c.nfsInfoLabels(nfs), nfs.ZoneName)...,
 			)
 
 			ch <- prometheus.MustNewConstMetric(
@@ -179,14 +239,14 @@ func (c *NFSCollector) Collect(ch chan<- prometheus.Metric) {
 				// Free disk size
 				wg.Add(1)
 				go func() {
-					c.collectFreeDiskSize(ch, nfs, now)
+					c.collectFreeDiskSize(ctx, ch, nfs, now)
 					wg.Done()
 				}()
 
 				// NICs
 				wg.Add(1)
 				go func() {
-					c.collectNICMetrics(ch, nfs, now)
+					c.collectNICMetrics(ctx, ch, nfs, now)
 					wg.Done()
 				}()
 
@@ -213,10 +273,46 @@ func (c *NFSCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
+var nfsInstanceStatuses = []types.EServerInstanceStatus{
+	types.ServerInstanceStatuses.Up,
+	types.ServerInstanceStatuses.Cleaning,
+	types.ServerInstanceStatuses.Down,
+}
+
+func (c *NFSCollector) collectInstanceStatus(ch chan<- prometheus.Metric, nfs *platform.NFS) {
+	known := false
+	for _, status := range nfsInstanceStatuses {
+		var value float64
+		if nfs.InstanceStatus == status {
+			value = 1.0
+			known = true
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.InstanceStatus,
+			prometheus.GaugeValue,
+			value,
+			append(c.nfsLabels(nfs), string(status))...,
+		)
+	}
+
+	if !known {
+		status := string(nfs.InstanceStatus)
+		if status == "" {
+			status = "unknown"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.InstanceStatus,
+			prometheus.GaugeValue,
+			float64(1.0),
+			append(c.nfsLabels(nfs), status)...,
+		)
+	}
+}
+
 func (c *NFSCollector) nfsLabels(nfs *platform.NFS) []string {
 	return []string{
 		nfs.ID.String(),
-		nfs.Name,
+		c.opts.truncateLabelValue(nfs.Name),
 		nfs.ZoneName,
 	}
 }
@@ -240,8 +336,8 @@ func (c *NFSCollector) nfsInfoLabels(nfs *platform.NFS) []string {
 		plan,
 		size,
 		instanceHost,
-		flattenStringSlice(nfs.Tags),
-		nfs.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(nfs.Tags)),
+		c.opts.truncateLabelValue(nfs.Description),
 	)
 }
 
@@ -271,8 +367,8 @@ func (c *NFSCollector) nicInfoLabels(nfs *platform.NFS) []string {
 	)
 }
 
-func (c *NFSCollector) collectFreeDiskSize(ch chan<- prometheus.Metric, nfs *platform.NFS, now time.Time) {
-	values, err := c.client.MonitorFreeDiskSize(c.ctx, nfs.ZoneName, nfs.ID, now)
+func (c *NFSCollector) collectFreeDiskSize(ctx context.Context, ch chan<- prometheus.Metric, nfs *platform.NFS, now time.Time) {
+	values, err := c.client.MonitorFreeDiskSize(ctx, nfs.ZoneName, nfs.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("nfs").Add(1)
 		c.logger.Warn(
@@ -297,10 +393,82 @@ func (c *NFSCollector) collectFreeDiskSize(ch chan<- prometheus.Metric, nfs *pla
 	)
 
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+
+	if eta, ok := c.freeDiskSizes.observe(nfs.ID, values.Time, values.FreeDiskSize); ok {
+		m = prometheus.MustNewConstMetric(
+			c.DiskFullETA,
+			prometheus.GaugeValue,
+			eta,
+			c.nfsLabels(nfs)...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
+}
+
+// nfsFreeDiskSizeHistory is the number of the recent free disk size samples used to estimate the time until the disk becomes full
+const nfsFreeDiskSizeHistory = 6
+
+// nfsFreeDiskSizeTracker keeps the recent free disk size samples of each NFS across Collects
+type nfsFreeDiskSizeTracker struct {
+	mu      sync.Mutex
+	samples map[types.ID][]observedFreeDiskSize
 }
 
-func (c *NFSCollector) collectNICMetrics(ch chan<- prometheus.Metric, nfs *platform.NFS, now time.Time) {
-	values, err := c.client.MonitorNIC(c.ctx, nfs.ZoneName, nfs.ID, now)
+type observedFreeDiskSize struct {
+	time time.Time
+	free float64
+}
+
+func newNFSFreeDiskSizeTracker() *nfsFreeDiskSizeTracker {
+	return &nfsFreeDiskSizeTracker{
+		samples: make(map[types.ID][]observedFreeDiskSize),
+	}
+}
+
+// observe records the free disk size sample of the NFS and returns the estimated seconds until the disk becomes full.
+// The estimation is only available when the free disk size has decreased over the recent samples.
+func (t *nfsFreeDiskSizeTracker) observe(id types.ID, sampleTime time.Time, free float64) (float64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	samples := t.samples[id]
+	if len(samples) == 0 || sampleTime.After(samples[len(samples)-1].time) {
+		samples = append(samples, observedFreeDiskSize{time: sampleTime, free: free})
+		if len(samples) > nfsFreeDiskSizeHistory {
+			samples = samples[len(samples)-nfsFreeDiskSizeHistory:]
+		}
+		t.samples[id] = samples
+	}
+	if len(samples) < 2 {
+		return 0, false
+	}
+
+	oldest, latest := samples[0], samples[len(samples)-1]
+	decrease := (oldest.free - latest.free) / latest.time.Sub(oldest.time).Seconds()
+	if decrease <= 0 {
+		return 0, false
+	}
+	return latest.free / decrease, true
+}
+
+// retain removes the NFSs which no longer exist
+func (t *nfsFreeDiskSizeTracker) retain(nfss []*platform.NFS) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exists := make(map[types.ID]bool)
+	for _, nfs := range nfss {
+		exists[nfs.ID] = true
+	}
+	for id := range t.samples {
+		if !exists[id] {
+			delete(t.samples, id)
+		}
+	}
+}
+
+func (c *NFSCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, nfs *platform.NFS, now time.Time) {
+	values, err := c.client.MonitorNIC(ctx, nfs.ZoneName, nfs.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("nfs").Add(1)
 		c.logger.Warn(
@@ -310,12 +478,17 @@ func (c *NFSCollector) collectNICMetrics(ch chan<- prometheus.Metric, nfs *platf
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
+	} else {
+		c.opts.collectMonitorSampleTimestamp(ch, c.MonitorSampleTimestamp, values.Time, c.nfsLabels(nfs)...)
 	}
 
 	receive := values.Receive
 	if receive > 0 {
-		receive = receive * 8 / 1000
+		receive = c.opts.convertNetworkTraffic(receive)
 	}
 	m := prometheus.MustNewConstMetric(
 		c.NICReceive,
@@ -327,7 +500,7 @@ func (c *NFSCollector) collectNICMetrics(ch chan<- prometheus.Metric, nfs *platf
 
 	send := values.Send
 	if send > 0 {
-		send = send * 8 / 1000
+		send = c.opts.convertNetworkTraffic(send)
 	}
 	m = prometheus.MustNewConstMetric(
 		c.NICSend,
@@ -344,7 +517,7 @@ func (c *NFSCollector) maintenanceInfoLabels(resource *platform.NFS, info *newsf
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
@@ -55,26 +55,32 @@ func (d *dummyNFSClient) MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, er
 
 func TestNFSCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewNFSCollector(context.Background(), testLogger, testErrors, &dummyNFSClient{})
+	c := NewNFSCollector(context.Background(), testLogger, testErrors, Options{}, &dummyNFSClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
+		c.InstanceStatus,
 		c.NFSInfo,
 		c.DiskFree,
+		c.DiskFullETA,
 		c.NICInfo,
 		c.NICReceive,
 		c.NICSend,
+		c.MonitorSampleTimestamp,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestNFSCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewNFSCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewNFSCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -136,6 +142,33 @@ func TestNFSCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "up",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "cleaning",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "down",
+					}),
+				},
 				{
 					desc: c.NFSInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -219,6 +252,33 @@ func TestNFSCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "up",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "cleaning",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "down",
+					}),
+				},
 				{
 					desc: c.NFSInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -319,6 +379,33 @@ func TestNFSCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "up",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "cleaning",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "down",
+					}),
+				},
 				{
 					desc: c.NFSInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -360,6 +447,99 @@ func TestNFSCollector_Collect(t *testing.T) {
 			},
 			wantErrCounter: 2,
 		},
+		{
+			name: "a cleaning nfs",
+			in: &dummyNFSClient{
+				find: []*platform.NFS{
+					{
+						ZoneName: "is1a",
+						NFS: &iaas.NFS{
+							ID:               101,
+							Name:             "nfs",
+							Tags:             types.Tags{"tag1", "tag2"},
+							Description:      "desc",
+							InstanceHostName: "sacXXX",
+							InstanceStatus:   types.ServerInstanceStatuses.Cleaning,
+							Availability:     types.Availabilities.Available,
+							IPAddresses:      []string{"192.168.0.11"},
+							DefaultRoute:     "192.168.0.1",
+							NetworkMaskLen:   24,
+							SwitchID:         201,
+							SwitchName:       "switch",
+						},
+						Plan: &query.NFSPlanInfo{
+							NFSPlanID:  1001,
+							Size:       types.NFSHDDSizes.Size100GB,
+							DiskPlanID: types.NFSPlans.HDD,
+						},
+						PlanName: "HDD 100GB",
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Up,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "nfs",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "up",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "cleaning",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "down",
+					}),
+				},
+				{
+					desc: c.NFSInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "nfs",
+						"zone":        "is1a",
+						"plan":        "HDD 100GB",
+						"size":        "100",
+						"host":        "sacXXX",
+						"tags":        ",tag1,tag2,",
+						"description": "desc",
+					}),
+				},
+				{
+					desc: c.NICInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":            "101",
+						"name":          "nfs",
+						"zone":          "is1a",
+						"upstream_id":   "201",
+						"upstream_name": "switch",
+						"ipaddress":     "192.168.0.11",
+						"nw_mask_len":   "24",
+						"gateway":       "192.168.0.1",
+					}),
+				},
+			},
+		},
 		{
 			name: "a nfs with maintenance info",
 			in: &dummyNFSClient{
@@ -407,6 +587,33 @@ func TestNFSCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "up",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "cleaning",
+					}),
+				},
+				{
+					desc: c.InstanceStatus,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":     "101",
+						"name":   "nfs",
+						"zone":   "is1a",
+						"status": "down",
+					}),
+				},
 				{
 					desc: c.NFSInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -487,3 +694,53 @@ func TestNFSCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestNFSCollector_DiskFullETA(t *testing.T) {
+	initLoggerAndErrors()
+	sampleTime := time.Unix(1700000000, 0)
+	client := &dummyNFSClient{
+		find: []*platform.NFS{
+			{
+				ZoneName: "is1a",
+				NFS: &iaas.NFS{
+					ID:             101,
+					Name:           "nfs",
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+					IPAddresses:    []string{"192.168.0.11"},
+					DefaultRoute:   "192.168.0.1",
+					NetworkMaskLen: 24,
+					SwitchID:       201,
+					SwitchName:     "switch",
+				},
+				Plan: &query.NFSPlanInfo{
+					NFSPlanID:  1001,
+					Size:       types.NFSHDDSizes.Size100GB,
+					DiskPlanID: types.NFSPlans.HDD,
+				},
+				PlanName: "HDD 100GB",
+			},
+		},
+	}
+	c := NewNFSCollector(context.Background(), testLogger, testErrors, Options{}, client)
+
+	collectDiskFullETA := func(free float64, at time.Time) []float64 {
+		client.monitorFree = &iaas.MonitorFreeDiskSizeValue{
+			Time:         at,
+			FreeDiskSize: free,
+		}
+		collected, err := collectMetrics(c, "nfs")
+		require.NoError(t, err)
+
+		got := collected.values(c.DiskFullETA)
+		return got
+	}
+
+	// the trend can't be computed from the first sample only
+	require.Empty(t, collectDiskFullETA(1000, sampleTime))
+	require.Empty(t, collectDiskFullETA(1000, sampleTime.Add(5*time.Minute)), "free disk size isn't decreasing")
+	// 800 / ((1000 - 800) / 600sec)
+	require.Equal(t, []float64{2400}, collectDiskFullETA(800, sampleTime.Add(10*time.Minute)))
+	// 700 / ((1000 - 700) / 900sec)
+	require.Equal(t, []float64{2100}, collectDiskFullETA(700, sampleTime.Add(15*time.Minute)))
+}
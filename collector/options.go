@@ -0,0 +1,72 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Options are the settings shared by the collectors
+type Options struct {
+	// MaxLabelLength is the maximum length(in characters) of free-form label values such as name/description/tags.
+	// If 0, label values are not truncated.
+	MaxLabelLength int
+
+	// LabelsTruncated is the counter of label values truncated by MaxLabelLength, created by NewLabelsTruncated.
+	// If nil, the truncations are not counted.
+	LabelsTruncated prometheus.Counter
+
+	// NetworkUnit is the unit of NIC traffic metrics, NetworkUnitBits or NetworkUnitBytes.
+	// If empty, NetworkUnitBits is used.
+	NetworkUnit string
+
+	// EmitZeroOnNil makes the collectors emit 0-valued samples with the scrape time when the monitor API returns no value.
+	//
+	// By default no sample is emitted in that case, which causes gaps in graphs and rate().
+	// Capacity metrics such as NFS free disk size and database memory are not affected
+	// because a 0 value would be mistaken for an actual value.
+	EmitZeroOnNil bool
+
+	// ExposeSampleTimestamps makes the collectors export the time of monitor samples as the value of
+	// sakuracloud_<resource>_monitor_sample_timestamp_seconds.
+	//
+	// This is useful for detecting the lag of the monitor API on storages that drop the timestamps attached to samples.
+	ExposeSampleTimestamps bool
+
+	// HelpLanguage is the language of metric help strings, HelpLanguageEnglish or HelpLanguageJapanese.
+	// If empty, HelpLanguageEnglish is used.
+	HelpLanguage string
+
+	// RecentlyCreatedWindow is the time window in which a resource is reported as recently created.
+	// If 0, sakuracloud_resource_recently_created is not collected.
+	RecentlyCreatedWindow time.Duration
+
+	// CountByTags is the list of tag keys for which sakuracloud_resource_count_by_tag is collected.
+	// Tags are parsed as "key=value". If empty, sakuracloud_resource_count_by_tag is not collected.
+	CountByTags []string
+
+	// GoroutineWarnThreshold is the number of goroutines per Collect of a single collector above which a warning is reported.
+	// If 0, the warning is disabled.
+	GoroutineWarnThreshold int
+
+	// GoroutineWarning is the gauge the goroutine warning is reported to, created by NewGoroutineWarning.
+	// If nil, the warning is only logged.
+	GoroutineWarning *prometheus.GaugeVec
+
+	// AddRegionLabel adds the region label derived from the zone name to the info metrics of zoned resources.
+	AddRegionLabel bool
+}
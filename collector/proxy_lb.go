@@ -16,15 +16,15 @@ package collector
 
 import (
 	"context"
-	"crypto/x509"
-	"encoding/pem"
 	"fmt"
 	"log/slog"
+	"net"
 	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
@@ -33,80 +33,169 @@ type ProxyLBCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.ProxyLBClient
 
-	Up          *prometheus.Desc
-	ProxyLBInfo *prometheus.Desc
+	maxCertSANs int
+	probe       bool
+
+	Up           *prometheus.Desc
+	ProxyLBInfo  *prometheus.Desc
+	AdvancedInfo *prometheus.Desc
+	RegionInfo   *prometheus.Desc
 
 	BindPortInfo *prometheus.Desc
+	TLSPolicy    *prometheus.Desc
+	VIPReachable *prometheus.Desc
 
-	ServerInfo *prometheus.Desc
+	ServerInfo         *prometheus.Desc
+	ServerCount        *prometheus.Desc
+	EnabledServerCount *prometheus.Desc
+	PortMapping        *prometheus.Desc
 
 	CertificateInfo       *prometheus.Desc
 	CertificateExpireDate *prometheus.Desc
+	CertificateSAN        *prometheus.Desc
+
+	AdditionalCertCount *prometheus.Desc
 
 	ActiveConnections *prometheus.Desc
 	ConnectionPerSec  *prometheus.Desc
+	PlanCPSCapacity   *prometheus.Desc
+	CPSCapacityRatio  *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewProxyLBCollector returns a new ProxyLBCollector.
-func NewProxyLBCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.ProxyLBClient) *ProxyLBCollector {
+//
+// maxCertSANs limits the number of sakuracloud_proxylb_cert_san series per certificate. 0 means no limit.
+// If probe is true, the VIP(or FQDN) of each bind port is probed by a TCP connect.
+func NewProxyLBCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.ProxyLBClient, maxCertSANs int, probe bool) *ProxyLBCollector {
 	errors.WithLabelValues("proxylb").Add(0)
 
 	proxyLBLabels := []string{"id", "name"}
 	proxyLBInfoLabels := append(proxyLBLabels, "plan", "vip", "fqdn",
 		"proxy_networks", "sorry_server_ipaddress", "sorry_server_port", "tags", "description")
+	proxyLBAdvancedInfoLabels := append(proxyLBLabels, "proxy_protocol", "timeout_seconds", "gzip")
+	proxyLBRegionInfoLabels := append(proxyLBLabels, "region", "vip", "fqdn")
 
 	proxyLBBindPortLabels := append(proxyLBLabels, "bind_port_index", "proxy_mode", "port")
 	proxyLBServerLabels := append(proxyLBLabels, "server_index", "ipaddress", "port", "enabled")
 	proxyLBCertificateLabels := append(proxyLBLabels, "cert_index")
 	proxyLBCertificateInfoLabels := append(proxyLBCertificateLabels, "common_name", "issuer_name")
+	proxyLBCertificateSANLabels := append(proxyLBCertificateLabels, "common_name", "san")
 
 	return &ProxyLBCollector{
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+
+		maxCertSANs: maxCertSANs,
+		probe:       probe,
+
+		Up: opts.newDesc(
 			"sakuracloud_proxylb_up",
 			"If 1 the ProxyLB is available, 0 otherwise",
 			proxyLBLabels, nil,
 		),
-		ProxyLBInfo: prometheus.NewDesc(
+		ProxyLBInfo: opts.newDesc(
 			"sakuracloud_proxylb_info",
 			"A metric with a constant '1' value labeled by proxyLB information",
 			proxyLBInfoLabels, nil,
 		),
-		BindPortInfo: prometheus.NewDesc(
+		AdvancedInfo: opts.newDesc(
+			"sakuracloud_proxylb_advanced_info",
+			"A metric with a constant '1' value labeled by proxyLB advanced settings",
+			proxyLBAdvancedInfoLabels, nil,
+		),
+		RegionInfo: opts.newDesc(
+			"sakuracloud_proxylb_region_info",
+			"A metric with a constant '1' value labeled by region and VIP of the proxyLB",
+			proxyLBRegionInfoLabels, nil,
+		),
+		BindPortInfo: opts.newDesc(
 			"sakuracloud_proxylb_bind_port_info",
 			"A metric with a constant '1' value labeled by BindPort information",
 			proxyLBBindPortLabels, nil,
 		),
-		ServerInfo: prometheus.NewDesc(
+		TLSPolicy: opts.newDesc(
+			"sakuracloud_proxylb_tls_policy",
+			"A metric with a constant '1' value labeled by TLS policy of the HTTPS BindPort",
+			append(proxyLBLabels, "bind_port_index", "policy"), nil,
+		),
+		VIPReachable: opts.newDesc(
+			"sakuracloud_proxylb_vip_reachable",
+			"If 1 the TCP connect to the bind port of the VIP succeeded, 0 otherwise",
+			append(proxyLBLabels, "bind_port_index", "port"), nil,
+		),
+		ServerInfo: opts.newDesc(
 			"sakuracloud_proxylb_server_info",
 			"A metric with a constant '1' value labeled by real-server information",
 			proxyLBServerLabels, nil,
 		),
-		CertificateInfo: prometheus.NewDesc(
+		ServerCount: opts.newDesc(
+			"sakuracloud_proxylb_server_count",
+			"The number of real-servers",
+			proxyLBLabels, nil,
+		),
+		EnabledServerCount: opts.newDesc(
+			"sakuracloud_proxylb_enabled_server_count",
+			"The number of enabled real-servers",
+			proxyLBLabels, nil,
+		),
+		PortMapping: opts.newDesc(
+			"sakuracloud_proxylb_port_mapping",
+			"The number of real-servers listening on a port different from the bind port",
+			append(proxyLBLabels, "bind_port", "server_port"), nil,
+		),
+		CertificateInfo: opts.newDesc(
 			"sakuracloud_proxylb_cert_info",
 			"A metric with a constant '1' value labeled by certificate information",
 			proxyLBCertificateInfoLabels, nil,
 		),
-		CertificateExpireDate: prometheus.NewDesc(
+		CertificateExpireDate: opts.newDesc(
 			"sakuracloud_proxylb_cert_expire",
 			"Certificate expiration date in seconds since epoch (1970)",
 			proxyLBCertificateLabels, nil,
 		),
-		ActiveConnections: prometheus.NewDesc(
+		CertificateSAN: opts.newDesc(
+			"sakuracloud_proxylb_cert_san",
+			"A metric with a constant '1' value labeled by subject alternative name of the certificate",
+			proxyLBCertificateSANLabels, nil,
+		),
+		AdditionalCertCount: opts.newDesc(
+			"sakuracloud_proxylb_additional_cert_count",
+			"The number of additional certificates",
+			proxyLBLabels, nil,
+		),
+		ActiveConnections: opts.newDesc(
 			"sakuracloud_proxylb_active_connections",
 			"Active connection count",
 			proxyLBLabels, nil,
 		),
-		ConnectionPerSec: prometheus.NewDesc(
+		ConnectionPerSec: opts.newDesc(
 			"sakuracloud_proxylb_connection_per_sec",
 			"Connection count per second",
 			proxyLBLabels, nil,
 		),
+		PlanCPSCapacity: opts.newDesc(
+			"sakuracloud_proxylb_plan_cps_capacity",
+			"Connection count per second allowed by the ProxyLB's plan",
+			proxyLBLabels, nil,
+		),
+		CPSCapacityRatio: opts.newDesc(
+			"sakuracloud_proxylb_cps_capacity_ratio",
+			"Ratio of connection count per second to the capacity of the ProxyLB's plan",
+			proxyLBLabels, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("proxylb"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("proxylb"),
+		CountByTag:      opts.newResourceCountByTagDesc("proxylb"),
 	}
 }
 
@@ -115,17 +204,36 @@ func NewProxyLBCollector(ctx context.Context, logger *slog.Logger, errors *prome
 func (c *ProxyLBCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
 	ch <- c.ProxyLBInfo
+	ch <- c.AdvancedInfo
+	ch <- c.RegionInfo
 	ch <- c.BindPortInfo
+	ch <- c.TLSPolicy
+	ch <- c.VIPReachable
 	ch <- c.ServerInfo
+	ch <- c.ServerCount
+	ch <- c.EnabledServerCount
+	ch <- c.PortMapping
 	ch <- c.CertificateInfo
 	ch <- c.CertificateExpireDate
+	ch <- c.CertificateSAN
+	ch <- c.AdditionalCertCount
 	ch <- c.ActiveConnections
 	ch <- c.ConnectionPerSec
+	ch <- c.PlanCPSCapacity
+	ch <- c.CPSCapacityRatio
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
-	proxyLBs, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *ProxyLBCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	proxyLBs, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("proxylb").Add(1)
 		c.logger.Warn(
@@ -134,6 +242,14 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(proxyLBs))
+	for _, proxyLB := range proxyLBs {
+		tags = append(tags, proxyLB.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	c.opts.warnGoroutines(c.logger, "proxylb", estimateProxyLBGoroutines(proxyLBs))
+
 	var wg sync.WaitGroup
 	wg.Add(len(proxyLBs))
 
@@ -142,6 +258,8 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			proxyLBLabels := c.proxyLBLabels(proxyLB)
+			collectResourceUnnamed(ch, c.Unnamed, proxyLB.ID, "", proxyLB.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, proxyLB.ID, "", proxyLB.CreatedAt)
 
 			var up float64
 			if proxyLB.Availability.IsAvailable() {
@@ -157,7 +275,7 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 			for i := range proxyLB.BindPorts {
 				wg.Add(1)
 				go func(index int) {
-					c.collectProxyLBBindPortInfo(ch, proxyLB, index)
+					c.collectProxyLBBindPortInfo(ctx, ch, proxyLB, index)
 					wg.Done()
 				}(i)
 			}
@@ -169,6 +287,8 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 					wg.Done()
 				}(i)
 			}
+			c.collectProxyLBServerCount(ch, proxyLB)
+			c.collectProxyLBPortMapping(ch, proxyLB)
 
 			wg.Add(1)
 			go func() {
@@ -178,7 +298,13 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 
 			wg.Add(1)
 			go func() {
-				c.collectProxyLBCertInfo(ch, proxyLB)
+				c.collectProxyLBAdvancedInfo(ch, proxyLB)
+				wg.Done()
+			}()
+
+			wg.Add(1)
+			go func() {
+				c.collectProxyLBCertInfo(ctx, ch, proxyLB)
 				wg.Done()
 			}()
 
@@ -187,7 +313,7 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 
 				wg.Add(1)
 				go func() {
-					c.collectProxyLBMetrics(ch, proxyLB, now)
+					c.collectProxyLBMetrics(ctx, ch, proxyLB, now)
 					wg.Done()
 				}()
 			}
@@ -197,13 +323,34 @@ func (c *ProxyLBCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
+// estimateProxyLBGoroutines returns the upper bound of the number of goroutines spawned per Collect:
+// info of each bind port/server, and info, advanced info, certificates and metrics for each proxyLB
+func estimateProxyLBGoroutines(proxyLBs []*iaas.ProxyLB) int {
+	n := 0
+	for _, proxyLB := range proxyLBs {
+		n += len(proxyLB.BindPorts) + len(proxyLB.Servers) + 4
+	}
+	return n
+}
+
 func (c *ProxyLBCollector) proxyLBLabels(proxyLB *iaas.ProxyLB) []string {
 	return []string{
 		proxyLB.ID.String(),
-		proxyLB.Name,
+		c.opts.truncateLabelValue(proxyLB.Name),
 	}
 }
 
+var proxyLBPlanCPSCapacities = map[types.EProxyLBPlan]float64{
+	types.ProxyLBPlans.CPS100:    100,
+	types.ProxyLBPlans.CPS500:    500,
+	types.ProxyLBPlans.CPS1000:   1_000,
+	types.ProxyLBPlans.CPS5000:   5_000,
+	types.ProxyLBPlans.CPS10000:  10_000,
+	types.ProxyLBPlans.CPS50000:  50_000,
+	types.ProxyLBPlans.CPS100000: 100_000,
+	types.ProxyLBPlans.CPS400000: 400_000,
+}
+
 func (c *ProxyLBCollector) collectProxyLBInfo(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
 	sorryServerPort := ""
 	if proxyLB.SorryServer.Port > 0 {
@@ -217,8 +364,8 @@ func (c *ProxyLBCollector) collectProxyLBInfo(ch chan<- prometheus.Metric, proxy
 		flattenStringSlice(proxyLB.ProxyNetworks),
 		proxyLB.SorryServer.IPAddress,
 		sorryServerPort,
-		flattenStringSlice(proxyLB.Tags),
-		proxyLB.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(proxyLB.Tags)),
+		c.opts.truncateLabelValue(proxyLB.Description),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -227,9 +374,58 @@ func (c *ProxyLBCollector) collectProxyLBInfo(ch chan<- prometheus.Metric, proxy
 		float64(1.0),
 		labels...,
 	)
+
+	if capacity, ok := proxyLBPlanCPSCapacities[proxyLB.Plan]; ok {
+		ch <- prometheus.MustNewConstMetric(
+			c.PlanCPSCapacity,
+			prometheus.GaugeValue,
+			capacity,
+			c.proxyLBLabels(proxyLB)...,
+		)
+	}
+
+	regionLabels := append(c.proxyLBLabels(proxyLB),
+		proxyLB.Region.String(),
+		proxyLB.VirtualIPAddress,
+		proxyLB.FQDN,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.RegionInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		regionLabels...,
+	)
 }
 
-func (c *ProxyLBCollector) collectProxyLBBindPortInfo(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, index int) {
+func (c *ProxyLBCollector) collectProxyLBAdvancedInfo(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
+	proxyProtocol := "0"
+	if proxyLB.ProxyProtocol != nil && proxyLB.ProxyProtocol.Enabled {
+		proxyProtocol = "1"
+	}
+	timeout := ""
+	if proxyLB.Timeout != nil && proxyLB.Timeout.InactiveSec > 0 {
+		timeout = fmt.Sprintf("%d", proxyLB.Timeout.InactiveSec)
+	}
+	gzip := "0"
+	if proxyLB.Gzip != nil && proxyLB.Gzip.Enabled {
+		gzip = "1"
+	}
+
+	labels := append(c.proxyLBLabels(proxyLB),
+		proxyProtocol,
+		timeout,
+		gzip,
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.AdvancedInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		labels...,
+	)
+}
+
+func (c *ProxyLBCollector) collectProxyLBBindPortInfo(ctx context.Context, ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, index int) {
 	bindPort := proxyLB.BindPorts[index]
 	labels := append(c.proxyLBLabels(proxyLB),
 		fmt.Sprintf("%d", index),
@@ -243,6 +439,61 @@ func (c *ProxyLBCollector) collectProxyLBBindPortInfo(ch chan<- prometheus.Metri
 		float64(1.0),
 		labels...,
 	)
+
+	if bindPort.ProxyMode == types.ProxyLBProxyModes.HTTPS {
+		policy := bindPort.SSLPolicy
+		if policy == "" {
+			policy = "default"
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.TLSPolicy,
+			prometheus.GaugeValue,
+			float64(1.0),
+			append(c.proxyLBLabels(proxyLB), fmt.Sprintf("%d", index), policy)...,
+		)
+	}
+
+	if c.probe {
+		c.collectProxyLBVIPReachable(ctx, ch, proxyLB, index)
+	}
+}
+
+// proxyLBProbeTimeout is the upper bound of the TCP connect to the VIP per bind port
+const proxyLBProbeTimeout = 3 * time.Second
+
+func (c *ProxyLBCollector) collectProxyLBVIPReachable(ctx context.Context, ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, index int) {
+	host := proxyLB.VirtualIPAddress
+	if host == "" {
+		host = proxyLB.FQDN
+	}
+	if host == "" {
+		return
+	}
+	bindPort := proxyLB.BindPorts[index]
+	port := fmt.Sprintf("%d", bindPort.Port)
+
+	ctx, cancel := context.WithTimeout(ctx, proxyLBProbeTimeout)
+	defer cancel()
+
+	var reachable float64
+	dialer := &net.Dialer{Timeout: proxyLBProbeTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+	if err == nil {
+		reachable = 1.0
+		conn.Close() //nolint:errcheck
+	} else {
+		c.logger.Debug(
+			fmt.Sprintf("can't connect to the VIP of ProxyLB[%s]: bind port[%d]", proxyLB.ID, index),
+			slog.Any("err", err),
+		)
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.VIPReachable,
+		prometheus.GaugeValue,
+		reachable,
+		append(c.proxyLBLabels(proxyLB), fmt.Sprintf("%d", index), port)...,
+	)
 }
 
 func (c *ProxyLBCollector) collectProxyLBServerInfo(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, index int) {
@@ -265,8 +516,59 @@ func (c *ProxyLBCollector) collectProxyLBServerInfo(ch chan<- prometheus.Metric,
 	)
 }
 
-func (c *ProxyLBCollector) collectProxyLBCertInfo(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
-	cert, err := c.client.GetCertificate(c.ctx, proxyLB.ID)
+func (c *ProxyLBCollector) collectProxyLBServerCount(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
+	var enabled int
+	for _, server := range proxyLB.Servers {
+		if server.Enabled {
+			enabled++
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.ServerCount,
+		prometheus.GaugeValue,
+		float64(len(proxyLB.Servers)),
+		c.proxyLBLabels(proxyLB)...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.EnabledServerCount,
+		prometheus.GaugeValue,
+		float64(enabled),
+		c.proxyLBLabels(proxyLB)...,
+	)
+}
+
+type proxyLBPortMapping struct {
+	bindPort   int
+	serverPort int
+}
+
+func (c *ProxyLBCollector) collectProxyLBPortMapping(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
+	bindPorts := make(map[int]bool)
+	for _, bindPort := range proxyLB.BindPorts {
+		bindPorts[bindPort.Port] = true
+	}
+
+	mappings := make(map[proxyLBPortMapping]int)
+	for bindPort := range bindPorts {
+		for _, server := range proxyLB.Servers {
+			if server.Port != bindPort {
+				mappings[proxyLBPortMapping{bindPort: bindPort, serverPort: server.Port}]++
+			}
+		}
+	}
+
+	for mapping, count := range mappings {
+		ch <- prometheus.MustNewConstMetric(
+			c.PortMapping,
+			prometheus.GaugeValue,
+			float64(count),
+			append(c.proxyLBLabels(proxyLB), fmt.Sprintf("%d", mapping.bindPort), fmt.Sprintf("%d", mapping.serverPort))...,
+		)
+	}
+}
+
+func (c *ProxyLBCollector) collectProxyLBCertInfo(ctx context.Context, ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB) {
+	cert, err := c.client.GetCertificate(ctx, proxyLB.ID)
 	if err != nil {
 		c.errors.WithLabelValues("proxylb").Add(1)
 		c.logger.Warn(
@@ -283,15 +585,7 @@ func (c *ProxyLBCollector) collectProxyLBCertInfo(ch chan<- prometheus.Metric, p
 		return
 	}
 
-	var commonName, issuerName string
-	block, _ := pem.Decode([]byte(cert.PrimaryCert.ServerCertificate))
-	if block != nil {
-		c, err := x509.ParseCertificate(block.Bytes) // ignore err
-		if err == nil {
-			commonName = c.Subject.CommonName
-			issuerName = c.Issuer.CommonName
-		}
-	}
+	commonName, issuerName, sans := parseServerCertificate(cert.PrimaryCert.ServerCertificate)
 
 	certLabels := append(c.proxyLBLabels(proxyLB), "0")
 	infoLabels := append(certLabels, commonName, issuerName)
@@ -311,16 +605,17 @@ func (c *ProxyLBCollector) collectProxyLBCertInfo(ch chan<- prometheus.Metric, p
 		certLabels...,
 	)
 
+	c.collectProxyLBCertSANs(ch, certLabels, commonName, sans)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.AdditionalCertCount,
+		prometheus.GaugeValue,
+		float64(len(cert.AdditionalCerts)),
+		c.proxyLBLabels(proxyLB)...,
+	)
+
 	for i, cert := range cert.AdditionalCerts {
-		var commonName, issuerName string
-		block, _ := pem.Decode([]byte(cert.ServerCertificate))
-		if block != nil {
-			c, err := x509.ParseCertificate(block.Bytes) // ignore err
-			if err == nil {
-				commonName = c.Subject.CommonName
-				issuerName = c.Issuer.CommonName
-			}
-		}
+		commonName, issuerName, sans := parseServerCertificate(cert.ServerCertificate)
 
 		certLabels := append(c.proxyLBLabels(proxyLB), fmt.Sprintf("%d", i+1))
 		infoLabels := append(certLabels, commonName, issuerName)
@@ -339,11 +634,31 @@ func (c *ProxyLBCollector) collectProxyLBCertInfo(ch chan<- prometheus.Metric, p
 			float64(cert.CertificateEndDate.Unix())*1000,
 			certLabels...,
 		)
+
+		c.collectProxyLBCertSANs(ch, certLabels, commonName, sans)
 	}
 }
 
-func (c *ProxyLBCollector) collectProxyLBMetrics(ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, now time.Time) {
-	values, err := c.client.Monitor(c.ctx, proxyLB.ID, now)
+func (c *ProxyLBCollector) collectProxyLBCertSANs(ch chan<- prometheus.Metric, certLabels []string, commonName string, sans []string) {
+	if c.maxCertSANs > 0 && len(sans) > c.maxCertSANs {
+		sans = sans[:c.maxCertSANs]
+	}
+	for _, san := range sans {
+		labels := make([]string, 0, len(certLabels)+2)
+		labels = append(labels, certLabels...)
+		labels = append(labels, commonName, san)
+
+		ch <- prometheus.MustNewConstMetric(
+			c.CertificateSAN,
+			prometheus.GaugeValue,
+			float64(1.0),
+			labels...,
+		)
+	}
+}
+
+func (c *ProxyLBCollector) collectProxyLBMetrics(ctx context.Context, ch chan<- prometheus.Metric, proxyLB *iaas.ProxyLB, now time.Time) {
+	values, err := c.client.Monitor(ctx, proxyLB.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("proxylb").Add(1)
 		c.logger.Warn(
@@ -353,7 +668,10 @@ func (c *ProxyLBCollector) collectProxyLBMetrics(ch chan<- prometheus.Metric, pr
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorConnectionValue{Time: now}
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -370,4 +688,14 @@ func (c *ProxyLBCollector) collectProxyLBMetrics(ch chan<- prometheus.Metric, pr
 		c.proxyLBLabels(proxyLB)...,
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+
+	if capacity := proxyLBPlanCPSCapacities[proxyLB.Plan]; capacity > 0 {
+		m = prometheus.MustNewConstMetric(
+			c.CPSCapacityRatio,
+			prometheus.GaugeValue,
+			values.ConnectionsPerSec/capacity,
+			c.proxyLBLabels(proxyLB)...,
+		)
+		ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
+	}
 }
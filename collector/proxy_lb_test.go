@@ -17,10 +17,13 @@ package collector
 import (
 	"context"
 	"errors"
+	"net"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
@@ -48,24 +51,38 @@ func (d *dummyProxyLBClient) Monitor(ctx context.Context, id types.ID, end time.
 
 func TestProxyLBCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, &dummyProxyLBClient{})
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{}, 0, false)
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
 		c.ProxyLBInfo,
+		c.AdvancedInfo,
+		c.RegionInfo,
 		c.BindPortInfo,
+		c.TLSPolicy,
+		c.VIPReachable,
 		c.ServerInfo,
+		c.ServerCount,
+		c.EnabledServerCount,
+		c.PortMapping,
 		c.CertificateInfo,
 		c.CertificateExpireDate,
+		c.CertificateSAN,
+		c.AdditionalCertCount,
 		c.ActiveConnections,
 		c.ConnectionPerSec,
+		c.PlanCPSCapacity,
+		c.CPSCapacityRatio,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestProxyLBCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, nil, 0, false)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -99,7 +116,7 @@ func TestProxyLBCollector_Collect(t *testing.T) {
 						Description:  "desc",
 						Tags:         types.Tags{"tag1", "tag2"},
 						Availability: types.Availabilities.Available,
-						Plan:         types.ProxyLBPlans.CPS100,
+						Plan:         types.ProxyLBPlans.CPS500,
 						HealthCheck: &iaas.ProxyLBHealthCheck{
 							Protocol:  types.ProxyLBProtocols.HTTP,
 							Path:      "/",
@@ -117,6 +134,7 @@ func TestProxyLBCollector_Collect(t *testing.T) {
 							{
 								ProxyMode: types.ProxyLBProxyModes.HTTPS,
 								Port:      443,
+								SSLPolicy: "TLS-1-2-2021-06",
 							},
 						},
 						Servers: []*iaas.ProxyLBServer{
@@ -131,6 +149,9 @@ func TestProxyLBCollector_Collect(t *testing.T) {
 						ProxyNetworks:    []string{"133.242.0.0/24"},
 						FQDN:             "site-xxx.proxylb.sakura.ne.jp",
 						VirtualIPAddress: "192.0.2.1",
+						ProxyProtocol:    &iaas.ProxyLBProxyProtocol{Enabled: true},
+						Timeout:          &iaas.ProxyLBTimeout{InactiveSec: 30},
+						Gzip:             &iaas.ProxyLBGzip{Enabled: true},
 					},
 				},
 				cert: &iaas.ProxyLBCertificates{
@@ -247,6 +268,15 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"port":            "443",
 					}),
 				},
+				{
+					desc: c.TLSPolicy,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "proxylb",
+						"bind_port_index": "1",
+						"policy":          "TLS-1-2-2021-06",
+					}),
+				},
 				{
 					desc: c.ServerInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -258,12 +288,35 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"enabled":      "1",
 					}),
 				},
+				{
+					desc: c.ServerCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.EnabledServerCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.PortMapping,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "proxylb",
+						"bind_port":   "443",
+						"server_port": "80",
+					}),
+				},
 				{
 					desc: c.ProxyLBInfo,
 					metric: createGaugeMetric(1, map[string]string{
 						"id":                     "101",
 						"name":                   "proxylb",
-						"plan":                   "100",
+						"plan":                   "500",
 						"vip":                    "192.0.2.1",
 						"fqdn":                   "site-xxx.proxylb.sakura.ne.jp",
 						"proxy_networks":         ",133.242.0.0/24,",
@@ -273,6 +326,26 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"description":            "desc",
 					}),
 				},
+				{
+					desc: c.RegionInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "proxylb",
+						"region": "tk1",
+						"vip":    "192.0.2.1",
+						"fqdn":   "site-xxx.proxylb.sakura.ne.jp",
+					}),
+				},
+				{
+					desc: c.AdvancedInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "proxylb",
+						"proxy_protocol":  "1",
+						"timeout_seconds": "30",
+						"gzip":            "1",
+					}),
+				},
 				{
 					desc: c.ActiveConnections,
 					metric: createGaugeWithTimestamp(100, map[string]string{
@@ -287,6 +360,20 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"name": "proxylb",
 					}, monitorTime),
 				},
+				{
+					desc: c.PlanCPSCapacity,
+					metric: createGaugeMetric(500, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.CPSCapacityRatio,
+					metric: createGaugeWithTimestamp(0.4, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}, monitorTime),
+				},
 				{
 					desc: c.CertificateInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -305,6 +392,13 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"cert_index": "0",
 					}),
 				},
+				{
+					desc: c.AdditionalCertCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
 			},
 		},
 		{
@@ -382,6 +476,15 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"port":            "443",
 					}),
 				},
+				{
+					desc: c.TLSPolicy,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "proxylb",
+						"bind_port_index": "1",
+						"policy":          "default",
+					}),
+				},
 				{
 					desc: c.ServerInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -393,6 +496,29 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"enabled":      "1",
 					}),
 				},
+				{
+					desc: c.ServerCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.EnabledServerCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.PortMapping,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "proxylb",
+						"bind_port":   "443",
+						"server_port": "80",
+					}),
+				},
 				{
 					desc: c.ProxyLBInfo,
 					metric: createGaugeMetric(1, map[string]string{
@@ -408,6 +534,33 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 						"description":            "desc",
 					}),
 				},
+				{
+					desc: c.PlanCPSCapacity,
+					metric: createGaugeMetric(100, map[string]string{
+						"id":   "101",
+						"name": "proxylb",
+					}),
+				},
+				{
+					desc: c.RegionInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":     "101",
+						"name":   "proxylb",
+						"region": "tk1",
+						"vip":    "192.0.2.1",
+						"fqdn":   "site-xxx.proxylb.sakura.ne.jp",
+					}),
+				},
+				{
+					desc: c.AdvancedInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "proxylb",
+						"proxy_protocol":  "0",
+						"timeout_seconds": "",
+						"gzip":            "0",
+					}),
+				},
 			},
 			wantErrCounter: 2,
 			wantLogs: []string{
@@ -430,3 +583,254 @@ CIKJ5Fd6mrntdmXkW1NWz/DR0a04wSB/eiCz8J8KGfx78/S44JUyDZQq6S+1JMzL
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+const proxyLBMultiSANCert = `-----BEGIN CERTIFICATE-----
+MIIDSzCCAjOgAwIBAgIUSB74yScVJwh8yUOcABjCm5HnG3swDQYJKoZIhvcNAQEL
+BQAwFjEUMBIGA1UEAwwLZXhhbXBsZS5jb20wIBcNMjYxMDE0MTAzNzEwWhgPMjEy
+NjA5MjAxMDM3MTBaMBYxFDASBgNVBAMMC2V4YW1wbGUuY29tMIIBIjANBgkqhkiG
+9w0BAQEFAAOCAQ8AMIIBCgKCAQEAnwURtkRjGUqwSIfLR4fi6x+hMSPsw7F7wzBf
+qamLmpIs2FsF20l8uzEBEAt0qZ1qbQQdyYKO9SBuwkNID8uC+IyNwVdjcx00/PbO
+qpoS74ae3pmCMqsFvs7BscivQyySR8OO0WKhf6puJMBXO7KI/Lmg4354X/zXBctf
+ScKfF0L6lWAUGAomS/F2F/2u90Ah41z48wfqHQje47cKiQD2wD13/BkNnmzQpzbG
+i1qY8lDkzpU9eChlqJaZrGhmb4sw5IDMRjXOD3Gu8pF9j+ZJF1x9N8EI6WP+GSS5
+VEG6hMLePyB7Z/L7unDmE5k/bNP8SJY7HtzuV4W/YF9TxLbzgQIDAQABo4GOMIGL
+MB0GA1UdDgQWBBSaI8yr9BScutOTXqFdJhhu3IhBUTAfBgNVHSMEGDAWgBSaI8yr
+9BScutOTXqFdJhhu3IhBUTAPBgNVHRMBAf8EBTADAQH/MDgGA1UdEQQxMC+CC2V4
+YW1wbGUuY29tgg93d3cuZXhhbXBsZS5jb22CD2FwaS5leGFtcGxlLmNvbTANBgkq
+hkiG9w0BAQsFAAOCAQEAPheCi8fBPM9b6mSoccNJXkL64QTe5SxwWJmDInfICtKT
+5QRyKUvqebTL/9hPXzeBPhYzz6+oNAJtOkvqjx1IiIygmsxVdg8KemfJiT0GbF5T
+Rp4yQEkKdHkF5lw3myW/gIUBJnY0j+PYieCvY2zkfO6XWddYZTKM2UmxpbVT8L/2
+fQKZ3lxkY/SKfKBBM455CwdG8aF/A+qsA45f82RT3OkX8Ex+47NoKbVui3xqwLX7
+E/62w11KRRgcYCzqXeulCcsadzjAMGZpfyVAHwHjHBdowUbFGrUg3Dp8MTeUQfz4
+1hFzHnyAXRceiNo8rcq9RHYFbBC0u48NjaLVmSpIrg==
+-----END CERTIFICATE-----`
+
+func TestProxyLBCollector_CertSAN(t *testing.T) {
+	proxyLB := &iaas.ProxyLB{
+		ID:           101,
+		Name:         "proxylb",
+		Availability: types.Availabilities.Migrating,
+		SorryServer:  &iaas.ProxyLBSorryServer{},
+	}
+	client := &dummyProxyLBClient{
+		find: []*iaas.ProxyLB{proxyLB},
+		cert: &iaas.ProxyLBCertificates{
+			PrimaryCert: &iaas.ProxyLBPrimaryCert{
+				ServerCertificate:  proxyLBMultiSANCert,
+				PrivateKey:         "dummy",
+				CertificateEndDate: time.Unix(1, 0),
+			},
+		},
+	}
+	sanMetric := func(san string) *dto.Metric {
+		return createGaugeMetric(1, map[string]string{
+			"id":          "101",
+			"name":        "proxylb",
+			"cert_index":  "0",
+			"common_name": "example.com",
+			"san":         san,
+		})
+	}
+
+	cases := []struct {
+		name        string
+		maxCertSANs int
+		want        []*dto.Metric
+	}{
+		{
+			name:        "no limit",
+			maxCertSANs: 0,
+			want: []*dto.Metric{
+				sanMetric("example.com"),
+				sanMetric("www.example.com"),
+				sanMetric("api.example.com"),
+			},
+		},
+		{
+			name:        "limited",
+			maxCertSANs: 2,
+			want: []*dto.Metric{
+				sanMetric("example.com"),
+				sanMetric("www.example.com"),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, client, tc.maxCertSANs, false)
+
+		collected, err := collectMetrics(c, "proxylb")
+		require.NoError(t, err)
+
+		sans := collected.metrics(c.CertificateSAN)
+		require.Equal(t, tc.want, sans, tc.name)
+	}
+}
+
+func TestProxyLBCollector_ServerCount(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{
+		find: []*iaas.ProxyLB{
+			{
+				ID:           101,
+				Name:         "proxylb",
+				Availability: types.Availabilities.Migrating,
+				SorryServer:  &iaas.ProxyLBSorryServer{},
+				Servers: []*iaas.ProxyLBServer{
+					{IPAddress: "192.168.0.101", Port: 80, Enabled: true},
+					{IPAddress: "192.168.0.102", Port: 80, Enabled: false},
+					{IPAddress: "192.168.0.103", Port: 80, Enabled: true},
+				},
+			},
+		},
+	}, 0, false)
+
+	collected, err := collectMetrics(c, "proxylb")
+	require.NoError(t, err)
+
+	labels := map[string]string{
+		"id":   "101",
+		"name": "proxylb",
+	}
+	require.Equal(t, []*dto.Metric{createGaugeMetric(3, labels)}, collected.metrics(c.ServerCount))
+	require.Equal(t, []*dto.Metric{createGaugeMetric(2, labels)}, collected.metrics(c.EnabledServerCount))
+}
+
+func TestProxyLBCollector_AdditionalCertCount(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{
+		find: []*iaas.ProxyLB{
+			{
+				ID:           101,
+				Name:         "proxylb",
+				Availability: types.Availabilities.Migrating,
+				SorryServer:  &iaas.ProxyLBSorryServer{},
+			},
+		},
+		cert: &iaas.ProxyLBCertificates{
+			PrimaryCert: &iaas.ProxyLBPrimaryCert{
+				ServerCertificate:  proxyLBMultiSANCert,
+				PrivateKey:         "dummy",
+				CertificateEndDate: time.Unix(1, 0),
+			},
+			AdditionalCerts: []*iaas.ProxyLBAdditionalCert{
+				{
+					ServerCertificate:  proxyLBMultiSANCert,
+					PrivateKey:         "dummy",
+					CertificateEndDate: time.Unix(2, 0),
+				},
+				{
+					ServerCertificate:  proxyLBMultiSANCert,
+					PrivateKey:         "dummy",
+					CertificateEndDate: time.Unix(3, 0),
+				},
+			},
+		},
+	}, 0, false)
+
+	collected, err := collectMetrics(c, "proxylb")
+	require.NoError(t, err)
+
+	counts := collected.metrics(c.AdditionalCertCount)
+	require.Equal(t, []*dto.Metric{
+		createGaugeMetric(2, map[string]string{
+			"id":   "101",
+			"name": "proxylb",
+		}),
+	}, counts)
+}
+
+func TestProxyLBCollector_VIPReachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	openPort := listener.Addr().(*net.TCPAddr).Port
+
+	// a port that was listened once and then closed
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedPort := closed.Addr().(*net.TCPAddr).Port
+	require.NoError(t, closed.Close())
+
+	initLoggerAndErrors()
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{
+		find: []*iaas.ProxyLB{
+			{
+				ID:               101,
+				Name:             "proxylb",
+				Availability:     types.Availabilities.Migrating,
+				VirtualIPAddress: "127.0.0.1",
+				SorryServer:      &iaas.ProxyLBSorryServer{},
+				BindPorts: []*iaas.ProxyLBBindPort{
+					{ProxyMode: types.ProxyLBProxyModes.TCP, Port: openPort},
+					{ProxyMode: types.ProxyLBProxyModes.TCP, Port: closedPort},
+				},
+			},
+		},
+	}, 0, true)
+
+	collected, err := collectMetrics(c, "proxylb")
+	require.NoError(t, err)
+
+	reachable := collected.filter(c.VIPReachable)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.VIPReachable,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":              "101",
+				"name":            "proxylb",
+				"bind_port_index": "0",
+				"port":            strconv.Itoa(openPort),
+			}),
+		},
+		{
+			desc: c.VIPReachable,
+			metric: createGaugeMetric(0, map[string]string{
+				"id":              "101",
+				"name":            "proxylb",
+				"bind_port_index": "1",
+				"port":            strconv.Itoa(closedPort),
+			}),
+		},
+	}, reachable)
+}
+
+func TestProxyLBCollector_PortMapping(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewProxyLBCollector(context.Background(), testLogger, testErrors, Options{}, &dummyProxyLBClient{
+		find: []*iaas.ProxyLB{
+			{
+				ID:           101,
+				Name:         "proxylb",
+				Availability: types.Availabilities.Migrating,
+				SorryServer:  &iaas.ProxyLBSorryServer{},
+				BindPorts: []*iaas.ProxyLBBindPort{
+					{ProxyMode: types.ProxyLBProxyModes.HTTPS, Port: 443},
+				},
+				Servers: []*iaas.ProxyLBServer{
+					{IPAddress: "192.168.0.101", Port: 80, Enabled: true},
+					{IPAddress: "192.168.0.102", Port: 80, Enabled: true},
+					{IPAddress: "192.168.0.103", Port: 443, Enabled: true},
+				},
+			},
+		},
+	}, 0, false)
+
+	collected, err := collectMetrics(c, "proxylb")
+	require.NoError(t, err)
+
+	mappings := collected.filter(c.PortMapping)
+	// the server listening on the bind port itself is not a translation
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.PortMapping,
+			metric: createGaugeMetric(2, map[string]string{
+				"id":          "101",
+				"name":        "proxylb",
+				"bind_port":   "443",
+				"server_port": "80",
+			}),
+		},
+	}, mappings)
+}
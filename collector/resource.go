@@ -0,0 +1,141 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+// newResourceUnnamedDesc returns the Desc of sakuracloud_resource_unnamed for each resource type.
+//
+// This metric is exposed by multiple collectors, so resource_type is a const label
+// to keep each Desc unique within the registry.
+func (o Options) newResourceUnnamedDesc(resourceType string) *prometheus.Desc {
+	return o.newDesc(
+		"sakuracloud_resource_unnamed",
+		"A metric with a constant '1' value for resources that have an empty or a placeholder name",
+		[]string{"id", "zone"}, prometheus.Labels{"resource_type": resourceType},
+	)
+}
+
+// unnamedPlaceholders are the names regarded as unnamed in addition to empty names.
+// These are compared case-insensitively.
+var unnamedPlaceholders = []string{
+	"unnamed",
+	"untitled",
+	"noname",
+	"no name",
+	"名称未設定",
+	"名前なし",
+}
+
+func isUnnamedResource(name string) bool {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return name == "" || slices.Contains(unnamedPlaceholders, name)
+}
+
+func collectResourceUnnamed(ch chan<- prometheus.Metric, desc *prometheus.Desc, id types.ID, zone, name string) {
+	if !isUnnamedResource(name) {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		float64(1.0),
+		id.String(), zone,
+	)
+}
+
+// newResourceRecentlyCreatedDesc returns the Desc of sakuracloud_resource_recently_created for each resource type.
+func (o Options) newResourceRecentlyCreatedDesc(resourceType string) *prometheus.Desc {
+	return o.newDesc(
+		"sakuracloud_resource_recently_created",
+		"A metric with a constant '1' value for resources created within the recently-created window",
+		[]string{"id", "zone"}, prometheus.Labels{"resource_type": resourceType},
+	)
+}
+
+func (o Options) isRecentlyCreatedResource(createdAt, now time.Time) bool {
+	if o.RecentlyCreatedWindow <= 0 || createdAt.IsZero() {
+		return false
+	}
+	return now.Sub(createdAt) <= o.RecentlyCreatedWindow
+}
+
+func (o Options) collectResourceRecentlyCreated(ch chan<- prometheus.Metric, desc *prometheus.Desc, id types.ID, zone string, createdAt time.Time) {
+	if !o.isRecentlyCreatedResource(createdAt, time.Now()) {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		desc,
+		prometheus.GaugeValue,
+		float64(1.0),
+		id.String(), zone,
+	)
+}
+
+// newResourceCountByTagDesc returns the Desc of sakuracloud_resource_count_by_tag for each resource type.
+func (o Options) newResourceCountByTagDesc(resourceType string) *prometheus.Desc {
+	return o.newDesc(
+		"sakuracloud_resource_count_by_tag",
+		"The number of resources grouped by the value of the tag keys specified by --count-by-tags",
+		[]string{"tag_key", "tag_value"}, prometheus.Labels{"resource_type": resourceType},
+	)
+}
+
+type resourceTag struct {
+	key   string
+	value string
+}
+
+// countResourcesByTag counts the resources having each "key=value" tag of CountByTags.
+// Each resource is counted at most once per key/value pair.
+func (o Options) countResourcesByTag(tags []types.Tags) map[resourceTag]int {
+	counts := make(map[resourceTag]int)
+	for _, resourceTags := range tags {
+		seen := make(map[resourceTag]bool)
+		for _, tag := range resourceTags {
+			key, value, ok := strings.Cut(tag, "=")
+			if !ok || !slices.Contains(o.CountByTags, key) {
+				continue
+			}
+			t := resourceTag{key: key, value: value}
+			if !seen[t] {
+				seen[t] = true
+				counts[t]++
+			}
+		}
+	}
+	return counts
+}
+
+func (o Options) collectResourceCountByTag(ch chan<- prometheus.Metric, desc *prometheus.Desc, tags []types.Tags) {
+	if len(o.CountByTags) == 0 {
+		return
+	}
+	for t, count := range o.countResourcesByTag(tags) {
+		ch <- prometheus.MustNewConstMetric(
+			desc,
+			prometheus.GaugeValue,
+			float64(count),
+			t.key, o.truncateLabelValue(t.value),
+		)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/packages-go/newsfeed"
 	"github.com/sacloud/sakuracloud_exporter/platform"
@@ -32,128 +33,219 @@ type ServerCollector struct {
 	ctx       context.Context
 	logger    *slog.Logger
 	errors    *prometheus.CounterVec
+	opts      Options
 	client    platform.ServerClient
 	maintOnly bool
 
+	transitionStuckThreshold time.Duration
+	statuses                 *serverStatusTracker
+	cpuSamples               *monitorSampleIntervalTracker
+
 	Up         *prometheus.Desc
 	ServerInfo *prometheus.Desc
+	PlanInfo   *prometheus.Desc
 	CPUs       *prometheus.Desc
 	CPUTime    *prometheus.Desc
 	Memories   *prometheus.Desc
 
-	DiskInfo  *prometheus.Desc
-	DiskRead  *prometheus.Desc
-	DiskWrite *prometheus.Desc
+	CDROMInserted *prometheus.Desc
+
+	TransitionStuck *prometheus.Desc
+
+	DiskInfo      *prometheus.Desc
+	DiskFeature   *prometheus.Desc
+	DiskSizeRatio *prometheus.Desc
+	DiskRead      *prometheus.Desc
+	DiskWrite     *prometheus.Desc
 
 	NICInfo      *prometheus.Desc
 	NICBandwidth *prometheus.Desc
 	NICReceive   *prometheus.Desc
 	NICSend      *prometheus.Desc
+	NICUpstream  *prometheus.Desc
+
+	MonitorSampleTimestamp *prometheus.Desc
+	SampleInterval         *prometheus.Desc
 
 	MaintenanceScheduled *prometheus.Desc
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	StorageDiskCount         *prometheus.Desc
+	StorageAttachedDiskCount *prometheus.Desc
+	MixedStorageGeneration   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewServerCollector returns a new ServerCollector.
-func NewServerCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.ServerClient, maintenanceOnly bool) *ServerCollector {
+//
+// If transitionStuckThreshold is 0, sakuracloud_server_transition_stuck is not collected.
+func NewServerCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.ServerClient, maintenanceOnly bool, transitionStuckThreshold time.Duration) *ServerCollector {
 	errors.WithLabelValues("server").Add(0)
 
 	serverLabels := []string{"id", "name", "zone"}
-	serverInfoLabels := append(serverLabels, "cpus", "disks", "nics", "memories", "host", "tags", "description", "private_host_id")
+	serverInfoLabels := append(serverLabels, "cpus", "disks", "nics", "memories", "host", "tags", "description", "private_host_id", "icon_id")
 	diskLabels := append(serverLabels, "disk_id", "disk_name", "index")
-	diskInfoLabels := append(diskLabels, "plan", "interface", "size", "tags", "description", "storage_id", "storage_generation", "storage_class")
+	diskInfoLabels := append(diskLabels, "plan", "interface", "size", "tags", "description", "storage_id", "storage_generation", "storage_class", "icon_id")
 	nicLabels := append(serverLabels, "interface_id", "index")
 	nicInfoLabels := append(nicLabels, "upstream_type", "upstream_id", "upstream_name")
+	storageLabels := []string{"storage_id", "storage_class", "storage_generation"}
 	maintenanceInfoLabel := append(serverLabels, "info_url", "info_title", "description", "start_date", "end_date")
 
 	return &ServerCollector{
 		ctx:       ctx,
 		logger:    logger,
 		errors:    errors,
+		opts:      opts,
 		client:    client,
 		maintOnly: maintenanceOnly,
-		Up: prometheus.NewDesc(
+
+		transitionStuckThreshold: transitionStuckThreshold,
+		statuses:                 newServerStatusTracker(),
+		cpuSamples:               newMonitorSampleIntervalTracker(),
+
+		Up: opts.newDesc(
 			"sakuracloud_server_up",
 			"If 1 the server is up and running, 0 otherwise",
 			serverLabels, nil,
 		),
-		ServerInfo: prometheus.NewDesc(
+		ServerInfo: opts.newDesc(
 			"sakuracloud_server_info",
 			"A metric with a constant '1' value labeled by server information",
-			serverInfoLabels, nil,
+			opts.withRegionLabel(serverInfoLabels), nil,
+		),
+		PlanInfo: opts.newDesc(
+			"sakuracloud_server_plan_info",
+			"A metric with a constant '1' value labeled by server plan information",
+			append(serverLabels, "cpu", "memory", "commitment"), nil,
 		),
-		CPUs: prometheus.NewDesc(
+		TransitionStuck: opts.newDesc(
+			"sakuracloud_server_transition_stuck",
+			"If 1 the server has been in a transitional state(e.g. cleaning) beyond the threshold, 0 otherwise",
+			serverLabels, nil,
+		),
+		CPUs: opts.newDesc(
 			"sakuracloud_server_cpus",
 			"Number of server's vCPU cores",
 			serverLabels, nil,
 		),
-		CPUTime: prometheus.NewDesc(
+		CPUTime: opts.newDesc(
 			"sakuracloud_server_cpu_time",
 			"Server's CPU time(unit: ms)",
 			serverLabels, nil,
 		),
-		Memories: prometheus.NewDesc(
+		Memories: opts.newDesc(
 			"sakuracloud_server_memories",
 			"Size of server's memories(unit: GB)",
 			serverLabels, nil,
 		),
-		DiskInfo: prometheus.NewDesc(
+		CDROMInserted: opts.newDesc(
+			"sakuracloud_server_cdrom_inserted",
+			"A metric with a constant '1' value labeled by the ID of the ISO image inserted into the server",
+			append(serverLabels, "cdrom_id"), nil,
+		),
+		DiskInfo: opts.newDesc(
 			"sakuracloud_server_disk_info",
 			"A metric with a constant '1' value labeled by disk information",
 			diskInfoLabels, nil,
 		),
-		DiskRead: prometheus.NewDesc(
+		DiskFeature: opts.newDesc(
+			"sakuracloud_disk_feature",
+			"If 1 the disk has the feature enabled, 0 otherwise",
+			append(diskLabels, "feature"), nil,
+		),
+		DiskSizeRatio: opts.newDesc(
+			"sakuracloud_disk_size_ratio",
+			"Ratio of the disk size to the maximum size of the disk plan",
+			diskLabels, nil,
+		),
+		DiskRead: opts.newDesc(
 			"sakuracloud_server_disk_read",
 			"Disk's read bytes(unit: KBps)",
 			diskLabels, nil,
 		),
-		DiskWrite: prometheus.NewDesc(
+		DiskWrite: opts.newDesc(
 			"sakuracloud_server_disk_write",
 			"Disk's write bytes(unit: KBps)",
 			diskLabels, nil,
 		),
-		NICInfo: prometheus.NewDesc(
+		NICInfo: opts.newDesc(
 			"sakuracloud_server_nic_info",
 			"A metric with a constant '1' value labeled by nic information",
 			nicInfoLabels, nil,
 		),
-		NICBandwidth: prometheus.NewDesc(
+		NICBandwidth: opts.newDesc(
 			"sakuracloud_server_nic_bandwidth",
 			"NIC's Bandwidth(unit: Mbps)",
 			nicLabels, nil,
 		),
-		NICReceive: prometheus.NewDesc(
+		NICReceive: opts.newNetworkTrafficDesc(
 			"sakuracloud_server_nic_receive",
-			"NIC's receive bytes(unit: Kbps)",
+			"NIC's receive traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		NICSend: prometheus.NewDesc(
+		NICSend: opts.newNetworkTrafficDesc(
 			"sakuracloud_server_nic_send",
-			"NIC's send bytes(unit: Kbps)",
+			"NIC's send traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		NICUpstream: opts.newDesc(
+			"sakuracloud_server_nic_upstream",
+			"The number of server's NICs per upstream type",
+			append(serverLabels, "upstream_type"), nil,
+		),
+		MonitorSampleTimestamp: opts.newDesc(
+			"sakuracloud_server_monitor_sample_timestamp_seconds",
+			"Time of the latest CPU-TIME sample returned by the monitor API in seconds since epoch (1970)",
+			serverLabels, nil,
+		),
+		SampleInterval: opts.newDesc(
+			"sakuracloud_server_sample_interval_seconds",
+			"Interval between the consecutive CPU-TIME samples returned by the monitor API in seconds",
+			serverLabels, nil,
+		),
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_server_maintenance_scheduled",
 			"If 1 the server has scheduled maintenance info, 0 otherwise",
 			serverLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_server_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			maintenanceInfoLabel, nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_server_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			serverLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_server_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			serverLabels, nil,
 		),
+		StorageDiskCount: opts.newDesc(
+			"sakuracloud_storage_disk_count",
+			"The number of disks on the storage",
+			storageLabels, nil,
+		),
+		StorageAttachedDiskCount: opts.newDesc(
+			"sakuracloud_storage_attached_disk_count",
+			"The number of disks attached to the servers on the storage",
+			[]string{"storage_id", "storage_class"}, nil,
+		),
+		MixedStorageGeneration: opts.newDesc(
+			"sakuracloud_server_mixed_storage_generation",
+			"If 1 the disks connected to the server are placed on storages of different generations, 0 otherwise",
+			serverLabels, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("server"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("server"),
+		CountByTag:      opts.newResourceCountByTagDesc("server"),
 	}
 }
 
@@ -162,11 +254,16 @@ func NewServerCollector(ctx context.Context, logger *slog.Logger, errors *promet
 func (c *ServerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
 	ch <- c.ServerInfo
+	ch <- c.PlanInfo
+	ch <- c.TransitionStuck
 	ch <- c.CPUs
 	ch <- c.CPUTime
 	ch <- c.Memories
+	ch <- c.CDROMInserted
 
 	ch <- c.DiskInfo
+	ch <- c.DiskFeature
+	ch <- c.DiskSizeRatio
 	ch <- c.DiskRead
 	ch <- c.DiskWrite
 
@@ -174,16 +271,30 @@ func (c *ServerCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.NICBandwidth
 	ch <- c.NICReceive
 	ch <- c.NICSend
+	ch <- c.NICUpstream
 
+	ch <- c.MonitorSampleTimestamp
+	ch <- c.SampleInterval
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.StorageDiskCount
+	ch <- c.StorageAttachedDiskCount
+	ch <- c.MixedStorageGeneration
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
-	servers, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *ServerCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	servers, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
@@ -192,9 +303,31 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	if err == nil {
+		c.statuses.retain(servers)
+
+		ids := make([]types.ID, 0, len(servers))
+		for _, server := range servers {
+			ids = append(ids, server.ID)
+		}
+		c.cpuSamples.retain(ids)
+	}
+
+	if !c.maintOnly {
+		tags := make([]types.Tags, 0, len(servers))
+		for _, server := range servers {
+			tags = append(tags, server.Tags)
+		}
+		c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+	}
+
+	c.opts.warnGoroutines(c.logger, "server", estimateServerGoroutines(servers))
+
 	var wg sync.WaitGroup
 	wg.Add(len(servers))
 
+	storages := newStorageDiskCounter()
+
 	for i := range servers {
 		func(server *platform.Server) {
 			defer wg.Done()
@@ -202,6 +335,9 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 			serverLabels := c.serverLabels(server)
 
 			if !c.maintOnly {
+				collectResourceUnnamed(ch, c.Unnamed, server.ID, server.ZoneName, server.Name)
+				c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, server.ID, server.ZoneName, server.CreatedAt)
+
 				var up float64
 				if server.InstanceStatus.IsUp() {
 					up = 1.0
@@ -216,8 +352,15 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 					c.ServerInfo,
 					prometheus.GaugeValue,
 					float64(1.0),
-					c.serverInfoLabels(server)...,
+					c.opts.withRegionLabelValue(c.serverInfoLabels(server), server.ZoneName)...,
 				)
+				ch <- prometheus.MustNewConstMetric(
+					c.PlanInfo,
+					prometheus.GaugeValue,
+					float64(1.0),
+					c.planInfoLabels(server)...,
+				)
+				c.collectTransitionStuck(ch, server)
 				ch <- prometheus.MustNewConstMetric(
 					c.CPUs,
 					prometheus.GaugeValue,
@@ -230,11 +373,19 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 					float64(server.GetMemoryGB()),
 					serverLabels...,
 				)
+				if !server.CDROMID.IsEmpty() {
+					ch <- prometheus.MustNewConstMetric(
+						c.CDROMInserted,
+						prometheus.GaugeValue,
+						float64(1.0),
+						append(serverLabels, server.CDROMID.String())...,
+					)
+				}
 
 				wg.Add(len(server.Disks))
 				for i := range server.Disks {
 					go func(i int) {
-						c.collectDiskInfo(ch, server, i)
+						storages.add(server.ID, c.collectDiskInfo(ctx, ch, server, i))
 						wg.Done()
 					}(i)
 				}
@@ -255,6 +406,7 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 						c.nicLabels(server, i)...,
 					)
 				}
+				c.collectNICUpstream(ch, server)
 
 				if server.Availability.IsAvailable() && server.InstanceStatus.IsUp() {
 					// collect metrics per resources under server
@@ -262,7 +414,7 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 					// CPU-TIME
 					wg.Add(1)
 					go func() {
-						c.collectCPUTime(ch, server, now)
+						c.collectCPUTime(ctx, ch, server, now)
 						wg.Done()
 					}()
 
@@ -270,7 +422,7 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 					wg.Add(len(server.Disks))
 					for i := range server.Disks {
 						go func(i int) {
-							c.collectDiskMetrics(ch, server, i, now)
+							c.collectDiskMetrics(ctx, ch, server, i, now)
 							wg.Done()
 						}(i)
 					}
@@ -279,7 +431,7 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 					wg.Add(len(server.Interfaces))
 					for i := range server.Interfaces {
 						go func(i int) {
-							c.collectNICMetrics(ch, server, i, now)
+							c.collectNICMetrics(ctx, ch, server, i, now)
 							wg.Done()
 						}(i)
 					}
@@ -306,12 +458,29 @@ func (c *ServerCollector) Collect(ch chan<- prometheus.Metric) {
 	}
 
 	wg.Wait()
+
+	c.collectStorageDiskCount(ch, storages)
+	if !c.maintOnly {
+		for _, server := range servers {
+			c.collectMixedStorageGeneration(ch, server, storages)
+		}
+	}
+}
+
+// estimateServerGoroutines returns the upper bound of the number of goroutines spawned per Collect:
+// disk info, disk and NIC metrics for each disk/NIC, cpu-time and maintenance info for each server
+func estimateServerGoroutines(servers []*platform.Server) int {
+	n := 0
+	for _, server := range servers {
+		n += len(server.Disks)*2 + len(server.Interfaces) + 2
+	}
+	return n
 }
 
 func (c *ServerCollector) serverLabels(server *platform.Server) []string {
 	return []string{
 		server.ID.String(),
-		server.Name,
+		c.opts.truncateLabelValue(server.Name),
 		server.ZoneName,
 	}
 }
@@ -331,9 +500,18 @@ func (c *ServerCollector) serverInfoLabels(server *platform.Server) []string {
 		fmt.Sprintf("%d", len(server.Interfaces)),
 		fmt.Sprintf("%d", server.GetMemoryGB()),
 		instanceHost,
-		flattenStringSlice(server.Tags),
-		server.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(server.Tags)),
+		c.opts.truncateLabelValue(server.Description),
 		server.PrivateHostID.String(),
+		server.IconID.String(),
+	)
+}
+
+func (c *ServerCollector) planInfoLabels(server *platform.Server) []string {
+	return append(c.serverLabels(server),
+		fmt.Sprintf("%d", server.GetCPU()),
+		fmt.Sprintf("%d", server.GetMemoryGB()),
+		string(server.ServerPlanCommitment),
 	)
 }
 
@@ -343,7 +521,7 @@ func (c *ServerCollector) maintenanceInfoLabels(server *platform.Server, info *n
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
@@ -354,6 +532,12 @@ var diskPlanLabels = map[types.ID]string{
 	types.DiskPlans.SSD: "ssd",
 }
 
+// diskPlanMaxSizeGB is the maximum size of a disk of each plan(unit: GB)
+var diskPlanMaxSizeGB = map[types.ID]int{
+	types.DiskPlans.HDD: 12 * 1024,
+	types.DiskPlans.SSD: 4 * 1024,
+}
+
 func (c *ServerCollector) diskLabels(server *platform.Server, index int) []string {
 	if len(server.Disks) <= index {
 		return nil
@@ -361,49 +545,68 @@ func (c *ServerCollector) diskLabels(server *platform.Server, index int) []strin
 	disk := server.Disks[index]
 	return []string{
 		server.ID.String(),
-		server.Name,
+		c.opts.truncateLabelValue(server.Name),
 		server.ZoneName,
 		disk.ID.String(),
-		disk.Name,
+		c.opts.truncateLabelValue(disk.Name),
 		fmt.Sprintf("%d", index),
 	}
 }
 
-func (c *ServerCollector) collectDiskInfo(ch chan<- prometheus.Metric, server *platform.Server, index int) {
+// collectDiskInfo collects disk info and returns the storage on which the disk is placed
+//
+// The plan, interface, size and storage are taken from the disk connected to the server,
+// and overridden by the result of ReadDisk if it succeeds, so that the metric is collected even if ReadDisk fails.
+func (c *ServerCollector) collectDiskInfo(ctx context.Context, ch chan<- prometheus.Metric, server *platform.Server, index int) *iaas.Storage {
 	if len(server.Disks) <= index {
-		return
+		return nil
 	}
 	labels := c.diskLabels(server, index)
 
-	disk, err := c.client.ReadDisk(c.ctx, server.ZoneName, server.Disks[index].ID)
+	connected := server.Disks[index]
+	planID, connection, sizeGB, storage := connected.DiskPlanID, connected.Connection, connected.GetSizeGB(), connected.Storage
+	encryption := connected.EncryptionAlgorithm
+	var tags types.Tags
+	var description, iconID string
+
+	disk, err := c.client.ReadDisk(ctx, server.ZoneName, connected.ID)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
-			fmt.Sprintf("can't get server connected disk info: ID=%d, DiskID=%d", server.ID, server.Disks[index].ID),
+			fmt.Sprintf("can't get server connected disk info: ID=%d, DiskID=%d", server.ID, connected.ID),
 			slog.Any("err", err),
 		)
-		return
 	}
-	if disk == nil {
-		return
+	if disk != nil {
+		planID, connection, sizeGB = disk.DiskPlanID, disk.Connection, disk.GetSizeGB()
+		if disk.Storage != nil {
+			storage = disk.Storage
+		}
+		if disk.EncryptionAlgorithm != "" {
+			encryption = disk.EncryptionAlgorithm
+		}
+		tags = disk.Tags
+		description = disk.Description
+		iconID = disk.IconID.String()
 	}
 
 	var storageID, storageGeneration, storageClass string
-	if disk.Storage != nil {
-		storageID = disk.Storage.ID.String()
-		storageGeneration = fmt.Sprintf("%d", disk.Storage.Generation)
-		storageClass = disk.Storage.Class
+	if storage != nil {
+		storageID = storage.ID.String()
+		storageGeneration = fmt.Sprintf("%d", storage.Generation)
+		storageClass = storage.Class
 	}
 
 	labels = append(labels,
-		diskPlanLabels[disk.DiskPlanID],
-		string(disk.Connection),
-		fmt.Sprintf("%d", disk.GetSizeGB()),
-		flattenStringSlice(disk.Tags),
-		disk.Description,
+		diskPlanLabels[planID],
+		string(connection),
+		fmt.Sprintf("%d", sizeGB),
+		c.opts.truncateLabelValue(flattenStringSlice(tags)),
+		c.opts.truncateLabelValue(description),
 		storageID,
 		storageGeneration,
 		storageClass,
+		iconID,
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -412,6 +615,192 @@ func (c *ServerCollector) collectDiskInfo(ch chan<- prometheus.Metric, server *p
 		float64(1.0),
 		labels...,
 	)
+	c.collectDiskFeatures(ch, server, index, encryption)
+	c.collectDiskSizeRatio(ch, server, index, planID, sizeGB)
+
+	return storage
+}
+
+// collectDiskFeatures collects the features of the disk.
+//
+// The encryption feature is not collected if the API doesn't report the encryption algorithm of the disk.
+func (c *ServerCollector) collectDiskFeatures(ch chan<- prometheus.Metric, server *platform.Server, index int, encryption types.EDiskEncryptionAlgorithm) {
+	if encryption == "" {
+		return
+	}
+
+	var encrypted float64
+	if encryption != types.DiskEncryptionAlgorithms.None {
+		encrypted = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.DiskFeature,
+		prometheus.GaugeValue,
+		encrypted,
+		append(c.diskLabels(server, index), "encryption")...,
+	)
+}
+
+// collectDiskSizeRatio collects the ratio of the disk size to the maximum size of the plan.
+// Nothing is collected if the maximum size of the plan is unknown.
+func (c *ServerCollector) collectDiskSizeRatio(ch chan<- prometheus.Metric, server *platform.Server, index int, planID types.ID, sizeGB int) {
+	maxSizeGB, ok := diskPlanMaxSizeGB[planID]
+	if !ok || maxSizeGB <= 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.DiskSizeRatio,
+		prometheus.GaugeValue,
+		float64(sizeGB)/float64(maxSizeGB),
+		c.diskLabels(server, index)...,
+	)
+}
+
+// isTransitionalServerStatus returns true if the instance status is neither up nor down
+func isTransitionalServerStatus(status types.EServerInstanceStatus) bool {
+	return status != types.ServerInstanceStatuses.Unknown && !status.IsUp() && !status.IsDown()
+}
+
+func (c *ServerCollector) collectTransitionStuck(ch chan<- prometheus.Metric, server *platform.Server) {
+	if c.transitionStuckThreshold <= 0 {
+		return
+	}
+
+	now := time.Now()
+	since := c.statuses.observe(server, now)
+
+	var stuck float64
+	if isTransitionalServerStatus(server.InstanceStatus) && now.Sub(since) >= c.transitionStuckThreshold {
+		stuck = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.TransitionStuck,
+		prometheus.GaugeValue,
+		stuck,
+		c.serverLabels(server)...,
+	)
+}
+
+// serverStatusTracker tracks since when each server has been in its current instance status across Collects
+type serverStatusTracker struct {
+	mu       sync.Mutex
+	statuses map[types.ID]*observedServerStatus
+}
+
+type observedServerStatus struct {
+	status types.EServerInstanceStatus
+	since  time.Time
+}
+
+func newServerStatusTracker() *serverStatusTracker {
+	return &serverStatusTracker{
+		statuses: make(map[types.ID]*observedServerStatus),
+	}
+}
+
+// observe records the current instance status of the server and returns since when the server has been in it.
+// InstanceStatusChangedAt is preferred, and the time the status was first observed is used if it is not set.
+func (t *serverStatusTracker) observe(server *platform.Server, now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	observed, ok := t.statuses[server.ID]
+	if !ok || observed.status != server.InstanceStatus {
+		observed = &observedServerStatus{status: server.InstanceStatus, since: now}
+		t.statuses[server.ID] = observed
+	}
+	if !server.InstanceStatusChangedAt.IsZero() {
+		observed.since = server.InstanceStatusChangedAt
+	}
+	return observed.since
+}
+
+// retain removes the servers which no longer exist
+func (t *serverStatusTracker) retain(servers []*platform.Server) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exists := make(map[types.ID]bool)
+	for _, server := range servers {
+		exists[server.ID] = true
+	}
+	for id := range t.statuses {
+		if !exists[id] {
+			delete(t.statuses, id)
+		}
+	}
+}
+
+// storageDiskCounter counts disks per storage across all servers in a single Collect,
+// and records the storage generations of the disks per server
+type storageDiskCounter struct {
+	mu          sync.Mutex
+	storages    map[types.ID]*iaas.Storage
+	counts      map[types.ID]int
+	generations map[types.ID]map[int]bool
+}
+
+func newStorageDiskCounter() *storageDiskCounter {
+	return &storageDiskCounter{
+		storages:    make(map[types.ID]*iaas.Storage),
+		counts:      make(map[types.ID]int),
+		generations: make(map[types.ID]map[int]bool),
+	}
+}
+
+func (s *storageDiskCounter) add(serverID types.ID, storage *iaas.Storage) {
+	if storage == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.storages[storage.ID] = storage
+	s.counts[storage.ID]++
+
+	if s.generations[serverID] == nil {
+		s.generations[serverID] = make(map[int]bool)
+	}
+	s.generations[serverID][storage.Generation] = true
+}
+
+func (c *ServerCollector) collectStorageDiskCount(ch chan<- prometheus.Metric, storages *storageDiskCounter) {
+	storages.mu.Lock()
+	defer storages.mu.Unlock()
+
+	for id, storage := range storages.storages {
+		ch <- prometheus.MustNewConstMetric(
+			c.StorageDiskCount,
+			prometheus.GaugeValue,
+			float64(storages.counts[id]),
+			storage.ID.String(),
+			storage.Class,
+			fmt.Sprintf("%d", storage.Generation),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.StorageAttachedDiskCount,
+			prometheus.GaugeValue,
+			float64(storages.counts[id]),
+			storage.ID.String(),
+			storage.Class,
+		)
+	}
+}
+
+func (c *ServerCollector) collectMixedStorageGeneration(ch chan<- prometheus.Metric, server *platform.Server, storages *storageDiskCounter) {
+	storages.mu.Lock()
+	defer storages.mu.Unlock()
+
+	var mixed float64
+	if len(storages.generations[server.ID]) > 1 {
+		mixed = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.MixedStorageGeneration,
+		prometheus.GaugeValue,
+		mixed,
+		c.serverLabels(server)...,
+	)
 }
 
 func (c *ServerCollector) nicLabels(server *platform.Server, index int) []string {
@@ -421,7 +810,7 @@ func (c *ServerCollector) nicLabels(server *platform.Server, index int) []string
 
 	return []string{
 		server.ID.String(),
-		server.Name,
+		c.opts.truncateLabelValue(server.Name),
 		server.ZoneName,
 		server.Interfaces[index].ID.String(),
 		fmt.Sprintf("%d", index),
@@ -448,8 +837,23 @@ func (c *ServerCollector) nicInfoLabels(server *platform.Server, index int) []st
 	)
 }
 
-func (c *ServerCollector) collectCPUTime(ch chan<- prometheus.Metric, server *platform.Server, now time.Time) {
-	values, err := c.client.MonitorCPU(c.ctx, server.ZoneName, server.ID, now)
+func (c *ServerCollector) collectNICUpstream(ch chan<- prometheus.Metric, server *platform.Server) {
+	counts := make(map[string]int)
+	for _, nic := range server.Interfaces {
+		counts[nic.UpstreamType.String()]++
+	}
+	for upstreamType, count := range counts {
+		ch <- prometheus.MustNewConstMetric(
+			c.NICUpstream,
+			prometheus.GaugeValue,
+			float64(count),
+			append(c.serverLabels(server), upstreamType)...,
+		)
+	}
+}
+
+func (c *ServerCollector) collectCPUTime(ctx context.Context, ch chan<- prometheus.Metric, server *platform.Server, now time.Time) {
+	values, err := c.client.MonitorCPU(ctx, server.ZoneName, server.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
@@ -459,7 +863,21 @@ func (c *ServerCollector) collectCPUTime(ch chan<- prometheus.Metric, server *pl
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorCPUTimeValue{Time: now}
+	} else {
+		c.opts.collectMonitorSampleTimestamp(ch, c.MonitorSampleTimestamp, values.Time, c.serverLabels(server)...)
+
+		if interval, ok := c.cpuSamples.observe(server.ID, values.Time); ok {
+			ch <- prometheus.MustNewConstMetric(
+				c.SampleInterval,
+				prometheus.GaugeValue,
+				interval.Seconds(),
+				c.serverLabels(server)...,
+			)
+		}
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -472,13 +890,13 @@ func (c *ServerCollector) collectCPUTime(ch chan<- prometheus.Metric, server *pl
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *ServerCollector) collectDiskMetrics(ch chan<- prometheus.Metric, server *platform.Server, index int, now time.Time) {
+func (c *ServerCollector) collectDiskMetrics(ctx context.Context, ch chan<- prometheus.Metric, server *platform.Server, index int, now time.Time) {
 	if len(server.Disks) <= index {
 		return
 	}
 	disk := server.Disks[index]
 
-	values, err := c.client.MonitorDisk(c.ctx, server.ZoneName, disk.ID, now)
+	values, err := c.client.MonitorDisk(ctx, server.ZoneName, disk.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
@@ -488,7 +906,10 @@ func (c *ServerCollector) collectDiskMetrics(ch chan<- prometheus.Metric, server
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorDiskValue{Time: now}
 	}
 
 	read := values.Read
@@ -516,13 +937,13 @@ func (c *ServerCollector) collectDiskMetrics(ch chan<- prometheus.Metric, server
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *ServerCollector) collectNICMetrics(ch chan<- prometheus.Metric, server *platform.Server, index int, now time.Time) {
+func (c *ServerCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, server *platform.Server, index int, now time.Time) {
 	if len(server.Interfaces) <= index {
 		return
 	}
 	nic := server.Interfaces[index]
 
-	values, err := c.client.MonitorNIC(c.ctx, server.ZoneName, nic.ID, now)
+	values, err := c.client.MonitorNIC(ctx, server.ZoneName, nic.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
@@ -532,12 +953,15 @@ func (c *ServerCollector) collectNICMetrics(ch chan<- prometheus.Metric, server
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
 	}
 
 	receive := values.Receive
 	if receive > 0 {
-		receive = receive * 8 / 1000
+		receive = c.opts.convertNetworkTraffic(receive)
 	}
 	m := prometheus.MustNewConstMetric(
 		c.NICReceive,
@@ -549,7 +973,7 @@ func (c *ServerCollector) collectNICMetrics(ch chan<- prometheus.Metric, server
 
 	send := values.Send
 	if send > 0 {
-		send = send * 8 / 1000
+		send = c.opts.convertNetworkTraffic(send)
 	}
 	m = prometheus.MustNewConstMetric(
 		c.NICSend,
@@ -0,0 +1,130 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// ServerHygieneCollector collects the number of governance issues of each server.
+//
+// The issues are:
+//   - the server has no tags
+//   - none of the disks of the server is targeted by an AutoBackup
+//   - the server has an empty name
+type ServerHygieneCollector struct {
+	ctx              context.Context
+	logger           *slog.Logger
+	errors           *prometheus.CounterVec
+	opts             Options
+	serverClient     platform.ServerClient
+	autoBackupClient platform.AutoBackupClient
+
+	Issues *prometheus.Desc
+}
+
+// NewServerHygieneCollector returns a new ServerHygieneCollector.
+func NewServerHygieneCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, serverClient platform.ServerClient, autoBackupClient platform.AutoBackupClient) *ServerHygieneCollector {
+	errors.WithLabelValues("server_hygiene").Add(0)
+
+	return &ServerHygieneCollector{
+		ctx:              ctx,
+		logger:           logger,
+		errors:           errors,
+		opts:             opts,
+		serverClient:     serverClient,
+		autoBackupClient: autoBackupClient,
+		Issues: opts.newDesc(
+			"sakuracloud_server_hygiene_issues",
+			"The number of governance issues of the server(untagged, no AutoBackup, unnamed)",
+			[]string{"id", "zone"}, nil,
+		),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *ServerHygieneCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Issues
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *ServerHygieneCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *ServerHygieneCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	autoBackups, err := c.autoBackupClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("server_hygiene").Add(1)
+		c.logger.Warn(
+			"can't list autoBackups",
+			slog.Any("err", err),
+		)
+		return
+	}
+	servers, err := c.serverClient.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("server_hygiene").Add(1)
+		c.logger.Warn(
+			"can't list servers",
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	backedUp := make(map[types.ID]struct{})
+	for _, autoBackup := range autoBackups {
+		backedUp[autoBackup.DiskID] = struct{}{}
+	}
+
+	for _, server := range servers {
+		ch <- prometheus.MustNewConstMetric(
+			c.Issues,
+			prometheus.GaugeValue,
+			float64(countServerHygieneIssues(server, backedUp)),
+			server.ID.String(), server.ZoneName,
+		)
+	}
+}
+
+func countServerHygieneIssues(server *platform.Server, backedUp map[types.ID]struct{}) int {
+	issues := 0
+	if len(server.Tags) == 0 {
+		issues++
+	}
+
+	hasAutoBackup := false
+	for _, disk := range server.Disks {
+		if _, ok := backedUp[disk.ID]; ok {
+			hasAutoBackup = true
+			break
+		}
+	}
+	if !hasAutoBackup {
+		issues++
+	}
+
+	if isUnnamedResource(server.Name) {
+		issues++
+	}
+	return issues
+}
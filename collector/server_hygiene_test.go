@@ -0,0 +1,131 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerHygieneCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerHygieneCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{}, &dummyAutoBackupClient{})
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.Issues,
+	}))
+}
+
+func TestServerHygieneCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerHygieneCollector(context.Background(), testLogger, testErrors, Options{}, nil, nil)
+
+	cases := []struct {
+		name           string
+		servers        *dummyServerClient
+		autoBackups    *dummyAutoBackupClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name:           "autoBackup client returns error",
+			servers:        &dummyServerClient{},
+			autoBackups:    &dummyAutoBackupClient{findErr: errors.New("dummy")},
+			wantLogs:       []string{`level=WARN msg="can't list autoBackups" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:           "server client returns error",
+			servers:        &dummyServerClient{findErr: errors.New("dummy")},
+			autoBackups:    &dummyAutoBackupClient{},
+			wantLogs:       []string{`level=WARN msg="can't list servers" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name: "a healthy server and a server failing multiple checks",
+			servers: &dummyServerClient{
+				find: []*platform.Server{
+					{
+						ZoneName: "is1a",
+						Server: &iaas.Server{
+							ID:   101,
+							Name: "healthy",
+							Tags: types.Tags{"env=prod"},
+							Disks: []*iaas.ServerConnectedDisk{
+								{ID: 201},
+							},
+						},
+					},
+					{
+						ZoneName: "is1a",
+						Server: &iaas.Server{
+							ID:   102,
+							Name: "",
+							Disks: []*iaas.ServerConnectedDisk{
+								{ID: 202},
+							},
+						},
+					},
+				},
+			},
+			autoBackups: &dummyAutoBackupClient{
+				autoBackup: []*iaas.AutoBackup{
+					{ID: 301, DiskID: 201},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Issues,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.Issues,
+					metric: createGaugeMetric(3, map[string]string{
+						"id":   "102",
+						"zone": "is1a",
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.serverClient = tc.servers
+		c.autoBackupClient = tc.autoBackups
+
+		collected, err := collectMetrics(c, "server_hygiene")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
@@ -67,46 +67,61 @@ func (d *dummyServerClient) MaintenanceInfo(infoURL string) (*newsfeed.FeedItem,
 
 func TestServerCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewServerCollector(context.Background(), testLogger, testErrors, &dummyServerClient{}, false)
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{}, false, 0)
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
 		c.ServerInfo,
+		c.PlanInfo,
+		c.TransitionStuck,
 		c.CPUs,
 		c.CPUTime,
 		c.Memories,
+		c.CDROMInserted,
 		c.DiskInfo,
+		c.DiskFeature,
+		c.DiskSizeRatio,
 		c.DiskRead,
 		c.DiskWrite,
 		c.NICInfo,
 		c.NICBandwidth,
 		c.NICReceive,
 		c.NICSend,
+		c.NICUpstream,
+		c.MonitorSampleTimestamp,
+		c.SampleInterval,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.StorageDiskCount,
+		c.StorageAttachedDiskCount,
+		c.MixedStorageGeneration,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestServerCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewServerCollector(context.Background(), testLogger, testErrors, nil, false)
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{RecentlyCreatedWindow: time.Hour}, nil, false, 0)
 	monitorTime := time.Unix(1, 0)
 
 	server := &platform.Server{
 		ZoneName: "is1a",
 		Server: &iaas.Server{
-			ID:               101,
-			Name:             "server",
-			Description:      "desc",
-			Tags:             types.Tags{"tag1", "tag2"},
-			CPU:              2,
-			MemoryMB:         4 * 1024,
-			InstanceStatus:   types.ServerInstanceStatuses.Up,
-			Availability:     types.Availabilities.Available,
-			InstanceHostName: "sacXXX",
+			ID:                   101,
+			Name:                 "server",
+			Description:          "desc",
+			Tags:                 types.Tags{"tag1", "tag2"},
+			CPU:                  2,
+			MemoryMB:             4 * 1024,
+			ServerPlanCommitment: types.Commitments.Standard,
+			InstanceStatus:       types.ServerInstanceStatuses.Up,
+			Availability:         types.Availabilities.Available,
+			InstanceHostName:     "sacXXX",
 			Disks: []*iaas.ServerConnectedDisk{
 				{
 					ID:         201,
@@ -159,6 +174,20 @@ func TestServerCollector_Collect(t *testing.T) {
 			name: "a server with activity monitors",
 			in: &dummyServerClient{
 				find: []*platform.Server{server},
+				readDisk: &iaas.Disk{
+					ID:          201,
+					Name:        "disk",
+					Tags:        types.Tags{"disk1", "disk2"},
+					Description: "disk-desc",
+					DiskPlanID:  types.DiskPlans.SSD,
+					Connection:  types.DiskConnections.VirtIO,
+					SizeMB:      20 * 1024,
+					Storage: &iaas.Storage{
+						ID:         1001,
+						Class:      "iscsi1204",
+						Generation: 100,
+					},
+				},
 				monitorCPU: &iaas.MonitorCPUTimeValue{
 					Time:    monitorTime,
 					CPUTime: 100,
@@ -197,6 +226,18 @@ func TestServerCollector_Collect(t *testing.T) {
 						"tags":            ",tag1,tag2,",
 						"description":     "desc",
 						"private_host_id": "3001",
+						"icon_id":         "",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "server",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "standard",
 					}),
 				},
 				{
@@ -223,25 +264,60 @@ func TestServerCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
-				//{
-				//	desc: c.DiskInfo,
-				//	metric: createGaugeMetric(1, map[string]string{
-				//		"id":                 "101",
-				//		"name":               "server",
-				//		"zone":               "is1a",
-				//		"disk_id":            "201",
-				//		"disk_name":          "disk",
-				//		"index":              "0",
-				//		"plan":               "ssd",
-				//		"interface":          "virtio",
-				//		"size":               "20",
-				//		"tags":               ",disk1,disk2,",
-				//		"description":        "disk-desc",
-				//		"storage_id":         "1001",
-				//		"storage_class":      "iscsi1204",
-				//		"storage_generation": "100",
-				//	}),
-				// },
+				{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.DiskInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":                 "101",
+						"name":               "server",
+						"zone":               "is1a",
+						"disk_id":            "201",
+						"disk_name":          "disk",
+						"index":              "0",
+						"plan":               "ssd",
+						"interface":          "virtio",
+						"size":               "20",
+						"tags":               ",disk1,disk2,",
+						"description":        "disk-desc",
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"icon_id":            "",
+						"storage_generation": "100",
+					}),
+				},
+				{
+					desc: c.DiskSizeRatio,
+					metric: createGaugeMetric(20.0/4096, map[string]string{
+						"id":        "101",
+						"name":      "server",
+						"zone":      "is1a",
+						"disk_id":   "201",
+						"disk_name": "disk",
+						"index":     "0",
+					}),
+				},
+				{
+					desc: c.StorageDiskCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"storage_generation": "100",
+					}),
+				},
+				{
+					desc: c.StorageAttachedDiskCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"storage_id":    "1001",
+						"storage_class": "iscsi1204",
+					}),
+				},
 				{
 					desc: c.NICBandwidth,
 					metric: createGaugeMetric(0, map[string]string{ // 専有ホストの場合は0
@@ -265,6 +341,15 @@ func TestServerCollector_Collect(t *testing.T) {
 						"upstream_type": "switch",
 					}),
 				},
+				{
+					desc: c.NICUpstream,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":            "101",
+						"name":          "server",
+						"zone":          "is1a",
+						"upstream_type": "switch",
+					}),
+				},
 				{
 					desc: c.CPUTime,
 					metric: createGaugeWithTimestamp(float64(100)*1000, map[string]string{
@@ -320,7 +405,21 @@ func TestServerCollector_Collect(t *testing.T) {
 		{
 			name: "activity monitor APIs return error",
 			in: &dummyServerClient{
-				find:           []*platform.Server{server},
+				find: []*platform.Server{server},
+				readDisk: &iaas.Disk{
+					ID:          201,
+					Name:        "disk",
+					Tags:        types.Tags{"disk1", "disk2"},
+					Description: "disk-desc",
+					DiskPlanID:  types.DiskPlans.SSD,
+					Connection:  types.DiskConnections.VirtIO,
+					SizeMB:      20 * 1024,
+					Storage: &iaas.Storage{
+						ID:         1001,
+						Class:      "iscsi1204",
+						Generation: 100,
+					},
+				},
 				monitorCPUErr:  errors.New("dummy1"),
 				monitorDiskErr: errors.New("dummy2"),
 				monitorNICErr:  errors.New("dummy3"),
@@ -348,6 +447,18 @@ func TestServerCollector_Collect(t *testing.T) {
 						"tags":            ",tag1,tag2,",
 						"description":     "desc",
 						"private_host_id": "3001",
+						"icon_id":         "",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "server",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "standard",
 					}),
 				},
 				{
@@ -374,25 +485,60 @@ func TestServerCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
-				//{
-				//	desc: c.DiskInfo,
-				//	metric: createGaugeMetric(1, map[string]string{
-				//		"id":                 "101",
-				//		"name":               "server",
-				//		"zone":               "is1a",
-				//		"disk_id":            "201",
-				//		"disk_name":          "disk",
-				//		"index":              "0",
-				//		"plan":               "ssd",
-				//		"interface":          "virtio",
-				//		"size":               "20",
-				//		"tags":               ",disk1,disk2,",
-				//		"description":        "disk-desc",
-				//		"storage_id":         "1001",
-				//		"storage_class":      "iscsi1204",
-				//		"storage_generation": "100",
-				//	}),
-				// },
+				{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.DiskInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":                 "101",
+						"name":               "server",
+						"zone":               "is1a",
+						"disk_id":            "201",
+						"disk_name":          "disk",
+						"index":              "0",
+						"plan":               "ssd",
+						"interface":          "virtio",
+						"size":               "20",
+						"tags":               ",disk1,disk2,",
+						"description":        "disk-desc",
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"icon_id":            "",
+						"storage_generation": "100",
+					}),
+				},
+				{
+					desc: c.DiskSizeRatio,
+					metric: createGaugeMetric(20.0/4096, map[string]string{
+						"id":        "101",
+						"name":      "server",
+						"zone":      "is1a",
+						"disk_id":   "201",
+						"disk_name": "disk",
+						"index":     "0",
+					}),
+				},
+				{
+					desc: c.StorageDiskCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"storage_generation": "100",
+					}),
+				},
+				{
+					desc: c.StorageAttachedDiskCount,
+					metric: createGaugeMetric(1, map[string]string{
+						"storage_id":    "1001",
+						"storage_class": "iscsi1204",
+					}),
+				},
 				{
 					desc: c.NICBandwidth,
 					metric: createGaugeMetric(0, map[string]string{
@@ -416,6 +562,15 @@ func TestServerCollector_Collect(t *testing.T) {
 						"upstream_type": "switch",
 					}),
 				},
+				{
+					desc: c.NICUpstream,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":            "101",
+						"name":          "server",
+						"zone":          "is1a",
+						"upstream_type": "switch",
+					}),
+				},
 			},
 			wantErrCounter: 3,
 			wantLogs: []string{
@@ -425,36 +580,43 @@ func TestServerCollector_Collect(t *testing.T) {
 			},
 		},
 		{
-			name: "maintenance info",
+			name: "disks sharing a storage",
 			in: &dummyServerClient{
 				find: []*platform.Server{
 					{
 						ZoneName: "is1a",
 						Server: &iaas.Server{
-							ID:                  101,
-							Name:                "server",
-							CPU:                 2,
-							MemoryMB:            4 * 1024,
-							InstanceStatus:      types.ServerInstanceStatuses.Up,
-							Availability:        types.Availabilities.Available,
-							InstanceHostName:    "sacXXX",
-							InstanceHostInfoURL: "https://maintenance.example.com",
+							ID:             101,
+							Name:           "server",
+							CPU:            2,
+							MemoryMB:       4 * 1024,
+							InstanceStatus: types.ServerInstanceStatuses.Down,
+							Availability:   types.Availabilities.Available,
+							IconID:         4001,
+							Disks: []*iaas.ServerConnectedDisk{
+								{ID: 201, Name: "disk1"},
+								{ID: 202, Name: "disk2"},
+							},
 						},
 					},
 				},
-				maintenance: &newsfeed.FeedItem{
-					StrDate:       fmt.Sprintf("%d", time.Unix(1, 0).Unix()),
-					Description:   "maintenance-desc",
-					StrEventStart: fmt.Sprintf("%d", time.Unix(2, 0).Unix()),
-					StrEventEnd:   fmt.Sprintf("%d", time.Unix(3, 0).Unix()),
-					Title:         "maintenance-title",
-					URL:           "https://maintenance.example.com/?entry=1",
+				readDisk: &iaas.Disk{
+					ID:         201,
+					DiskPlanID: types.DiskPlans.SSD,
+					Connection: types.DiskConnections.VirtIO,
+					SizeMB:     20 * 1024,
+					IconID:     4002,
+					Storage: &iaas.Storage{
+						ID:         1001,
+						Class:      "iscsi1204",
+						Generation: 100,
+					},
 				},
 			},
 			wantMetrics: []*collectedMetric{
 				{
 					desc: c.Up,
-					metric: createGaugeMetric(1, map[string]string{
+					metric: createGaugeMetric(0, map[string]string{
 						"id":   "101",
 						"name": "server",
 						"zone": "is1a",
@@ -467,13 +629,25 @@ func TestServerCollector_Collect(t *testing.T) {
 						"name":            "server",
 						"zone":            "is1a",
 						"cpus":            "2",
-						"disks":           "0",
+						"disks":           "2",
 						"nics":            "0",
 						"memories":        "4",
-						"host":            "sacXXX",
+						"host":            "-",
 						"tags":            "",
 						"description":     "",
 						"private_host_id": "",
+						"icon_id":         "4001",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "server",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "",
 					}),
 				},
 				{
@@ -493,128 +667,504 @@ func TestServerCollector_Collect(t *testing.T) {
 					}),
 				},
 				{
-					desc: c.MaintenanceScheduled,
+					desc: c.DiskInfo,
 					metric: createGaugeMetric(1, map[string]string{
-						"id":   "101",
-						"name": "server",
-						"zone": "is1a",
+						"id":                 "101",
+						"name":               "server",
+						"zone":               "is1a",
+						"disk_id":            "201",
+						"disk_name":          "disk1",
+						"index":              "0",
+						"plan":               "ssd",
+						"interface":          "virtio",
+						"size":               "20",
+						"tags":               "",
+						"description":        "",
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"icon_id":            "4002",
+						"storage_generation": "100",
 					}),
 				},
 				{
-					desc: c.MaintenanceInfo,
+					desc: c.DiskSizeRatio,
+					metric: createGaugeMetric(20.0/4096, map[string]string{
+						"id":        "101",
+						"name":      "server",
+						"zone":      "is1a",
+						"disk_id":   "201",
+						"disk_name": "disk1",
+						"index":     "0",
+					}),
+				},
+				{
+					desc: c.DiskInfo,
 					metric: createGaugeMetric(1, map[string]string{
-						"id":          "101",
-						"name":        "server",
-						"zone":        "is1a",
-						"info_url":    "https://maintenance.example.com/?entry=1",
-						"info_title":  "maintenance-title",
-						"description": "maintenance-desc",
-						"start_date":  fmt.Sprintf("%d", time.Unix(2, 0).Unix()),
-						"end_date":    fmt.Sprintf("%d", time.Unix(3, 0).Unix()),
+						"id":                 "101",
+						"name":               "server",
+						"zone":               "is1a",
+						"disk_id":            "202",
+						"disk_name":          "disk2",
+						"index":              "1",
+						"plan":               "ssd",
+						"interface":          "virtio",
+						"size":               "20",
+						"tags":               "",
+						"description":        "",
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"icon_id":            "4002",
+						"storage_generation": "100",
 					}),
 				},
 				{
-					desc: c.MaintenanceStartTime,
-					metric: createGaugeMetric(float64(time.Unix(2, 0).Unix()), map[string]string{
+					desc: c.DiskSizeRatio,
+					metric: createGaugeMetric(20.0/4096, map[string]string{
+						"id":        "101",
+						"name":      "server",
+						"zone":      "is1a",
+						"disk_id":   "202",
+						"disk_name": "disk2",
+						"index":     "1",
+					}),
+				},
+				{
+					desc: c.MaintenanceScheduled,
+					metric: createGaugeMetric(0, map[string]string{
 						"id":   "101",
 						"name": "server",
 						"zone": "is1a",
 					}),
 				},
 				{
-					desc: c.MaintenanceEndTime,
-					metric: createGaugeMetric(float64(time.Unix(3, 0).Unix()), map[string]string{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
 						"id":   "101",
 						"name": "server",
 						"zone": "is1a",
 					}),
 				},
-			},
-		},
-	}
-
-	for _, tc := range cases {
-		initLoggerAndErrors()
-		c.logger = testLogger
-		c.errors = testErrors
-		c.client = tc.in
-
-		collected, err := collectMetrics(c, "server")
-		require.NoError(t, err)
-		require.Equal(t, tc.wantLogs, collected.logged)
-		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
-		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
-	}
-}
-
-func TestServerCollector_CollectMaintenanceOnly(t *testing.T) {
-	initLoggerAndErrors()
-	c := NewServerCollector(context.Background(), testLogger, testErrors, nil, true)
-	monitorTime := time.Unix(1, 0)
-
-	server := &platform.Server{
-		ZoneName: "is1a",
-		Server: &iaas.Server{
-			ID:               101,
-			Name:             "server",
-			Description:      "desc",
-			Tags:             types.Tags{"tag1", "tag2"},
-			CPU:              2,
-			MemoryMB:         4 * 1024,
-			InstanceStatus:   types.ServerInstanceStatuses.Up,
-			Availability:     types.Availabilities.Available,
-			InstanceHostName: "sacXXX",
-			Disks: []*iaas.ServerConnectedDisk{
 				{
-					ID:         201,
-					Name:       "disk",
-					DiskPlanID: types.DiskPlans.SSD,
-					Connection: types.DiskConnections.VirtIO,
-					SizeMB:     20 * 1024,
-					Storage: &iaas.Storage{
-						ID:         1001,
-						Class:      "iscsi1204",
-						Generation: 100,
-					},
+					desc: c.StorageDiskCount,
+					metric: createGaugeMetric(2, map[string]string{
+						"storage_id":         "1001",
+						"storage_class":      "iscsi1204",
+						"storage_generation": "100",
+					}),
 				},
-			},
-			Interfaces: []*iaas.InterfaceView{
 				{
-					ID:           301,
-					SwitchID:     401,
-					SwitchName:   "switch",
-					UpstreamType: types.UpstreamNetworkTypes.Switch,
+					desc: c.StorageAttachedDiskCount,
+					metric: createGaugeMetric(2, map[string]string{
+						"storage_id":    "1001",
+						"storage_class": "iscsi1204",
+					}),
 				},
 			},
 		},
-	}
-
-	cases := []struct {
-		name           string
-		in             platform.ServerClient
-		wantLogs       []string
-		wantErrCounter float64
-		wantMetrics    []*collectedMetric
-	}{
 		{
-			name: "collector returns error",
+			name: "unnamed server",
 			in: &dummyServerClient{
-				findErr: errors.New("dummy"),
+				find: []*platform.Server{
+					{
+						ZoneName: "is1a",
+						Server: &iaas.Server{
+							ID:             101,
+							Name:           "",
+							CPU:            2,
+							MemoryMB:       4 * 1024,
+							InstanceStatus: types.ServerInstanceStatuses.Down,
+							Availability:   types.Availabilities.Available,
+						},
+					},
+				},
 			},
-			wantLogs:       []string{`level=WARN msg="can't list servers" err=dummy`},
-			wantErrCounter: 1,
-			wantMetrics:    nil,
-		},
-		{
-			name:        "empty result",
-			in:          &dummyServerClient{},
-			wantMetrics: nil,
-		},
-		{
-			name: "a server maintenance scheduled",
-			in: &dummyServerClient{
-				find: []*platform.Server{server},
-				monitorCPU: &iaas.MonitorCPUTimeValue{
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Up,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.ServerInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "",
+						"zone":            "is1a",
+						"cpus":            "2",
+						"disks":           "0",
+						"nics":            "0",
+						"memories":        "4",
+						"host":            "-",
+						"tags":            "",
+						"description":     "",
+						"private_host_id": "",
+						"icon_id":         "",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "",
+					}),
+				},
+				{
+					desc: c.CPUs,
+					metric: createGaugeMetric(2, map[string]string{
+						"id":   "101",
+						"name": "",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.Memories,
+					metric: createGaugeMetric(4, map[string]string{
+						"id":   "101",
+						"name": "",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MaintenanceScheduled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.Unnamed,
+					metric: createGaugeMetric(1, map[string]string{
+						"resource_type": "server",
+						"id":            "101",
+						"zone":          "is1a",
+					}),
+				},
+			},
+		},
+		{
+			name: "recently created server",
+			in: &dummyServerClient{
+				find: []*platform.Server{
+					{
+						ZoneName: "is1a",
+						Server: &iaas.Server{
+							ID:             101,
+							Name:           "server",
+							CPU:            2,
+							MemoryMB:       4 * 1024,
+							InstanceStatus: types.ServerInstanceStatuses.Down,
+							Availability:   types.Availabilities.Available,
+							CreatedAt:      time.Now().Add(-time.Minute),
+						},
+					},
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Up,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.ServerInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "server",
+						"zone":            "is1a",
+						"cpus":            "2",
+						"disks":           "0",
+						"nics":            "0",
+						"memories":        "4",
+						"host":            "-",
+						"tags":            "",
+						"description":     "",
+						"private_host_id": "",
+						"icon_id":         "",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "server",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "",
+					}),
+				},
+				{
+					desc: c.CPUs,
+					metric: createGaugeMetric(2, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.Memories,
+					metric: createGaugeMetric(4, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MaintenanceScheduled,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.RecentlyCreated,
+					metric: createGaugeMetric(1, map[string]string{
+						"resource_type": "server",
+						"id":            "101",
+						"zone":          "is1a",
+					}),
+				},
+			},
+		},
+		{
+			name: "maintenance info",
+			in: &dummyServerClient{
+				find: []*platform.Server{
+					{
+						ZoneName: "is1a",
+						Server: &iaas.Server{
+							ID:                  101,
+							Name:                "server",
+							CPU:                 2,
+							MemoryMB:            4 * 1024,
+							InstanceStatus:      types.ServerInstanceStatuses.Up,
+							Availability:        types.Availabilities.Available,
+							InstanceHostName:    "sacXXX",
+							InstanceHostInfoURL: "https://maintenance.example.com",
+						},
+					},
+				},
+				maintenance: &newsfeed.FeedItem{
+					StrDate:       fmt.Sprintf("%d", time.Unix(1, 0).Unix()),
+					Description:   "maintenance-desc",
+					StrEventStart: fmt.Sprintf("%d", time.Unix(2, 0).Unix()),
+					StrEventEnd:   fmt.Sprintf("%d", time.Unix(3, 0).Unix()),
+					Title:         "maintenance-title",
+					URL:           "https://maintenance.example.com/?entry=1",
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.Up,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.ServerInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":              "101",
+						"name":            "server",
+						"zone":            "is1a",
+						"cpus":            "2",
+						"disks":           "0",
+						"nics":            "0",
+						"memories":        "4",
+						"host":            "sacXXX",
+						"tags":            "",
+						"description":     "",
+						"private_host_id": "",
+						"icon_id":         "",
+					}),
+				},
+				{
+					desc: c.PlanInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":         "101",
+						"name":       "server",
+						"zone":       "is1a",
+						"cpu":        "2",
+						"memory":     "4",
+						"commitment": "",
+					}),
+				},
+				{
+					desc: c.CPUs,
+					metric: createGaugeMetric(2, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.Memories,
+					metric: createGaugeMetric(4, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MaintenanceScheduled,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MixedStorageGeneration,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MaintenanceInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "server",
+						"zone":        "is1a",
+						"info_url":    "https://maintenance.example.com/?entry=1",
+						"info_title":  "maintenance-title",
+						"description": "maintenance-desc",
+						"start_date":  fmt.Sprintf("%d", time.Unix(2, 0).Unix()),
+						"end_date":    fmt.Sprintf("%d", time.Unix(3, 0).Unix()),
+					}),
+				},
+				{
+					desc: c.MaintenanceStartTime,
+					metric: createGaugeMetric(float64(time.Unix(2, 0).Unix()), map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.MaintenanceEndTime,
+					metric: createGaugeMetric(float64(time.Unix(3, 0).Unix()), map[string]string{
+						"id":   "101",
+						"name": "server",
+						"zone": "is1a",
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = tc.in
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
+
+func TestServerCollector_CollectMaintenanceOnly(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, nil, true, 0)
+	monitorTime := time.Unix(1, 0)
+
+	server := &platform.Server{
+		ZoneName: "is1a",
+		Server: &iaas.Server{
+			ID:               101,
+			Name:             "server",
+			Description:      "desc",
+			Tags:             types.Tags{"tag1", "tag2"},
+			CPU:              2,
+			MemoryMB:         4 * 1024,
+			InstanceStatus:   types.ServerInstanceStatuses.Up,
+			Availability:     types.Availabilities.Available,
+			InstanceHostName: "sacXXX",
+			Disks: []*iaas.ServerConnectedDisk{
+				{
+					ID:         201,
+					Name:       "disk",
+					DiskPlanID: types.DiskPlans.SSD,
+					Connection: types.DiskConnections.VirtIO,
+					SizeMB:     20 * 1024,
+					Storage: &iaas.Storage{
+						ID:         1001,
+						Class:      "iscsi1204",
+						Generation: 100,
+					},
+				},
+			},
+			Interfaces: []*iaas.InterfaceView{
+				{
+					ID:           301,
+					SwitchID:     401,
+					SwitchName:   "switch",
+					UpstreamType: types.UpstreamNetworkTypes.Switch,
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		in             platform.ServerClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "collector returns error",
+			in: &dummyServerClient{
+				findErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list servers" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			in:          &dummyServerClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a server maintenance scheduled",
+			in: &dummyServerClient{
+				find: []*platform.Server{server},
+				monitorCPU: &iaas.MonitorCPUTimeValue{
 					Time:    monitorTime,
 					CPUTime: 100,
 				},
@@ -722,3 +1272,755 @@ func TestServerCollector_CollectMaintenanceOnly(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestServerCollector_NICUpstream(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:             101,
+					Name:           "server",
+					InstanceStatus: types.ServerInstanceStatuses.Down,
+					Availability:   types.Availabilities.Available,
+					Interfaces: []*iaas.InterfaceView{
+						{ID: 301, UpstreamType: types.UpstreamNetworkTypes.Shared},
+						{ID: 302, SwitchID: 401, UpstreamType: types.UpstreamNetworkTypes.Switch},
+						{ID: 303, SwitchID: 402, UpstreamType: types.UpstreamNetworkTypes.Switch},
+						{ID: 304, UpstreamType: types.UpstreamNetworkTypes.None},
+					},
+				},
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	got := collected.filter(c.NICUpstream)
+
+	upstream := func(upstreamType string, count float64) *collectedMetric {
+		return &collectedMetric{
+			desc: c.NICUpstream,
+			metric: createGaugeMetric(count, map[string]string{
+				"id":            "101",
+				"name":          "server",
+				"zone":          "is1a",
+				"upstream_type": upstreamType,
+			}),
+		}
+	}
+	requireMetricsEqual(t, []*collectedMetric{
+		upstream("shared", 1),
+		upstream("switch", 2),
+		upstream("none", 1),
+	}, got)
+}
+
+func TestServerCollector_TransitionStuck(t *testing.T) {
+	initLoggerAndErrors()
+	now := time.Now()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:                      101,
+					Name:                    "long-cleaning",
+					InstanceStatus:          types.ServerInstanceStatuses.Cleaning,
+					InstanceStatusChangedAt: now.Add(-2 * time.Hour),
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:                      102,
+					Name:                    "short-cleaning",
+					InstanceStatus:          types.ServerInstanceStatuses.Cleaning,
+					InstanceStatusChangedAt: now.Add(-10 * time.Minute),
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:                      103,
+					Name:                    "long-down",
+					InstanceStatus:          types.ServerInstanceStatuses.Down,
+					InstanceStatusChangedAt: now.Add(-2 * time.Hour),
+				},
+			},
+		},
+	}, false, time.Hour)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	got := collected.filter(c.TransitionStuck)
+
+	stuck := func(id, name string, v float64) *collectedMetric {
+		return &collectedMetric{
+			desc: c.TransitionStuck,
+			metric: createGaugeMetric(v, map[string]string{
+				"id":   id,
+				"name": name,
+				"zone": "is1a",
+			}),
+		}
+	}
+	requireMetricsEqual(t, []*collectedMetric{
+		stuck("101", "long-cleaning", 1),
+		stuck("102", "short-cleaning", 0),
+		stuck("103", "long-down", 0),
+	}, got)
+}
+
+func TestServerStatusTracker(t *testing.T) {
+	tracker := newServerStatusTracker()
+	start := time.Unix(1, 0)
+	server := &platform.Server{
+		Server: &iaas.Server{
+			ID:             101,
+			InstanceStatus: types.ServerInstanceStatuses.Cleaning,
+		},
+	}
+
+	// without InstanceStatusChangedAt, the time first observed is used
+	require.Equal(t, start, tracker.observe(server, start))
+	require.Equal(t, start, tracker.observe(server, start.Add(2*time.Hour)))
+
+	// status changes reset the time
+	server.InstanceStatus = types.ServerInstanceStatuses.Up
+	require.Equal(t, start.Add(3*time.Hour), tracker.observe(server, start.Add(3*time.Hour)))
+
+	// removed servers are forgotten
+	tracker.retain(nil)
+	server.InstanceStatus = types.ServerInstanceStatuses.Cleaning
+	require.Equal(t, start.Add(4*time.Hour), tracker.observe(server, start.Add(4*time.Hour)))
+}
+
+func TestServerCollector_EmitZeroOnNil(t *testing.T) {
+	client := &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:             101,
+					Name:           "server",
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+				},
+			},
+		},
+		monitorCPU: nil,
+	}
+
+	cases := []struct {
+		name          string
+		emitZeroOnNil bool
+		wantCount     int
+	}{
+		{
+			name:          "disabled",
+			emitZeroOnNil: false,
+			wantCount:     0,
+		},
+		{
+			name:          "enabled",
+			emitZeroOnNil: true,
+			wantCount:     1,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{EmitZeroOnNil: tc.emitZeroOnNil}, client, false, 0)
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		got := collected.filter(c.CPUTime)
+		require.Len(t, got, tc.wantCount, tc.name)
+		for _, m := range got {
+			require.Equal(t, float64(0), m.metric.GetGauge().GetValue(), tc.name)
+			require.NotZero(t, m.metric.GetTimestampMs(), tc.name)
+		}
+	}
+}
+
+func TestServerCollector_ExposeSampleTimestamps(t *testing.T) {
+	sampleTime := time.Unix(1700000000, 0)
+	client := &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:             101,
+					Name:           "server",
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+				},
+			},
+		},
+		monitorCPU: &iaas.MonitorCPUTimeValue{
+			Time:    sampleTime,
+			CPUTime: 1,
+		},
+	}
+
+	cases := []struct {
+		name                   string
+		exposeSampleTimestamps bool
+		want                   []float64
+	}{
+		{
+			name:                   "disabled",
+			exposeSampleTimestamps: false,
+			want:                   nil,
+		},
+		{
+			name:                   "enabled",
+			exposeSampleTimestamps: true,
+			want:                   []float64{float64(sampleTime.Unix())},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{ExposeSampleTimestamps: tc.exposeSampleTimestamps}, client, false, 0)
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		got := collected.values(c.MonitorSampleTimestamp)
+		require.Equal(t, tc.want, got, tc.name)
+	}
+}
+
+func TestServerCollector_CountByTag(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{CountByTags: []string{"env"}}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   101,
+					Name: "server1",
+					Tags: types.Tags{"env=prod", "team=a"},
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   102,
+					Name: "server2",
+					Tags: types.Tags{"env=prod", "team=b"},
+				},
+			},
+			{
+				ZoneName: "is1b",
+				Server: &iaas.Server{
+					ID:   103,
+					Name: "server3",
+					Tags: types.Tags{"env=dev", "env"},
+				},
+			},
+			{
+				ZoneName: "is1b",
+				Server: &iaas.Server{
+					ID:   104,
+					Name: "server4",
+				},
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	got := collected.filter(c.CountByTag)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.CountByTag,
+			metric: createGaugeMetric(2, map[string]string{
+				"resource_type": "server",
+				"tag_key":       "env",
+				"tag_value":     "prod",
+			}),
+		},
+		{
+			desc: c.CountByTag,
+			metric: createGaugeMetric(1, map[string]string{
+				"resource_type": "server",
+				"tag_key":       "env",
+				"tag_value":     "dev",
+			}),
+		},
+	}, got)
+}
+
+func TestServerCollector_Memories(t *testing.T) {
+	cases := []struct {
+		name     string
+		memoryMB int
+		want     float64
+	}{
+		{name: "1GB", memoryMB: 1024, want: 1},
+		{name: "4GB", memoryMB: 4 * 1024, want: 4},
+		{name: "224GB", memoryMB: 224 * 1024, want: 224},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+			find: []*platform.Server{
+				{
+					ZoneName: "is1a",
+					Server: &iaas.Server{
+						ID:       101,
+						Name:     "server",
+						MemoryMB: tc.memoryMB,
+					},
+				},
+			},
+		}, false, 0)
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		got := collected.values(c.Memories)
+		// the memory size is reported in GB, not in MB
+		require.Equal(t, []float64{tc.want}, got, tc.name)
+	}
+}
+
+func TestServerCollector_CDROMInserted(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:      101,
+					Name:    "with-iso",
+					CDROMID: 201,
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   102,
+					Name: "without-iso",
+				},
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	inserted := collected.filter(c.CDROMInserted)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.CDROMInserted,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":       "101",
+				"name":     "with-iso",
+				"zone":     "is1a",
+				"cdrom_id": "201",
+			}),
+		},
+	}, inserted)
+}
+
+func TestServerCollector_DefaultNamedServer(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   101,
+					Name: "Untitled",
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   102,
+					Name: " 名称未設定 ",
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   103,
+					Name: "untitled-server",
+				},
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	unnamed := collected.filter(c.Unnamed)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.Unnamed,
+			metric: createGaugeMetric(1, map[string]string{
+				"resource_type": "server",
+				"id":            "101",
+				"zone":          "is1a",
+			}),
+		},
+		{
+			desc: c.Unnamed,
+			metric: createGaugeMetric(1, map[string]string{
+				"resource_type": "server",
+				"id":            "102",
+				"zone":          "is1a",
+			}),
+		},
+	}, unnamed)
+}
+
+func TestServerCollector_DiskInfoWithoutReadDisk(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   101,
+					Name: "server",
+					Disks: []*iaas.ServerConnectedDisk{
+						{
+							ID:         201,
+							Name:       "disk1",
+							DiskPlanID: types.DiskPlans.HDD,
+							Connection: types.DiskConnections.IDE,
+							SizeMB:     40 * 1024,
+							Storage: &iaas.Storage{
+								ID:         1001,
+								Class:      "iscsi1204",
+								Generation: 100,
+							},
+						},
+						{
+							ID:         202,
+							Name:       "disk2",
+							DiskPlanID: types.DiskPlans.SSD,
+							Connection: types.DiskConnections.VirtIO,
+							SizeMB:     20 * 1024,
+						},
+					},
+				},
+			},
+		},
+		readDiskErr: errors.New("dummy"),
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+	require.Equal(t, float64(2), *collected.errors.Counter.Value)
+
+	diskInfo := collected.filter(c.DiskInfo)
+	// the labels are taken from the disks connected to the server, and the disk without storage has empty storage labels
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.DiskInfo,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":                 "101",
+				"name":               "server",
+				"zone":               "is1a",
+				"disk_id":            "201",
+				"disk_name":          "disk1",
+				"index":              "0",
+				"plan":               "hdd",
+				"interface":          "ide",
+				"size":               "40",
+				"tags":               "",
+				"description":        "",
+				"storage_id":         "1001",
+				"storage_class":      "iscsi1204",
+				"icon_id":            "",
+				"storage_generation": "100",
+			}),
+		},
+		{
+			desc: c.DiskInfo,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":                 "101",
+				"name":               "server",
+				"zone":               "is1a",
+				"disk_id":            "202",
+				"disk_name":          "disk2",
+				"index":              "1",
+				"plan":               "ssd",
+				"interface":          "virtio",
+				"size":               "20",
+				"tags":               "",
+				"description":        "",
+				"storage_id":         "",
+				"storage_class":      "",
+				"icon_id":            "",
+				"storage_generation": "",
+			}),
+		},
+	}, diskInfo)
+}
+
+func TestServerCollector_MixedStorageGeneration(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   101,
+					Name: "mixed",
+					Disks: []*iaas.ServerConnectedDisk{
+						{ID: 201, Storage: &iaas.Storage{ID: 1001, Generation: 100}},
+						{ID: 202, Storage: &iaas.Storage{ID: 1002, Generation: 200}},
+					},
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   102,
+					Name: "single",
+					Disks: []*iaas.ServerConnectedDisk{
+						{ID: 203, Storage: &iaas.Storage{ID: 1001, Generation: 100}},
+						{ID: 204, Storage: &iaas.Storage{ID: 1003, Generation: 100}},
+					},
+				},
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	mixed := collected.filter(c.MixedStorageGeneration)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.MixedStorageGeneration,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":   "101",
+				"name": "mixed",
+				"zone": "is1a",
+			}),
+		},
+		{
+			desc: c.MixedStorageGeneration,
+			metric: createGaugeMetric(0, map[string]string{
+				"id":   "102",
+				"name": "single",
+				"zone": "is1a",
+			}),
+		},
+	}, mixed)
+}
+
+func TestServerCollector_SampleInterval(t *testing.T) {
+	initLoggerAndErrors()
+	client := &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:             101,
+					Name:           "server",
+					Availability:   types.Availabilities.Available,
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+				},
+			},
+		},
+	}
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, client, false, 0)
+
+	collectIntervals := func(sampleTime time.Time) []float64 {
+		client.monitorCPU = &iaas.MonitorCPUTimeValue{Time: sampleTime, CPUTime: 1}
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		intervals := collected.values(c.SampleInterval)
+		return intervals
+	}
+	// the interval can't be computed from the first sample
+	require.Empty(t, collectIntervals(time.Unix(1000, 0)))
+	require.Equal(t, []float64{300}, collectIntervals(time.Unix(1300, 0)))
+	// the last interval is kept while the monitor API returns the same sample
+	require.Equal(t, []float64{300}, collectIntervals(time.Unix(1300, 0)))
+}
+
+func TestServerCollector_StorageDiskCount(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+		find: []*platform.Server{
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   101,
+					Name: "server1",
+					Disks: []*iaas.ServerConnectedDisk{
+						{ID: 201},
+						{ID: 202},
+					},
+				},
+			},
+			{
+				ZoneName: "is1a",
+				Server: &iaas.Server{
+					ID:   102,
+					Name: "server2",
+					Disks: []*iaas.ServerConnectedDisk{
+						{ID: 203},
+					},
+				},
+			},
+		},
+		// every disk is placed on the same storage
+		readDisk: &iaas.Disk{
+			ID: 201,
+			Storage: &iaas.Storage{
+				ID:         1001,
+				Class:      "iscsi1204",
+				Generation: 100,
+			},
+		},
+	}, false, 0)
+
+	collected, err := collectMetrics(c, "server")
+	require.NoError(t, err)
+
+	counts := collected.filter(c.StorageDiskCount, c.StorageAttachedDiskCount)
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.StorageDiskCount,
+			metric: createGaugeMetric(3, map[string]string{
+				"storage_id":         "1001",
+				"storage_class":      "iscsi1204",
+				"storage_generation": "100",
+			}),
+		},
+		{
+			desc: c.StorageAttachedDiskCount,
+			metric: createGaugeMetric(3, map[string]string{
+				"storage_id":    "1001",
+				"storage_class": "iscsi1204",
+			}),
+		},
+	}, counts)
+}
+
+func TestServerCollector_DiskFeature(t *testing.T) {
+	server := &platform.Server{
+		ZoneName: "is1a",
+		Server: &iaas.Server{
+			ID:   101,
+			Name: "server",
+			Disks: []*iaas.ServerConnectedDisk{
+				{ID: 201, Name: "disk"},
+			},
+		},
+	}
+	encryption := func(v float64) *collectedMetric {
+		return &collectedMetric{
+			metric: createGaugeMetric(v, map[string]string{
+				"id":        "101",
+				"name":      "server",
+				"zone":      "is1a",
+				"disk_id":   "201",
+				"disk_name": "disk",
+				"index":     "0",
+				"feature":   "encryption",
+			}),
+		}
+	}
+
+	cases := []struct {
+		name     string
+		readDisk *iaas.Disk
+		want     []*collectedMetric
+	}{
+		{
+			name:     "encrypted disk",
+			readDisk: &iaas.Disk{ID: 201, EncryptionAlgorithm: types.DiskEncryptionAlgorithms.AES256XTS},
+			want:     []*collectedMetric{encryption(1)},
+		},
+		{
+			name:     "unencrypted disk",
+			readDisk: &iaas.Disk{ID: 201, EncryptionAlgorithm: types.DiskEncryptionAlgorithms.None},
+			want:     []*collectedMetric{encryption(0)},
+		},
+		{
+			name:     "encryption algorithm is not reported",
+			readDisk: &iaas.Disk{ID: 201},
+			want:     nil,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+			find:     []*platform.Server{server},
+			readDisk: tc.readDisk,
+		}, false, 0)
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+
+		for _, m := range tc.want {
+			m.desc = c.DiskFeature
+		}
+		requireMetricsEqual(t, tc.want, collected.filter(c.DiskFeature))
+	}
+}
+
+func TestServerCollector_DiskSizeRatio(t *testing.T) {
+	cases := []struct {
+		name string
+		disk *iaas.ServerConnectedDisk
+		want []float64
+	}{
+		{
+			name: "maxed-out SSD",
+			disk: &iaas.ServerConnectedDisk{ID: 201, DiskPlanID: types.DiskPlans.SSD, SizeMB: 4 * 1024 * 1024},
+			want: []float64{1},
+		},
+		{
+			name: "half-sized HDD",
+			disk: &iaas.ServerConnectedDisk{ID: 201, DiskPlanID: types.DiskPlans.HDD, SizeMB: 6 * 1024 * 1024},
+			want: []float64{0.5},
+		},
+		{
+			name: "unknown plan",
+			disk: &iaas.ServerConnectedDisk{ID: 201, DiskPlanID: 999, SizeMB: 20 * 1024},
+			want: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewServerCollector(context.Background(), testLogger, testErrors, Options{}, &dummyServerClient{
+			find: []*platform.Server{
+				{
+					ZoneName: "is1a",
+					Server: &iaas.Server{
+						ID:    101,
+						Name:  "server",
+						Disks: []*iaas.ServerConnectedDisk{tc.disk},
+					},
+				},
+			},
+			readDiskErr: errors.New("dummy"),
+		}, false, 0)
+
+		collected, err := collectMetrics(c, "server")
+		require.NoError(t, err)
+		require.Equal(t, tc.want, collected.values(c.DiskSizeRatio), tc.name)
+	}
+}
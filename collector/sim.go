@@ -24,6 +24,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/platform"
 )
 
@@ -32,6 +33,7 @@ type SIMCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.SIMClient
 
 	Up      *prometheus.Desc
@@ -39,10 +41,16 @@ type SIMCollector struct {
 
 	Uplink   *prometheus.Desc
 	Downlink *prometheus.Desc
+
+	SessionDuration *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
 // NewSIMCollector returns a new SIMCollector.
-func NewSIMCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.SIMClient) *SIMCollector {
+func NewSIMCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.SIMClient) *SIMCollector {
 	errors.WithLabelValues("sim").Add(0)
 
 	simLabels := []string{"id", "name"}
@@ -54,27 +62,36 @@ func NewSIMCollector(ctx context.Context, logger *slog.Logger, errors *prometheu
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+		Up: opts.newDesc(
 			"sakuracloud_sim_session_up",
 			"If 1 the session is up and running, 0 otherwise",
 			simLabels, nil,
 		),
-		SIMInfo: prometheus.NewDesc(
+		SIMInfo: opts.newDesc(
 			"sakuracloud_sim_info",
 			"A metric with a constant '1' value labeled by sim information",
 			simInfoLabels, nil,
 		),
-		Uplink: prometheus.NewDesc(
+		Uplink: opts.newDesc(
 			"sakuracloud_sim_uplink",
 			"Uplink traffic (unit: Kbps)",
 			simLabels, nil,
 		),
-		Downlink: prometheus.NewDesc(
+		Downlink: opts.newDesc(
 			"sakuracloud_sim_downlink",
 			"Downlink traffic (unit: Kbps)",
 			simLabels, nil,
 		),
+		SessionDuration: opts.newDesc(
+			"sakuracloud_sim_session_duration_seconds",
+			"Elapsed time since the current data session was started (unit: second)",
+			simLabels, nil,
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("sim"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("sim"),
+		CountByTag:      opts.newResourceCountByTagDesc("sim"),
 	}
 }
 
@@ -86,11 +103,20 @@ func (c *SIMCollector) Describe(ch chan<- *prometheus.Desc) {
 
 	ch <- c.Uplink
 	ch <- c.Downlink
+	ch <- c.SessionDuration
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
-	sims, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *SIMCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	sims, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("sim").Add(1)
 		c.logger.Warn(
@@ -99,6 +125,12 @@ func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(sims))
+	for _, sim := range sims {
+		tags = append(tags, sim.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
 	var wg sync.WaitGroup
 	wg.Add(len(sims))
 
@@ -107,6 +139,8 @@ func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			simLabels := c.simLabels(sim)
+			collectResourceUnnamed(ch, c.Unnamed, sim.ID, "", sim.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, sim.ID, "", sim.CreatedAt)
 
 			var up float64
 			if strings.ToLower(sim.Info.SessionStatus) == "up" {
@@ -121,7 +155,7 @@ func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
 
 			wg.Add(1)
 			go func() {
-				c.collectSIMInfo(ch, sim)
+				c.collectSIMInfo(ctx, ch, sim)
 				wg.Done()
 			}()
 
@@ -130,7 +164,13 @@ func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
 
 				wg.Add(1)
 				go func() {
-					c.collectSIMMetrics(ch, sim, now)
+					c.collectSIMMetrics(ctx, ch, sim, now)
+					wg.Done()
+				}()
+
+				wg.Add(1)
+				go func() {
+					c.collectSessionDuration(ctx, ch, sim, now)
 					wg.Done()
 				}()
 			}
@@ -143,12 +183,12 @@ func (c *SIMCollector) Collect(ch chan<- prometheus.Metric) {
 func (c *SIMCollector) simLabels(sim *iaas.SIM) []string {
 	return []string{
 		sim.ID.String(),
-		sim.Name,
+		c.opts.truncateLabelValue(sim.Name),
 	}
 }
 
-func (c *SIMCollector) collectSIMInfo(ch chan<- prometheus.Metric, sim *iaas.SIM) {
-	simConfigs, err := c.client.GetNetworkOperatorConfig(c.ctx, sim.ID)
+func (c *SIMCollector) collectSIMInfo(ctx context.Context, ch chan<- prometheus.Metric, sim *iaas.SIM) {
+	simConfigs, err := c.client.GetNetworkOperatorConfig(ctx, sim.ID)
 	if err != nil {
 		c.errors.WithLabelValues("sim").Add(1)
 		c.logger.Warn(
@@ -190,8 +230,8 @@ func (c *SIMCollector) collectSIMInfo(ch chan<- prometheus.Metric, sim *iaas.SIM
 		simInfo.IP,
 		simInfo.SIMGroupID,
 		flattenStringSlice(carriers),
-		flattenStringSlice(sim.Tags),
-		sim.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(sim.Tags)),
+		c.opts.truncateLabelValue(sim.Description),
 	)
 
 	ch <- prometheus.MustNewConstMetric(
@@ -202,8 +242,8 @@ func (c *SIMCollector) collectSIMInfo(ch chan<- prometheus.Metric, sim *iaas.SIM
 	)
 }
 
-func (c *SIMCollector) collectSIMMetrics(ch chan<- prometheus.Metric, sim *iaas.SIM, now time.Time) {
-	values, err := c.client.MonitorTraffic(c.ctx, sim.ID, now)
+func (c *SIMCollector) collectSIMMetrics(ctx context.Context, ch chan<- prometheus.Metric, sim *iaas.SIM, now time.Time) {
+	values, err := c.client.MonitorTraffic(ctx, sim.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("sim").Add(1)
 		c.logger.Warn(
@@ -213,7 +253,10 @@ func (c *SIMCollector) collectSIMMetrics(ch chan<- prometheus.Metric, sim *iaas.
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorLinkValue{Time: now}
 	}
 
 	uplink := values.UplinkBPS
@@ -240,3 +283,44 @@ func (c *SIMCollector) collectSIMMetrics(ch chan<- prometheus.Metric, sim *iaas.
 	)
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
+
+func (c *SIMCollector) collectSessionDuration(ctx context.Context, ch chan<- prometheus.Metric, sim *iaas.SIM, now time.Time) {
+	logs, err := c.client.Logs(ctx, sim.ID)
+	if err != nil {
+		c.errors.WithLabelValues("sim").Add(1)
+		c.logger.Warn(
+			fmt.Sprintf("can't get sim's logs: SIMID=%d", sim.ID),
+			slog.Any("err", err),
+		)
+		return
+	}
+
+	start := simSessionStartedAt(logs)
+	if start.IsZero() || start.After(now) {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(
+		c.SessionDuration,
+		prometheus.GaugeValue,
+		now.Sub(start).Seconds(),
+		c.simLabels(sim)...,
+	)
+}
+
+// simSessionStartedAt returns the date of the latest session start log, or zero time if not found
+func simSessionStartedAt(logs []*iaas.SIMLog) time.Time {
+	var start time.Time
+	for _, log := range logs {
+		if log == nil {
+			continue
+		}
+		switch strings.ToLower(log.SessionStatus) {
+		case "created", "up":
+			if log.Date.After(start) {
+				start = log.Date
+			}
+		}
+	}
+	return start
+}
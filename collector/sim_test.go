@@ -34,6 +34,8 @@ type dummySIMClient struct {
 	nopConfigErr error
 	monitor      *iaas.MonitorLinkValue
 	monitorErr   error
+	logs         []*iaas.SIMLog
+	logsErr      error
 }
 
 func (d *dummySIMClient) Find(ctx context.Context) ([]*iaas.SIM, error) {
@@ -45,10 +47,13 @@ func (d *dummySIMClient) GetNetworkOperatorConfig(ctx context.Context, id types.
 func (d *dummySIMClient) MonitorTraffic(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorLinkValue, error) {
 	return d.monitor, d.monitorErr
 }
+func (d *dummySIMClient) Logs(ctx context.Context, id types.ID) ([]*iaas.SIMLog, error) {
+	return d.logs, d.logsErr
+}
 
 func TestSIMCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewSIMCollector(context.Background(), testLogger, testErrors, &dummySIMClient{})
+	c := NewSIMCollector(context.Background(), testLogger, testErrors, Options{}, &dummySIMClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
@@ -56,12 +61,16 @@ func TestSIMCollector_Describe(t *testing.T) {
 		c.SIMInfo,
 		c.Uplink,
 		c.Downlink,
+		c.SessionDuration,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestSIMCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewSIMCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewSIMCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -182,6 +191,7 @@ func TestSIMCollector_Collect(t *testing.T) {
 				},
 				nopConfigErr: errors.New("dummy1"),
 				monitorErr:   errors.New("dummy2"),
+				logsErr:      errors.New("dummy3"),
 			},
 			wantMetrics: []*collectedMetric{
 				{
@@ -192,8 +202,9 @@ func TestSIMCollector_Collect(t *testing.T) {
 					}),
 				},
 			},
-			wantErrCounter: 2,
+			wantErrCounter: 3,
 			wantLogs: []string{
+				`level=WARN msg="can't get sim's logs: SIMID=101" err=dummy3`,
 				`level=WARN msg="can't get sim's metrics: SIMID=101" err=dummy2`,
 				`level=WARN msg="can't get sim's network operator config: SIMID=101" err=dummy1`,
 			},
@@ -213,3 +224,30 @@ func TestSIMCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestSIMCollector_SessionDuration(t *testing.T) {
+	initLoggerAndErrors()
+	sessionStarted := time.Now().Add(-72 * time.Hour)
+	c := NewSIMCollector(context.Background(), testLogger, testErrors, Options{}, &dummySIMClient{
+		find: []*iaas.SIM{
+			{
+				ID:   101,
+				Name: "sim",
+				Info: &iaas.SIMInfo{SessionStatus: "UP"},
+			},
+		},
+		logs: []*iaas.SIMLog{
+			{Date: sessionStarted, SessionStatus: "Created"},
+			{Date: sessionStarted.Add(-time.Hour), SessionStatus: "Deleted"},
+			{Date: sessionStarted.Add(-2 * time.Hour), SessionStatus: "Created"},
+		},
+	})
+
+	collected, err := collectMetrics(c, "sim")
+	require.NoError(t, err)
+
+	durations := collected.values(c.SessionDuration)
+	require.Len(t, durations, 1)
+	require.GreaterOrEqual(t, durations[0], (72 * time.Hour).Seconds())
+	require.Less(t, durations[0], (73 * time.Hour).Seconds())
+}
@@ -0,0 +1,330 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+)
+
+// SimpleMonitorCollector collects metrics about all simple monitors.
+type SimpleMonitorCollector struct {
+	ctx    context.Context
+	logger *slog.Logger
+	errors *prometheus.CounterVec
+	opts   Options
+	client platform.SimpleMonitorClient
+
+	availabilityWindow time.Duration
+	availabilities     *simpleMonitorAvailabilityTracker
+
+	Up                *prometheus.Desc
+	SimpleMonitorInfo *prometheus.Desc
+	Latency           *prometheus.Desc
+	AvailabilityRatio *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
+}
+
+// NewSimpleMonitorCollector returns a new SimpleMonitorCollector.
+//
+// If availabilityWindow is 0, sakuracloud_simplemonitor_availability_ratio is not collected.
+func NewSimpleMonitorCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.SimpleMonitorClient, availabilityWindow time.Duration) *SimpleMonitorCollector {
+	errors.WithLabelValues("simple_monitor").Add(0)
+
+	simpleMonitorLabels := []string{"id", "name", "target"}
+	simpleMonitorInfoLabels := append(simpleMonitorLabels, "protocol", "enabled", "tags", "description")
+
+	return &SimpleMonitorCollector{
+		ctx:    ctx,
+		logger: logger,
+		errors: errors,
+		opts:   opts,
+		client: client,
+
+		availabilityWindow: availabilityWindow,
+		availabilities:     newSimpleMonitorAvailabilityTracker(),
+
+		Up: opts.newDesc(
+			"sakuracloud_simplemonitor_up",
+			"If 1 the latest health check of the simple monitor succeeded, 0 otherwise",
+			simpleMonitorLabels, nil,
+		),
+		SimpleMonitorInfo: opts.newDesc(
+			"sakuracloud_simplemonitor_info",
+			"A metric with a constant '1' value labeled by simple monitor information",
+			simpleMonitorInfoLabels, nil,
+		),
+		Latency: opts.newDesc(
+			"sakuracloud_simplemonitor_latency_seconds",
+			"Response time of the health check in seconds",
+			simpleMonitorLabels, nil,
+		),
+		AvailabilityRatio: opts.newDesc(
+			"sakuracloud_simplemonitor_availability_ratio",
+			"Ratio of the health checks observed as up within the availability window",
+			simpleMonitorLabels, prometheus.Labels{"window": formatWindow(availabilityWindow)},
+		),
+		Unnamed:         opts.newResourceUnnamedDesc("simple_monitor"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("simple_monitor"),
+		CountByTag:      opts.newResourceCountByTagDesc("simple_monitor"),
+	}
+}
+
+// Describe sends the super-set of all possible descriptors of metrics
+// collected by this Collector.
+func (c *SimpleMonitorCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.Up
+	ch <- c.SimpleMonitorInfo
+	ch <- c.Latency
+	ch <- c.AvailabilityRatio
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
+}
+
+// Collect is called by the Prometheus registry when collecting metrics.
+func (c *SimpleMonitorCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *SimpleMonitorCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	simpleMonitors, err := c.client.Find(ctx)
+	if err != nil {
+		c.errors.WithLabelValues("simple_monitor").Add(1)
+		c.logger.Warn(
+			"can't list simple monitors",
+			slog.Any("err", err),
+		)
+	}
+
+	if err == nil {
+		ids := make([]types.ID, 0, len(simpleMonitors))
+		for _, simpleMonitor := range simpleMonitors {
+			ids = append(ids, simpleMonitor.ID)
+		}
+		c.availabilities.retain(ids)
+	}
+
+	tags := make([]types.Tags, 0, len(simpleMonitors))
+	for _, simpleMonitor := range simpleMonitors {
+		tags = append(tags, simpleMonitor.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	c.opts.warnGoroutines(c.logger, "simple_monitor", estimateSimpleMonitorGoroutines(simpleMonitors))
+
+	var wg sync.WaitGroup
+	for _, simpleMonitor := range simpleMonitors {
+		collectResourceUnnamed(ch, c.Unnamed, simpleMonitor.ID, "", simpleMonitor.Name)
+		c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, simpleMonitor.ID, "", simpleMonitor.CreatedAt)
+
+		c.collectSimpleMonitorInfo(ch, simpleMonitor)
+
+		// disabled simple monitors don't run the health checks
+		if !simpleMonitor.Enabled.Bool() {
+			continue
+		}
+
+		now := time.Now()
+		wg.Add(2)
+		go func(simpleMonitor *iaas.SimpleMonitor) {
+			c.collectHealthStatus(ctx, ch, simpleMonitor)
+			wg.Done()
+		}(simpleMonitor)
+		go func(simpleMonitor *iaas.SimpleMonitor) {
+			c.collectLatency(ctx, ch, simpleMonitor, now)
+			wg.Done()
+		}(simpleMonitor)
+	}
+
+	wg.Wait()
+}
+
+// estimateSimpleMonitorGoroutines returns the upper bound of the number of goroutines spawned per Collect:
+// health status and response time for each enabled simple monitor
+func estimateSimpleMonitorGoroutines(simpleMonitors []*iaas.SimpleMonitor) int {
+	n := 0
+	for _, simpleMonitor := range simpleMonitors {
+		if simpleMonitor.Enabled.Bool() {
+			n += 2
+		}
+	}
+	return n
+}
+
+func (c *SimpleMonitorCollector) simpleMonitorLabels(simpleMonitor *iaas.SimpleMonitor) []string {
+	return []string{
+		simpleMonitor.ID.String(),
+		c.opts.truncateLabelValue(simpleMonitor.Name),
+		simpleMonitor.Target,
+	}
+}
+
+func (c *SimpleMonitorCollector) collectSimpleMonitorInfo(ch chan<- prometheus.Metric, simpleMonitor *iaas.SimpleMonitor) {
+	var protocol string
+	if simpleMonitor.HealthCheck != nil {
+		protocol = simpleMonitor.HealthCheck.Protocol.String()
+	}
+	enabled := "0"
+	if simpleMonitor.Enabled.Bool() {
+		enabled = "1"
+	}
+
+	labels := append(c.simpleMonitorLabels(simpleMonitor),
+		protocol,
+		enabled,
+		c.opts.truncateLabelValue(flattenStringSlice(simpleMonitor.Tags)),
+		c.opts.truncateLabelValue(simpleMonitor.Description),
+	)
+
+	ch <- prometheus.MustNewConstMetric(
+		c.SimpleMonitorInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		labels...,
+	)
+}
+
+func (c *SimpleMonitorCollector) collectHealthStatus(ctx context.Context, ch chan<- prometheus.Metric, simpleMonitor *iaas.SimpleMonitor) {
+	status, err := c.client.HealthStatus(ctx, simpleMonitor.ID)
+	if err != nil {
+		c.errors.WithLabelValues("simple_monitor").Add(1)
+		c.logger.Warn(
+			fmt.Sprintf("can't get simple monitor's health status: SimpleMonitorID=%d", simpleMonitor.ID),
+			slog.Any("err", err),
+		)
+		return
+	}
+	if status == nil {
+		return
+	}
+
+	var up float64
+	if status.Health.IsUp() {
+		up = 1.0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.Up,
+		prometheus.GaugeValue,
+		up,
+		c.simpleMonitorLabels(simpleMonitor)...,
+	)
+
+	if c.availabilityWindow > 0 {
+		ratio := c.availabilities.observe(simpleMonitor.ID, status.Health.IsUp(), time.Now(), c.availabilityWindow)
+		ch <- prometheus.MustNewConstMetric(
+			c.AvailabilityRatio,
+			prometheus.GaugeValue,
+			ratio,
+			c.simpleMonitorLabels(simpleMonitor)...,
+		)
+	}
+}
+
+// simpleMonitorAvailabilityTracker keeps the up/down states of each simple monitor observed across Collects
+type simpleMonitorAvailabilityTracker struct {
+	mu      sync.Mutex
+	history map[types.ID][]simpleMonitorAvailabilitySample
+}
+
+type simpleMonitorAvailabilitySample struct {
+	time time.Time
+	up   bool
+}
+
+func newSimpleMonitorAvailabilityTracker() *simpleMonitorAvailabilityTracker {
+	return &simpleMonitorAvailabilityTracker{
+		history: make(map[types.ID][]simpleMonitorAvailabilitySample),
+	}
+}
+
+// observe records the state of the simple monitor, and returns the ratio of up states observed within the window.
+// The states observed before the window are dropped.
+func (t *simpleMonitorAvailabilityTracker) observe(id types.ID, up bool, now time.Time, window time.Duration) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	history := append(t.history[id], simpleMonitorAvailabilitySample{time: now, up: up})
+	start := now.Add(-window)
+	for len(history) > 0 && !history[0].time.After(start) {
+		history = history[1:]
+	}
+	t.history[id] = history
+
+	if len(history) == 0 {
+		return 0
+	}
+	var ups int
+	for _, sample := range history {
+		if sample.up {
+			ups++
+		}
+	}
+	return float64(ups) / float64(len(history))
+}
+
+// retain removes the simple monitors which no longer exist
+func (t *simpleMonitorAvailabilityTracker) retain(ids []types.ID) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	exists := make(map[types.ID]bool)
+	for _, id := range ids {
+		exists[id] = true
+	}
+	for id := range t.history {
+		if !exists[id] {
+			delete(t.history, id)
+		}
+	}
+}
+
+func (c *SimpleMonitorCollector) collectLatency(ctx context.Context, ch chan<- prometheus.Metric, simpleMonitor *iaas.SimpleMonitor, now time.Time) {
+	value, err := c.client.MonitorResponseTime(ctx, simpleMonitor.ID, now)
+	if err != nil {
+		c.errors.WithLabelValues("simple_monitor").Add(1)
+		c.logger.Warn(
+			fmt.Sprintf("can't get simple monitor's response time: SimpleMonitorID=%d", simpleMonitor.ID),
+			slog.Any("err", err),
+		)
+		return
+	}
+	if value == nil {
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		value = &iaas.MonitorResponseTimeSecValue{Time: now}
+	}
+
+	m := prometheus.MustNewConstMetric(
+		c.Latency,
+		prometheus.GaugeValue,
+		value.ResponseTimeSec,
+		c.simpleMonitorLabels(simpleMonitor)...,
+	)
+	ch <- prometheus.NewMetricWithTimestamp(value.Time, m)
+}
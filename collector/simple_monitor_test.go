@@ -0,0 +1,317 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type dummySimpleMonitorClient struct {
+	find            []*iaas.SimpleMonitor
+	findErr         error
+	health          *iaas.SimpleMonitorHealthStatus
+	healthErr       error
+	responseTime    *iaas.MonitorResponseTimeSecValue
+	responseTimeErr error
+}
+
+func (d *dummySimpleMonitorClient) Find(ctx context.Context) ([]*iaas.SimpleMonitor, error) {
+	return d.find, d.findErr
+}
+
+func (d *dummySimpleMonitorClient) HealthStatus(ctx context.Context, id types.ID) (*iaas.SimpleMonitorHealthStatus, error) {
+	return d.health, d.healthErr
+}
+
+func (d *dummySimpleMonitorClient) MonitorResponseTime(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorResponseTimeSecValue, error) {
+	return d.responseTime, d.responseTimeErr
+}
+
+func TestSimpleMonitorCollector_Describe(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewSimpleMonitorCollector(context.Background(), testLogger, testErrors, Options{}, &dummySimpleMonitorClient{}, 0)
+
+	descs := collectDescs(c)
+	require.Len(t, descs, len([]*prometheus.Desc{
+		c.Up,
+		c.SimpleMonitorInfo,
+		c.Latency,
+		c.AvailabilityRatio,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
+	}))
+}
+
+func TestSimpleMonitorCollector_Collect(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewSimpleMonitorCollector(context.Background(), testLogger, testErrors, Options{}, nil, 0)
+	monitorTime := time.Unix(1, 0)
+
+	simpleMonitor := &iaas.SimpleMonitor{
+		ID:          101,
+		Name:        "example.com",
+		Tags:        types.Tags{"tag1", "tag2"},
+		Description: "desc",
+		Target:      "example.com",
+		Enabled:     types.StringTrue,
+		HealthCheck: &iaas.SimpleMonitorHealthCheck{
+			Protocol: types.SimpleMonitorProtocols.HTTPS,
+		},
+	}
+	simpleMonitorLabels := map[string]string{
+		"id":     "101",
+		"name":   "example.com",
+		"target": "example.com",
+	}
+	infoLabels := map[string]string{
+		"id":          "101",
+		"name":        "example.com",
+		"target":      "example.com",
+		"protocol":    "https",
+		"enabled":     "1",
+		"tags":        ",tag1,tag2,",
+		"description": "desc",
+	}
+
+	cases := []struct {
+		name           string
+		in             platform.SimpleMonitorClient
+		wantLogs       []string
+		wantErrCounter float64
+		wantMetrics    []*collectedMetric
+	}{
+		{
+			name: "collector returns error",
+			in: &dummySimpleMonitorClient{
+				findErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't list simple monitors" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics:    nil,
+		},
+		{
+			name:        "empty result",
+			in:          &dummySimpleMonitorClient{},
+			wantMetrics: nil,
+		},
+		{
+			name: "a simple monitor",
+			in: &dummySimpleMonitorClient{
+				find: []*iaas.SimpleMonitor{simpleMonitor},
+				health: &iaas.SimpleMonitorHealthStatus{
+					Health: types.SimpleMonitorHealth.Up,
+				},
+				responseTime: &iaas.MonitorResponseTimeSecValue{
+					Time:            monitorTime,
+					ResponseTimeSec: 0.25,
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc:   c.SimpleMonitorInfo,
+					metric: createGaugeMetric(1, infoLabels),
+				},
+				{
+					desc:   c.Up,
+					metric: createGaugeMetric(1, simpleMonitorLabels),
+				},
+				{
+					desc:   c.Latency,
+					metric: createGaugeWithTimestamp(0.25, simpleMonitorLabels, monitorTime),
+				},
+			},
+		},
+		{
+			name: "a down simple monitor",
+			in: &dummySimpleMonitorClient{
+				find: []*iaas.SimpleMonitor{simpleMonitor},
+				health: &iaas.SimpleMonitorHealthStatus{
+					Health: types.SimpleMonitorHealth.Down,
+				},
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc:   c.SimpleMonitorInfo,
+					metric: createGaugeMetric(1, infoLabels),
+				},
+				{
+					desc:   c.Up,
+					metric: createGaugeMetric(0, simpleMonitorLabels),
+				},
+			},
+		},
+		{
+			name: "a disabled simple monitor",
+			in: &dummySimpleMonitorClient{
+				find: []*iaas.SimpleMonitor{
+					{
+						ID:     101,
+						Name:   "example.com",
+						Target: "example.com",
+						HealthCheck: &iaas.SimpleMonitorHealthCheck{
+							Protocol: types.SimpleMonitorProtocols.Ping,
+						},
+					},
+				},
+				healthErr: errors.New("dummy"),
+			},
+			wantMetrics: []*collectedMetric{
+				{
+					desc: c.SimpleMonitorInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":          "101",
+						"name":        "example.com",
+						"target":      "example.com",
+						"protocol":    "ping",
+						"enabled":     "0",
+						"tags":        "",
+						"description": "",
+					}),
+				},
+			},
+		},
+		{
+			name: "health status API returns error",
+			in: &dummySimpleMonitorClient{
+				find:      []*iaas.SimpleMonitor{simpleMonitor},
+				healthErr: errors.New("dummy"),
+				responseTime: &iaas.MonitorResponseTimeSecValue{
+					Time:            monitorTime,
+					ResponseTimeSec: 0.25,
+				},
+			},
+			wantLogs:       []string{`level=WARN msg="can't get simple monitor's health status: SimpleMonitorID=101" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics: []*collectedMetric{
+				{
+					desc:   c.SimpleMonitorInfo,
+					metric: createGaugeMetric(1, infoLabels),
+				},
+				{
+					desc:   c.Latency,
+					metric: createGaugeWithTimestamp(0.25, simpleMonitorLabels, monitorTime),
+				},
+			},
+		},
+		{
+			name: "response time API returns error",
+			in: &dummySimpleMonitorClient{
+				find: []*iaas.SimpleMonitor{simpleMonitor},
+				health: &iaas.SimpleMonitorHealthStatus{
+					Health: types.SimpleMonitorHealth.Up,
+				},
+				responseTimeErr: errors.New("dummy"),
+			},
+			wantLogs:       []string{`level=WARN msg="can't get simple monitor's response time: SimpleMonitorID=101" err=dummy`},
+			wantErrCounter: 1,
+			wantMetrics: []*collectedMetric{
+				{
+					desc:   c.SimpleMonitorInfo,
+					metric: createGaugeMetric(1, infoLabels),
+				},
+				{
+					desc:   c.Up,
+					metric: createGaugeMetric(1, simpleMonitorLabels),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c.logger = testLogger
+		c.errors = testErrors
+		c.client = tc.in
+
+		collected, err := collectMetrics(c, "simple_monitor")
+		require.NoError(t, err)
+		require.Equal(t, tc.wantLogs, collected.logged)
+		require.Equal(t, tc.wantErrCounter, *collected.errors.Counter.Value)
+		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
+	}
+}
+
+func TestSimpleMonitorCollector_AvailabilityRatio(t *testing.T) {
+	simpleMonitor := &iaas.SimpleMonitor{
+		ID:      101,
+		Name:    "example.com",
+		Target:  "example.com",
+		Enabled: types.StringTrue,
+	}
+	client := &dummySimpleMonitorClient{
+		find: []*iaas.SimpleMonitor{simpleMonitor},
+	}
+
+	initLoggerAndErrors()
+	c := NewSimpleMonitorCollector(context.Background(), testLogger, testErrors, Options{}, client, time.Hour)
+
+	// the history is kept across Collects: up, down, up, up
+	healths := []types.ESimpleMonitorHealth{
+		types.SimpleMonitorHealth.Up,
+		types.SimpleMonitorHealth.Down,
+		types.SimpleMonitorHealth.Up,
+		types.SimpleMonitorHealth.Up,
+	}
+	wantRatios := []float64{1, 0.5, 2.0 / 3.0, 0.75}
+	for i, health := range healths {
+		client.health = &iaas.SimpleMonitorHealthStatus{Health: health}
+
+		collected, err := collectMetrics(c, "simple_monitor")
+		require.NoError(t, err)
+
+		ratios := collected.filter(c.AvailabilityRatio)
+		requireMetricsEqual(t, []*collectedMetric{
+			{
+				desc: c.AvailabilityRatio,
+				metric: createGaugeMetric(wantRatios[i], map[string]string{
+					"id":     "101",
+					"name":   "example.com",
+					"target": "example.com",
+					"window": "1h",
+				}),
+			},
+		}, ratios)
+	}
+
+	// the history of the removed simple monitors is dropped
+	client.find = nil
+	_, err := collectMetrics(c, "simple_monitor")
+	require.NoError(t, err)
+	require.Empty(t, c.availabilities.history)
+}
+
+func TestSimpleMonitorAvailabilityTracker_Window(t *testing.T) {
+	tracker := newSimpleMonitorAvailabilityTracker()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.Equal(t, 0.0, tracker.observe(101, false, now, time.Hour))
+	require.Equal(t, 0.5, tracker.observe(101, true, now.Add(30*time.Minute), time.Hour))
+	// the first down sample goes out of the window
+	require.Equal(t, 1.0, tracker.observe(101, true, now.Add(time.Hour), time.Hour))
+	require.Equal(t, 2.0/3.0, tracker.observe(101, false, now.Add(80*time.Minute), time.Hour))
+
+	// simple monitors are tracked separately
+	require.Equal(t, 1.0, tracker.observe(102, true, now.Add(80*time.Minute), time.Hour))
+}
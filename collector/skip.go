@@ -0,0 +1,117 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricNamePrefix = "sakuracloud_"
+
+// SkipMetricsCollector wraps a collector and drops the metric families listed in skip-set
+type SkipMetricsCollector struct {
+	collector prometheus.Collector
+	skip      map[*prometheus.Desc]struct{}
+}
+
+// NewSkipMetricsCollector returns a new SkipMetricsCollector.
+//
+// Each name can be a comma separated list. A name which doesn't match any metric of the wrapped collector
+// is resolved with the "sakuracloud_" prefix, so that the prefix can be omitted.
+// The names are resolved to the descs of the wrapped collector here, so that Collect only compares the desc of each metric.
+func NewSkipMetricsCollector(collector prometheus.Collector, names []string) *SkipMetricsCollector {
+	var skipNames []string
+	for _, v := range names {
+		for _, name := range strings.Split(v, ",") {
+			name = strings.TrimSpace(name)
+			if name != "" {
+				skipNames = append(skipNames, name)
+			}
+		}
+	}
+
+	skip := make(map[*prometheus.Desc]struct{})
+	if len(skipNames) > 0 {
+		descsByName := make(map[string][]*prometheus.Desc)
+		descs := make(chan *prometheus.Desc)
+		go func() {
+			collector.Describe(descs)
+			close(descs)
+		}()
+		for desc := range descs {
+			name := descFQName(desc)
+			descsByName[name] = append(descsByName[name], desc)
+		}
+
+		for _, name := range skipNames {
+			matched, ok := descsByName[name]
+			if !ok {
+				matched = descsByName[metricNamePrefix+name]
+			}
+			for _, desc := range matched {
+				skip[desc] = struct{}{}
+			}
+		}
+	}
+	return &SkipMetricsCollector{
+		collector: collector,
+		skip:      skip,
+	}
+}
+
+// Describe sends metric descriptors of the wrapped collector to the prometheus desc channel.
+func (c *SkipMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+// Collect receives metrics from the wrapped collector and sends them except skipped families.
+func (c *SkipMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.collect(ch, c.collector.Collect)
+}
+
+// CollectWithContext is the same as Collect, but collects the wrapped collector with ctx.
+func (c *SkipMetricsCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	c.collect(ch, collectFunc(ctx, c.collector))
+}
+
+func (c *SkipMetricsCollector) collect(ch chan<- prometheus.Metric, collect func(chan<- prometheus.Metric)) {
+	if len(c.skip) == 0 {
+		collect(ch)
+		return
+	}
+
+	metrics := make(chan prometheus.Metric)
+	go func() {
+		collect(metrics)
+		close(metrics)
+	}()
+
+	for m := range metrics {
+		if _, ok := c.skip[m.Desc()]; ok {
+			continue
+		}
+		ch <- m
+	}
+}
+
+// Reset resets the state of the wrapped collector if it is supported
+func (c *SkipMetricsCollector) Reset() {
+	if r, ok := c.collector.(resetter); ok {
+		r.Reset()
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package collector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/webaccel-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkipMetricsCollector_Collect(t *testing.T) {
+	client := &dummyZoneClient{
+		zones: []*iaas.Zone{
+			{
+				ID:          1,
+				Name:        "zone",
+				Description: "desc",
+				Region: &iaas.Region{
+					ID:   2,
+					Name: "region",
+				},
+			},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		skip      []string
+		wantCount int
+	}{
+		{
+			name:      "no skip",
+			skip:      nil,
+			wantCount: 1,
+		},
+		{
+			name:      "skip other family",
+			skip:      []string{"server_nic_receive,server_nic_send"},
+			wantCount: 1,
+		},
+		{
+			name:      "skip without prefix",
+			skip:      []string{"server_nic_receive,zone_info"},
+			wantCount: 0,
+		},
+		{
+			name:      "skip with prefix",
+			skip:      []string{"sakuracloud_zone_info"},
+			wantCount: 0,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewSkipMetricsCollector(NewZoneCollector(context.Background(), testLogger, testErrors, Options{}, client), tc.skip)
+
+		require.Len(t, collectDescs(c), 1, tc.name)
+
+		collected, err := collectMetrics(c, "zone")
+		require.NoError(t, err)
+		require.Len(t, collected.collected, tc.wantCount, tc.name)
+	}
+}
+
+func TestSkipMetricsCollector_WebAccel(t *testing.T) {
+	client := &dummyWebAccelClient{
+		sites: []*webaccel.Site{
+			{
+				ID:         "100000000001",
+				Name:       "site",
+				DomainType: "subdomain",
+				Subdomain:  "site.user.webaccel.jp",
+			},
+		},
+		usage: &webaccel.MonthlyUsageResults{},
+	}
+
+	cases := []struct {
+		name      string
+		skip      []string
+		wantCount int
+	}{
+		{
+			name:      "no skip",
+			skip:      nil,
+			wantCount: 1,
+		},
+		{
+			name:      "skip a webaccel metric",
+			skip:      []string{"webaccel_site_info"},
+			wantCount: 0,
+		},
+		{
+			name:      "skip a sakuracloud metric of the same suffix",
+			skip:      []string{"site_info"},
+			wantCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		webAccel := NewWebAccelCollector(context.Background(), testLogger, testErrors, Options{}, client)
+		c := NewSkipMetricsCollector(webAccel, tc.skip)
+
+		collected, err := collectMetrics(c, "webaccel")
+		require.NoError(t, err)
+		require.Len(t, collected.filter(webAccel.SiteInfo), tc.wantCount, tc.name)
+	}
+}
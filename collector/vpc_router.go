@@ -34,18 +34,31 @@ type VPCRouterCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.VPCRouterClient
 
-	Up            *prometheus.Desc
-	SessionCount  *prometheus.Desc
-	VPCRouterInfo *prometheus.Desc
-	Receive       *prometheus.Desc
-	Send          *prometheus.Desc
+	sessionDetails bool
+
+	Up              *prometheus.Desc
+	SessionCount    *prometheus.Desc
+	SessionHeadroom *prometheus.Desc
+	VPCRouterInfo   *prometheus.Desc
+	SoftwareInfo    *prometheus.Desc
+	VRID            *prometheus.Desc
+	Receive         *prometheus.Desc
+	Send            *prometheus.Desc
+	NICBandwidth    *prometheus.Desc
+
+	StaticRouteCount    *prometheus.Desc
+	PortForwardingCount *prometheus.Desc
+	FirewallRuleCount   *prometheus.Desc
 
 	CPUTime              *prometheus.Desc
 	DHCPLeaseCount       *prometheus.Desc
 	L2TPSessionCount     *prometheus.Desc
 	PPTPSessionCount     *prometheus.Desc
+	L2TPSessionInfo      *prometheus.Desc
+	PPTPSessionInfo      *prometheus.Desc
 	SiteToSitePeerStatus *prometheus.Desc
 
 	SessionAnalysis *prometheus.Desc
@@ -54,10 +67,20 @@ type VPCRouterCollector struct {
 	MaintenanceInfo      *prometheus.Desc
 	MaintenanceStartTime *prometheus.Desc
 	MaintenanceEndTime   *prometheus.Desc
+
+	Unnamed         *prometheus.Desc
+	RecentlyCreated *prometheus.Desc
+	CountByTag      *prometheus.Desc
 }
 
+// maxVPCRouterSessionDetails is the maximum number of sessions reported per VPCRouter and per protocol
+// to bound the cardinality of sakuracloud_vpc_router_l2tp_session_info/pptp_session_info.
+const maxVPCRouterSessionDetails = 100
+
 // NewVPCRouterCollector returns a new VPCRouterCollector.
-func NewVPCRouterCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.VPCRouterClient) *VPCRouterCollector {
+//
+// If sessionDetails is true, the user and IP address of each L2TP/IPsec and PPTP session are reported.
+func NewVPCRouterCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.VPCRouterClient, sessionDetails bool) *VPCRouterCollector {
 	errors.WithLabelValues("vpc_router").Add(0)
 
 	vpcRouterLabels := []string{"id", "name", "zone"}
@@ -65,87 +88,140 @@ func NewVPCRouterCollector(ctx context.Context, logger *slog.Logger, errors *pro
 	nicLabels := append(vpcRouterLabels, "nic_index", "vip", "ipaddress1", "ipaddress2", "nw_mask_len")
 	s2sPeerLabels := append(vpcRouterLabels, "peer_address", "peer_index")
 	sessionAnalysisLabels := append(vpcRouterLabels, "type", "label")
+	sessionInfoLabels := append(vpcRouterLabels, "user", "ipaddress")
 
 	return &VPCRouterCollector{
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		Up: prometheus.NewDesc(
+
+		sessionDetails: sessionDetails,
+
+		Up: opts.newDesc(
 			"sakuracloud_vpc_router_up",
 			"If 1 the vpc_router is up and running, 0 otherwise",
 			vpcRouterLabels, nil,
 		),
-		SessionCount: prometheus.NewDesc(
+		SessionCount: opts.newDesc(
 			"sakuracloud_vpc_router_session",
 			"Current session count",
 			vpcRouterLabels, nil,
 		),
-		VPCRouterInfo: prometheus.NewDesc(
+		SessionHeadroom: opts.newDesc(
+			"sakuracloud_vpc_router_session_headroom_ratio",
+			"Ratio of the remaining sessions to the session limit of the plan",
+			vpcRouterLabels, nil,
+		),
+		VPCRouterInfo: opts.newDesc(
 			"sakuracloud_vpc_router_info",
 			"A metric with a constant '1' value labeled by vpc_router information",
-			vpcRouterInfoLabels, nil,
+			opts.withRegionLabel(vpcRouterInfoLabels), nil,
+		),
+		SoftwareInfo: opts.newDesc(
+			"sakuracloud_vpc_router_software_info",
+			"A metric with a constant '1' value labeled by the version of the vpc_router's software",
+			append(vpcRouterLabels, "version"), nil,
 		),
-		CPUTime: prometheus.NewDesc(
+		VRID: opts.newDesc(
+			"sakuracloud_vpc_router_vrid",
+			"A metric with a constant '1' value labeled by VRID and the connected switch",
+			append(vpcRouterLabels, "vrid", "switch_id"), nil,
+		),
+		CPUTime: opts.newDesc(
 			"sakuracloud_vpc_router_cpu_time",
 			"VPCRouter's CPU time(unit: ms)",
 			vpcRouterLabels, nil,
 		),
-		DHCPLeaseCount: prometheus.NewDesc(
+		DHCPLeaseCount: opts.newDesc(
 			"sakuracloud_vpc_router_dhcp_lease",
 			"Current DHCPServer lease count",
 			vpcRouterLabels, nil,
 		),
-		L2TPSessionCount: prometheus.NewDesc(
+		L2TPSessionCount: opts.newDesc(
 			"sakuracloud_vpc_router_l2tp_session",
 			"Current L2TP-IPsec session count",
 			vpcRouterLabels, nil,
 		),
-		PPTPSessionCount: prometheus.NewDesc(
+		PPTPSessionCount: opts.newDesc(
 			"sakuracloud_vpc_router_pptp_session",
 			"Current PPTP session count",
 			vpcRouterLabels, nil,
 		),
-		SiteToSitePeerStatus: prometheus.NewDesc(
+		L2TPSessionInfo: opts.newDesc(
+			"sakuracloud_vpc_router_l2tp_session_info",
+			"A metric with a constant '1' value labeled by user and IP address of the L2TP-IPsec session",
+			sessionInfoLabels, nil,
+		),
+		PPTPSessionInfo: opts.newDesc(
+			"sakuracloud_vpc_router_pptp_session_info",
+			"A metric with a constant '1' value labeled by user and IP address of the PPTP session",
+			sessionInfoLabels, nil,
+		),
+		SiteToSitePeerStatus: opts.newDesc(
 			"sakuracloud_vpc_router_s2s_peer_up",
 			"If 1 the vpc_router's site to site peer is up, 0 otherwise",
 			s2sPeerLabels, nil,
 		),
-		Receive: prometheus.NewDesc(
+		Receive: opts.newNetworkTrafficDesc(
 			"sakuracloud_vpc_router_receive",
-			"VPCRouter's receive bytes(unit: Kbps)",
+			"VPCRouter's receive traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		Send: prometheus.NewDesc(
+		Send: opts.newNetworkTrafficDesc(
 			"sakuracloud_vpc_router_send",
-			"VPCRouter's receive bytes(unit: Kbps)",
+			"VPCRouter's send traffic(unit: %s)",
 			nicLabels, nil,
 		),
-		SessionAnalysis: prometheus.NewDesc(
+		NICBandwidth: opts.newDesc(
+			"sakuracloud_vpc_router_nic_bandwidth",
+			"NIC's Bandwidth depending on the plan(unit: Mbps)",
+			nicLabels, nil,
+		),
+		StaticRouteCount: opts.newDesc(
+			"sakuracloud_vpc_router_static_route_count",
+			"The number of static routes",
+			vpcRouterLabels, nil,
+		),
+		PortForwardingCount: opts.newDesc(
+			"sakuracloud_vpc_router_port_forwarding_count",
+			"The number of port forwarding rules",
+			vpcRouterLabels, nil,
+		),
+		FirewallRuleCount: opts.newDesc(
+			"sakuracloud_vpc_router_firewall_rule_count",
+			"The number of firewall rules of all interfaces per direction",
+			append(vpcRouterLabels, "direction"), nil,
+		),
+		SessionAnalysis: opts.newDesc(
 			"sakuracloud_vpc_router_session_analysis",
 			"Session statistics for VPC routers",
 			sessionAnalysisLabels, nil,
 		),
-		MaintenanceScheduled: prometheus.NewDesc(
+		MaintenanceScheduled: opts.newDesc(
 			"sakuracloud_vpc_router_maintenance_scheduled",
 			"If 1 the vpc router has scheduled maintenance info, 0 otherwise",
 			vpcRouterLabels, nil,
 		),
-		MaintenanceInfo: prometheus.NewDesc(
+		MaintenanceInfo: opts.newDesc(
 			"sakuracloud_vpc_router_maintenance_info",
 			"A metric with a constant '1' value labeled by maintenance information",
 			append(vpcRouterLabels, "info_url", "info_title", "description", "start_date", "end_date"), nil,
 		),
-		MaintenanceStartTime: prometheus.NewDesc(
+		MaintenanceStartTime: opts.newDesc(
 			"sakuracloud_vpc_router_maintenance_start",
 			"Scheduled maintenance start time in seconds since epoch (1970)",
 			vpcRouterLabels, nil,
 		),
-		MaintenanceEndTime: prometheus.NewDesc(
+		MaintenanceEndTime: opts.newDesc(
 			"sakuracloud_vpc_router_maintenance_end",
 			"Scheduled maintenance end time in seconds since epoch (1970)",
 			vpcRouterLabels, nil,
 		),
+		Unnamed:         opts.newResourceUnnamedDesc("vpc_router"),
+		RecentlyCreated: opts.newResourceRecentlyCreatedDesc("vpc_router"),
+		CountByTag:      opts.newResourceCountByTagDesc("vpc_router"),
 	}
 }
 
@@ -154,25 +230,42 @@ func NewVPCRouterCollector(ctx context.Context, logger *slog.Logger, errors *pro
 func (c *VPCRouterCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.Up
 	ch <- c.VPCRouterInfo
+	ch <- c.SoftwareInfo
+	ch <- c.VRID
 	ch <- c.CPUTime
 	ch <- c.SessionCount
+	ch <- c.SessionHeadroom
 	ch <- c.DHCPLeaseCount
 	ch <- c.L2TPSessionCount
 	ch <- c.PPTPSessionCount
+	ch <- c.L2TPSessionInfo
+	ch <- c.PPTPSessionInfo
 	ch <- c.SiteToSitePeerStatus
 	ch <- c.Receive
 	ch <- c.Send
+	ch <- c.NICBandwidth
+	ch <- c.StaticRouteCount
+	ch <- c.PortForwardingCount
+	ch <- c.FirewallRuleCount
 	ch <- c.SessionAnalysis
 
 	ch <- c.MaintenanceScheduled
 	ch <- c.MaintenanceInfo
 	ch <- c.MaintenanceStartTime
 	ch <- c.MaintenanceEndTime
+	ch <- c.Unnamed
+	ch <- c.RecentlyCreated
+	ch <- c.CountByTag
 }
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
-	vpcRouters, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *VPCRouterCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	vpcRouters, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("vpc_router").Add(1)
 		c.logger.Warn(
@@ -181,6 +274,14 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	tags := make([]types.Tags, 0, len(vpcRouters))
+	for _, vpcRouter := range vpcRouters {
+		tags = append(tags, vpcRouter.Tags)
+	}
+	c.opts.collectResourceCountByTag(ch, c.CountByTag, tags)
+
+	c.opts.warnGoroutines(c.logger, "vpc_router", estimateVPCRouterGoroutines(vpcRouters))
+
 	var wg sync.WaitGroup
 	wg.Add(len(vpcRouters))
 
@@ -189,6 +290,8 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 			defer wg.Done()
 
 			vpcRouterLabels := c.vpcRouterLabels(vpcRouter)
+			collectResourceUnnamed(ch, c.Unnamed, vpcRouter.ID, vpcRouter.ZoneName, vpcRouter.Name)
+			c.opts.collectResourceRecentlyCreated(ch, c.RecentlyCreated, vpcRouter.ID, vpcRouter.ZoneName, vpcRouter.CreatedAt)
 
 			var up float64
 			if vpcRouter.InstanceStatus.IsUp() {
@@ -204,8 +307,12 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 				c.VPCRouterInfo,
 				prometheus.GaugeValue,
 				float64(1.0),
-				c.vpcRouterInfoLabels(vpcRouter)...,
+				c.opts.withRegionLabelValue(c.vpcRouterInfoLabels(vpcRouter), vpcRouter.ZoneName)...,
 			)
+			c.collectSoftwareInfo(ch, vpcRouter)
+			c.collectVRID(ch, vpcRouter)
+			c.collectNICBandwidth(ch, vpcRouter)
+			c.collectRuleCount(ch, vpcRouter)
 
 			if vpcRouter.Availability.IsAvailable() && vpcRouter.InstanceStatus.IsUp() {
 				// collect metrics per resources under server
@@ -213,7 +320,7 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 				// CPU-TIME
 				wg.Add(1)
 				go func() {
-					c.collectCPUTime(ch, vpcRouter, now)
+					c.collectCPUTime(ctx, ch, vpcRouter, now)
 					wg.Done()
 				}()
 
@@ -221,7 +328,7 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 					wg.Add(1)
 					go func() {
 						defer wg.Done()
-						status, err := c.client.Status(c.ctx, vpcRouter.ZoneName, vpcRouter.ID)
+						status, err := c.client.Status(ctx, vpcRouter.ZoneName, vpcRouter.ID)
 						if err != nil {
 							c.errors.WithLabelValues("vpc_router").Add(1)
 							c.logger.Warn(
@@ -241,6 +348,7 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 							float64(status.SessionCount),
 							c.vpcRouterLabels(vpcRouter)...,
 						)
+						c.collectSessionHeadroom(ch, vpcRouter, status)
 						// DHCP Server Leases
 						ch <- prometheus.MustNewConstMetric(
 							c.DHCPLeaseCount,
@@ -262,6 +370,9 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 							float64(len(status.PPTPServerSessions)),
 							c.vpcRouterLabels(vpcRouter)...,
 						)
+						if c.sessionDetails {
+							c.collectSessionDetails(ch, vpcRouter, status)
+						}
 						// Site to Site Peer
 						for i, peer := range status.SiteToSiteIPsecVPNPeers {
 							up := float64(0)
@@ -306,7 +417,7 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 						// NIC(Receive/Send)
 						wg.Add(1)
 						go func(nic *iaas.VPCRouterInterface) {
-							c.collectNICMetrics(ch, vpcRouter, nic.Index, now)
+							c.collectNICMetrics(ctx, ch, vpcRouter, nic.Index, now)
 							wg.Done()
 						}(nic)
 					}
@@ -335,10 +446,20 @@ func (c *VPCRouterCollector) Collect(ch chan<- prometheus.Metric) {
 	wg.Wait()
 }
 
+// estimateVPCRouterGoroutines returns the upper bound of the number of goroutines spawned per Collect:
+// cpu-time, status and maintenance info for each router and NIC metrics for each NIC
+func estimateVPCRouterGoroutines(vpcRouters []*platform.VPCRouter) int {
+	n := 0
+	for _, vpcRouter := range vpcRouters {
+		n += len(vpcRouter.Interfaces) + 3
+	}
+	return n
+}
+
 func (c *VPCRouterCollector) vpcRouterLabels(vpcRouter *platform.VPCRouter) []string {
 	return []string{
 		vpcRouter.ID.String(),
-		vpcRouter.Name,
+		c.opts.truncateLabelValue(vpcRouter.Name),
 		vpcRouter.ZoneName,
 	}
 }
@@ -349,6 +470,22 @@ var vpcRouterPlanMapping = map[types.ID]string{
 	types.VPCRouterPlans.HighSpec: "highspec",
 }
 
+// vpcRouterPlanBandwidth is the maximum throughput of each plan(unit: Mbps)
+var vpcRouterPlanBandwidth = map[types.ID]float64{
+	types.VPCRouterPlans.Standard:     80,
+	types.VPCRouterPlans.Premium:      400,
+	types.VPCRouterPlans.HighSpec:     1600,
+	types.VPCRouterPlans.HighSpec4000: 4000,
+}
+
+// vpcRouterPlanSessionLimit is the maximum number of sessions of each plan
+var vpcRouterPlanSessionLimit = map[types.ID]float64{
+	types.VPCRouterPlans.Standard:     20000,
+	types.VPCRouterPlans.Premium:      100000,
+	types.VPCRouterPlans.HighSpec:     400000,
+	types.VPCRouterPlans.HighSpec4000: 1000000,
+}
+
 func (c *VPCRouterCollector) vpcRouterInfoLabels(vpcRouter *platform.VPCRouter) []string {
 	labels := c.vpcRouterLabels(vpcRouter)
 
@@ -390,11 +527,34 @@ func (c *VPCRouterCollector) vpcRouterInfoLabels(vpcRouter *platform.VPCRouter)
 		ipaddress2,
 		nwMaskLen,
 		internetConn,
-		flattenStringSlice(vpcRouter.Tags),
-		vpcRouter.Description,
+		c.opts.truncateLabelValue(flattenStringSlice(vpcRouter.Tags)),
+		c.opts.truncateLabelValue(vpcRouter.Description),
 	)
 }
 
+func (c *VPCRouterCollector) collectVRID(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter) {
+	// VRRP is used only by the redundant plans
+	if vpcRouter.PlanID == types.VPCRouterPlans.Standard || vpcRouter.Settings == nil || vpcRouter.Settings.VRID < 0 {
+		return
+	}
+
+	for _, nic := range vpcRouter.Interfaces {
+		if nic.SwitchID.IsEmpty() {
+			continue
+		}
+		labels := append(c.vpcRouterLabels(vpcRouter),
+			fmt.Sprintf("%d", vpcRouter.Settings.VRID),
+			nic.SwitchID.String(),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			c.VRID,
+			prometheus.GaugeValue,
+			float64(1.0),
+			labels...,
+		)
+	}
+}
+
 func findVPCRouterInterfaceSettingByIndex(settings []*iaas.VPCRouterInterfaceSetting, index int) *iaas.VPCRouterInterfaceSetting {
 	for _, s := range settings {
 		if s.Index == index {
@@ -442,8 +602,124 @@ func (c *VPCRouterCollector) nicLabels(vpcRouter *platform.VPCRouter, index int)
 	)
 }
 
-func (c *VPCRouterCollector) collectNICMetrics(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, index int, now time.Time) {
-	values, err := c.client.MonitorNIC(c.ctx, vpcRouter.ZoneName, vpcRouter.ID, index, now)
+func (c *VPCRouterCollector) collectNICBandwidth(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter) {
+	bandwidth, ok := vpcRouterPlanBandwidth[vpcRouter.PlanID]
+	if !ok || vpcRouter.Settings == nil {
+		return
+	}
+	for _, nic := range vpcRouter.Interfaces {
+		labels := c.nicLabels(vpcRouter, nic.Index)
+		if labels == nil {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.NICBandwidth,
+			prometheus.GaugeValue,
+			bandwidth,
+			labels...,
+		)
+	}
+}
+
+// collectSessionHeadroom collects the ratio of the remaining sessions to the session limit of the plan.
+// Nothing is collected if the session limit of the plan is unknown, and the ratio is 0 if the limit is exceeded.
+func (c *VPCRouterCollector) collectSessionHeadroom(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, status *iaas.VPCRouterStatus) {
+	limit, ok := vpcRouterPlanSessionLimit[vpcRouter.PlanID]
+	if !ok || limit <= 0 {
+		return
+	}
+	headroom := (limit - float64(status.SessionCount)) / limit
+	if headroom < 0 {
+		headroom = 0
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.SessionHeadroom,
+		prometheus.GaugeValue,
+		headroom,
+		c.vpcRouterLabels(vpcRouter)...,
+	)
+}
+
+func (c *VPCRouterCollector) collectSessionDetails(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, status *iaas.VPCRouterStatus) {
+	for i, session := range status.L2TPIPsecServerSessions {
+		if i >= maxVPCRouterSessionDetails {
+			break
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.L2TPSessionInfo,
+			prometheus.GaugeValue,
+			float64(1.0),
+			append(c.vpcRouterLabels(vpcRouter), session.User, session.IPAddress)...,
+		)
+	}
+	for i, session := range status.PPTPServerSessions {
+		if i >= maxVPCRouterSessionDetails {
+			break
+		}
+		ch <- prometheus.MustNewConstMetric(
+			c.PPTPSessionInfo,
+			prometheus.GaugeValue,
+			float64(1.0),
+			append(c.vpcRouterLabels(vpcRouter), session.User, session.IPAddress)...,
+		)
+	}
+}
+
+// collectSoftwareInfo collects the version of the vpc_router's software.
+// The version isn't reported by the API for some vpc_routers, so nothing is collected if it is missing.
+func (c *VPCRouterCollector) collectSoftwareInfo(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter) {
+	if vpcRouter.Version <= 0 {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.SoftwareInfo,
+		prometheus.GaugeValue,
+		float64(1.0),
+		append(c.vpcRouterLabels(vpcRouter), fmt.Sprintf("%d", vpcRouter.Version))...,
+	)
+}
+
+func (c *VPCRouterCollector) collectRuleCount(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter) {
+	if vpcRouter.Settings == nil {
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.StaticRouteCount,
+		prometheus.GaugeValue,
+		float64(len(vpcRouter.Settings.StaticRoute)),
+		c.vpcRouterLabels(vpcRouter)...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.PortForwardingCount,
+		prometheus.GaugeValue,
+		float64(len(vpcRouter.Settings.PortForwarding)),
+		c.vpcRouterLabels(vpcRouter)...,
+	)
+
+	var send, receive int
+	for _, firewall := range vpcRouter.Settings.Firewall {
+		if firewall == nil {
+			continue
+		}
+		send += len(firewall.Send)
+		receive += len(firewall.Receive)
+	}
+	ch <- prometheus.MustNewConstMetric(
+		c.FirewallRuleCount,
+		prometheus.GaugeValue,
+		float64(send),
+		append(c.vpcRouterLabels(vpcRouter), "send")...,
+	)
+	ch <- prometheus.MustNewConstMetric(
+		c.FirewallRuleCount,
+		prometheus.GaugeValue,
+		float64(receive),
+		append(c.vpcRouterLabels(vpcRouter), "receive")...,
+	)
+}
+
+func (c *VPCRouterCollector) collectNICMetrics(ctx context.Context, ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, index int, now time.Time) {
+	values, err := c.client.MonitorNIC(ctx, vpcRouter.ZoneName, vpcRouter.ID, index, now)
 	if err != nil {
 		c.errors.WithLabelValues("vpc_router").Add(1)
 		c.logger.Warn(
@@ -453,12 +729,15 @@ func (c *VPCRouterCollector) collectNICMetrics(ch chan<- prometheus.Metric, vpcR
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorInterfaceValue{Time: now}
 	}
 
 	receive := values.Receive
 	if receive > 0 {
-		receive = receive * 8 / 1000
+		receive = c.opts.convertNetworkTraffic(receive)
 	}
 	m := prometheus.MustNewConstMetric(
 		c.Receive,
@@ -470,7 +749,7 @@ func (c *VPCRouterCollector) collectNICMetrics(ch chan<- prometheus.Metric, vpcR
 
 	send := values.Send
 	if send > 0 {
-		send = send * 8 / 1000
+		send = c.opts.convertNetworkTraffic(send)
 	}
 	m = prometheus.MustNewConstMetric(
 		c.Send,
@@ -481,8 +760,8 @@ func (c *VPCRouterCollector) collectNICMetrics(ch chan<- prometheus.Metric, vpcR
 	ch <- prometheus.NewMetricWithTimestamp(values.Time, m)
 }
 
-func (c *VPCRouterCollector) collectCPUTime(ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, now time.Time) {
-	values, err := c.client.MonitorCPU(c.ctx, vpcRouter.ZoneName, vpcRouter.ID, now)
+func (c *VPCRouterCollector) collectCPUTime(ctx context.Context, ch chan<- prometheus.Metric, vpcRouter *platform.VPCRouter, now time.Time) {
+	values, err := c.client.MonitorCPU(ctx, vpcRouter.ZoneName, vpcRouter.ID, now)
 	if err != nil {
 		c.errors.WithLabelValues("server").Add(1)
 		c.logger.Warn(
@@ -492,7 +771,10 @@ func (c *VPCRouterCollector) collectCPUTime(ch chan<- prometheus.Metric, vpcRout
 		return
 	}
 	if values == nil {
-		return
+		if !c.opts.EmitZeroOnNil {
+			return
+		}
+		values = &iaas.MonitorCPUTimeValue{Time: now}
 	}
 
 	m := prometheus.MustNewConstMetric(
@@ -511,7 +793,7 @@ func (c *VPCRouterCollector) maintenanceInfoLabels(resource *platform.VPCRouter,
 	return append(labels,
 		info.URL,
 		info.Title,
-		info.Description,
+		c.opts.truncateLabelValue(info.Description),
 		fmt.Sprintf("%d", info.EventStart().Unix()),
 		fmt.Sprintf("%d", info.EventEnd().Unix()),
 	)
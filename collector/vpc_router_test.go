@@ -60,31 +60,43 @@ func (d *dummyVPCRouterClient) MaintenanceInfo(infoURL string) (*newsfeed.FeedIt
 
 func TestVPCRouterCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, &dummyVPCRouterClient{})
+	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyVPCRouterClient{}, false)
 
 	descs := collectDescs(c)
 	require.Len(t, descs, len([]*prometheus.Desc{
 		c.Up,
 		c.VPCRouterInfo,
+		c.SoftwareInfo,
+		c.VRID,
 		c.CPUTime,
 		c.SessionCount,
+		c.SessionHeadroom,
 		c.DHCPLeaseCount,
 		c.L2TPSessionCount,
 		c.PPTPSessionCount,
+		c.L2TPSessionInfo,
+		c.PPTPSessionInfo,
 		c.SiteToSitePeerStatus,
 		c.Receive,
 		c.Send,
+		c.NICBandwidth,
+		c.StaticRouteCount,
+		c.PortForwardingCount,
+		c.FirewallRuleCount,
 		c.SessionAnalysis,
 		c.MaintenanceScheduled,
 		c.MaintenanceInfo,
 		c.MaintenanceStartTime,
 		c.MaintenanceEndTime,
+		c.Unnamed,
+		c.RecentlyCreated,
+		c.CountByTag,
 	}))
 }
 
 func TestVPCRouterCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, nil, false)
 	monitorTime := time.Unix(1, 0)
 
 	cases := []struct {
@@ -128,13 +140,23 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 									ID:    200,
 								},
 								{
-									Index: 1,
-									ID:    201,
+									Index:    1,
+									ID:       201,
+									SwitchID: 301,
 								},
 							},
 							Settings: &iaas.VPCRouterSetting{
 								VRID:                      1,
 								InternetConnectionEnabled: true,
+								StaticRoute: []*iaas.VPCRouterStaticRoute{
+									{Prefix: "172.16.10.0/24", NextHop: "192.168.1.101"},
+									{Prefix: "172.16.11.0/24", NextHop: "192.168.1.102"},
+								},
+								PortForwarding: []*iaas.VPCRouterPortForwarding{
+									{Protocol: types.VPCRouterPortForwardingProtocols.TCP, GlobalPort: 22, PrivateAddress: "192.168.1.201", PrivatePort: 22},
+									{Protocol: types.VPCRouterPortForwardingProtocols.TCP, GlobalPort: 80, PrivateAddress: "192.168.1.202", PrivatePort: 80},
+									{Protocol: types.VPCRouterPortForwardingProtocols.UDP, GlobalPort: 53, PrivateAddress: "192.168.1.203", PrivatePort: 53},
+								},
 								Interfaces: []*iaas.VPCRouterInterfaceSetting{
 									{
 										VirtualIPAddress: "192.168.0.1",
@@ -220,6 +242,76 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 						"description":         "desc",
 					}),
 				},
+				{
+					desc: c.StaticRouteCount,
+					metric: createGaugeMetric(2, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.PortForwardingCount,
+					metric: createGaugeMetric(3, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "send",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "receive",
+					}),
+				},
+				{
+					desc: c.NICBandwidth,
+					metric: createGaugeMetric(400, map[string]string{
+						"id":          "101",
+						"name":        "router",
+						"zone":        "is1a",
+						"nic_index":   "0",
+						"vip":         "192.168.0.1",
+						"ipaddress1":  "192.168.0.11",
+						"ipaddress2":  "192.168.0.12",
+						"nw_mask_len": "24",
+					}),
+				},
+				{
+					desc: c.NICBandwidth,
+					metric: createGaugeMetric(400, map[string]string{
+						"id":          "101",
+						"name":        "router",
+						"zone":        "is1a",
+						"nic_index":   "1",
+						"vip":         "192.168.1.1",
+						"ipaddress1":  "192.168.1.11",
+						"ipaddress2":  "192.168.1.12",
+						"nw_mask_len": "24",
+					}),
+				},
+				{
+					desc: c.VRID,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"vrid":      "1",
+						"switch_id": "301",
+					}),
+				},
 				{
 					desc: c.SessionCount,
 					metric: createGaugeMetric(100, map[string]string{
@@ -228,6 +320,14 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 						"zone": "is1a",
 					}),
 				},
+				{
+					desc: c.SessionHeadroom,
+					metric: createGaugeMetric(0.999, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
 				{
 					desc: c.DHCPLeaseCount,
 					metric: createGaugeMetric(1, map[string]string{
@@ -396,6 +496,53 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 						"description":         "desc",
 					}),
 				},
+				{
+					desc: c.StaticRouteCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.PortForwardingCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "send",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "receive",
+					}),
+				},
+				{
+					desc: c.NICBandwidth,
+					metric: createGaugeMetric(400, map[string]string{
+						"id":          "101",
+						"name":        "router",
+						"zone":        "is1a",
+						"nic_index":   "0",
+						"vip":         "192.168.0.1",
+						"ipaddress1":  "192.168.0.11",
+						"ipaddress2":  "192.168.0.12",
+						"nw_mask_len": "24",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(0, map[string]string{
@@ -493,6 +640,66 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 						"description":         "desc",
 					}),
 				},
+				{
+					desc: c.StaticRouteCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.PortForwardingCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":   "101",
+						"name": "router",
+						"zone": "is1a",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "send",
+					}),
+				},
+				{
+					desc: c.FirewallRuleCount,
+					metric: createGaugeMetric(0, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"direction": "receive",
+					}),
+				},
+				{
+					desc: c.NICBandwidth,
+					metric: createGaugeMetric(400, map[string]string{
+						"id":          "101",
+						"name":        "router",
+						"zone":        "is1a",
+						"nic_index":   "0",
+						"vip":         "192.168.0.1",
+						"ipaddress1":  "192.168.0.11",
+						"ipaddress2":  "192.168.0.12",
+						"nw_mask_len": "24",
+					}),
+				},
+				{
+					desc: c.NICBandwidth,
+					metric: createGaugeMetric(400, map[string]string{
+						"id":          "101",
+						"name":        "router",
+						"zone":        "is1a",
+						"nic_index":   "1",
+						"vip":         "192.168.1.1",
+						"ipaddress1":  "192.168.1.11",
+						"ipaddress2":  "192.168.1.12",
+						"nw_mask_len": "24",
+					}),
+				},
 				{
 					desc: c.MaintenanceScheduled,
 					metric: createGaugeMetric(1, map[string]string{
@@ -547,3 +754,309 @@ func TestVPCRouterCollector_Collect(t *testing.T) {
 		requireMetricsEqual(t, tc.wantMetrics, collected.collected)
 	}
 }
+
+func TestVPCRouterCollector_NICBandwidth(t *testing.T) {
+	cases := []struct {
+		plan types.ID
+		want float64
+	}{
+		{plan: types.VPCRouterPlans.Standard, want: 80},
+		{plan: types.VPCRouterPlans.Premium, want: 400},
+		{plan: types.VPCRouterPlans.HighSpec, want: 1600},
+		{plan: types.VPCRouterPlans.HighSpec4000, want: 4000},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyVPCRouterClient{
+			find: []*platform.VPCRouter{
+				{
+					ZoneName: "is1a",
+					VPCRouter: &iaas.VPCRouter{
+						ID:     101,
+						Name:   "router",
+						PlanID: tc.plan,
+						Interfaces: []*iaas.VPCRouterInterface{
+							{Index: 0, ID: 200},
+						},
+						Settings: &iaas.VPCRouterSetting{
+							Interfaces: []*iaas.VPCRouterInterfaceSetting{
+								{
+									IPAddress:      []string{"192.168.0.11"},
+									NetworkMaskLen: 24,
+									Index:          0,
+								},
+							},
+						},
+					},
+				},
+			},
+		}, false)
+
+		collected, err := collectMetrics(c, "vpc_router")
+		require.NoError(t, err)
+
+		got := collected.filter(c.NICBandwidth)
+		requireMetricsEqual(t, []*collectedMetric{
+			{
+				desc: c.NICBandwidth,
+				metric: createGaugeMetric(tc.want, map[string]string{
+					"id":          "101",
+					"name":        "router",
+					"zone":        "is1a",
+					"nic_index":   "0",
+					"vip":         "",
+					"ipaddress1":  "192.168.0.11",
+					"ipaddress2":  "",
+					"nw_mask_len": "24",
+				}),
+			},
+		}, got)
+	}
+}
+
+func TestVPCRouterCollector_SessionHeadroom(t *testing.T) {
+	cases := []struct {
+		name     string
+		plan     types.ID
+		sessions int
+		want     []float64
+	}{
+		{name: "premium router near capacity", plan: types.VPCRouterPlans.Premium, sessions: 95000, want: []float64{0.05}},
+		{name: "sessions over the limit", plan: types.VPCRouterPlans.Standard, sessions: 30000, want: []float64{0}},
+		{name: "unknown plan", plan: 999, sessions: 100, want: nil},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyVPCRouterClient{
+			find: []*platform.VPCRouter{
+				{
+					ZoneName: "is1a",
+					VPCRouter: &iaas.VPCRouter{
+						ID:             101,
+						Name:           "router",
+						PlanID:         tc.plan,
+						Availability:   types.Availabilities.Available,
+						InstanceStatus: types.ServerInstanceStatuses.Up,
+						Interfaces: []*iaas.VPCRouterInterface{
+							{Index: 0, ID: 200},
+						},
+						Settings: &iaas.VPCRouterSetting{},
+					},
+				},
+			},
+			status: &iaas.VPCRouterStatus{SessionCount: tc.sessions},
+		}, false)
+
+		collected, err := collectMetrics(c, "vpc_router")
+		require.NoError(t, err)
+		require.Equal(t, tc.want, collected.values(c.SessionHeadroom), tc.name)
+	}
+}
+
+func TestVPCRouterCollector_SessionDetails(t *testing.T) {
+	client := &dummyVPCRouterClient{
+		find: []*platform.VPCRouter{
+			{
+				ZoneName: "is1a",
+				VPCRouter: &iaas.VPCRouter{
+					ID:             101,
+					Name:           "router",
+					PlanID:         types.VPCRouterPlans.Standard,
+					InstanceStatus: types.ServerInstanceStatuses.Up,
+					Availability:   types.Availabilities.Available,
+					Interfaces: []*iaas.VPCRouterInterface{
+						{Index: 0, ID: 200},
+					},
+					Settings: &iaas.VPCRouterSetting{
+						Interfaces: []*iaas.VPCRouterInterfaceSetting{
+							{
+								IPAddress:      []string{"192.168.0.11"},
+								NetworkMaskLen: 24,
+								Index:          0,
+							},
+						},
+					},
+				},
+			},
+		},
+		status: &iaas.VPCRouterStatus{
+			L2TPIPsecServerSessions: []*iaas.VPCRouterL2TPIPsecServerSession{
+				{User: "user1", IPAddress: "172.16.1.1", TimeSec: 10},
+				{User: "user2", IPAddress: "172.16.1.2", TimeSec: 20},
+			},
+			PPTPServerSessions: []*iaas.VPCRouterPPTPServerSession{
+				{User: "user3", IPAddress: "172.16.2.1", TimeSec: 30},
+			},
+		},
+	}
+
+	cases := []struct {
+		name           string
+		sessionDetails bool
+		want           []*collectedMetric
+	}{
+		{
+			name:           "disabled",
+			sessionDetails: false,
+			want:           nil,
+		},
+		{
+			name:           "enabled",
+			sessionDetails: true,
+		},
+	}
+
+	for _, tc := range cases {
+		initLoggerAndErrors()
+		c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, client, tc.sessionDetails)
+		if tc.sessionDetails {
+			tc.want = []*collectedMetric{
+				{
+					desc: c.L2TPSessionInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"user":      "user1",
+						"ipaddress": "172.16.1.1",
+					}),
+				},
+				{
+					desc: c.L2TPSessionInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"user":      "user2",
+						"ipaddress": "172.16.1.2",
+					}),
+				},
+				{
+					desc: c.PPTPSessionInfo,
+					metric: createGaugeMetric(1, map[string]string{
+						"id":        "101",
+						"name":      "router",
+						"zone":      "is1a",
+						"user":      "user3",
+						"ipaddress": "172.16.2.1",
+					}),
+				},
+			}
+		}
+
+		collected, err := collectMetrics(c, "vpc_router")
+		require.NoError(t, err)
+
+		got := collected.filter(c.L2TPSessionInfo, c.PPTPSessionInfo)
+		requireMetricsEqual(t, tc.want, got)
+	}
+}
+
+func TestVPCRouterCollector_FirewallRuleCount(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyVPCRouterClient{
+		find: []*platform.VPCRouter{
+			{
+				ZoneName: "is1a",
+				VPCRouter: &iaas.VPCRouter{
+					ID:     101,
+					Name:   "router",
+					PlanID: types.VPCRouterPlans.Standard,
+					Settings: &iaas.VPCRouterSetting{
+						Firewall: []*iaas.VPCRouterFirewall{
+							{
+								Index: 0,
+								Send: []*iaas.VPCRouterFirewallRule{
+									{Protocol: types.Protocols.TCP, DestinationPort: "80", Action: types.Actions.Allow},
+									{Protocol: types.Protocols.TCP, DestinationPort: "443", Action: types.Actions.Allow},
+									{Protocol: types.Protocols.IP, Action: types.Actions.Deny},
+								},
+								Receive: []*iaas.VPCRouterFirewallRule{
+									{Protocol: types.Protocols.IP, Action: types.Actions.Allow},
+								},
+							},
+							{
+								Index: 1,
+								Send: []*iaas.VPCRouterFirewallRule{
+									{Protocol: types.Protocols.IP, Action: types.Actions.Allow},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}, false)
+
+	collected, err := collectMetrics(c, "vpc_router")
+	require.NoError(t, err)
+
+	got := collected.filter(c.FirewallRuleCount)
+	// the rules of all interfaces are summed up per direction
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.FirewallRuleCount,
+			metric: createGaugeMetric(4, map[string]string{
+				"id":        "101",
+				"name":      "router",
+				"zone":      "is1a",
+				"direction": "send",
+			}),
+		},
+		{
+			desc: c.FirewallRuleCount,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":        "101",
+				"name":      "router",
+				"zone":      "is1a",
+				"direction": "receive",
+			}),
+		},
+	}, got)
+}
+
+func TestVPCRouterCollector_SoftwareInfo(t *testing.T) {
+	initLoggerAndErrors()
+	c := NewVPCRouterCollector(context.Background(), testLogger, testErrors, Options{}, &dummyVPCRouterClient{
+		find: []*platform.VPCRouter{
+			{
+				ZoneName: "is1a",
+				VPCRouter: &iaas.VPCRouter{
+					ID:       101,
+					Name:     "router1",
+					PlanID:   types.VPCRouterPlans.Standard,
+					Version:  2,
+					Settings: &iaas.VPCRouterSetting{},
+				},
+			},
+			{
+				ZoneName: "is1a",
+				VPCRouter: &iaas.VPCRouter{
+					ID:       102,
+					Name:     "router2",
+					PlanID:   types.VPCRouterPlans.Standard,
+					Settings: &iaas.VPCRouterSetting{},
+				},
+			},
+		},
+	}, false)
+
+	collected, err := collectMetrics(c, "vpc_router")
+	require.NoError(t, err)
+
+	got := collected.filter(c.SoftwareInfo)
+	// the vpc_router without the version is not reported
+	requireMetricsEqual(t, []*collectedMetric{
+		{
+			desc: c.SoftwareInfo,
+			metric: createGaugeMetric(1, map[string]string{
+				"id":      "101",
+				"name":    "router1",
+				"zone":    "is1a",
+				"version": "2",
+			}),
+		},
+	}, got)
+}
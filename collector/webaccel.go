@@ -27,6 +27,7 @@ type WebAccelCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.WebAccelClient
 
 	SiteInfo           *prometheus.Desc
@@ -41,7 +42,7 @@ type WebAccelCollector struct {
 }
 
 // NewWebAccelCollector returns a new WebAccelCollector.
-func NewWebAccelCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.WebAccelClient) *WebAccelCollector {
+func NewWebAccelCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.WebAccelClient) *WebAccelCollector {
 	errors.WithLabelValues("webaccel").Add(0)
 
 	labels := []string{"id"}
@@ -50,43 +51,44 @@ func NewWebAccelCollector(ctx context.Context, logger *slog.Logger, errors *prom
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		SiteInfo: prometheus.NewDesc(
+		SiteInfo: opts.newDesc(
 			"webaccel_site_info",
 			"A metric with a constant '1' value labeled by id, name, domain_type, domain, subdomain",
 			[]string{"id", "name", "domain_type", "domain", "subdomain"}, nil,
 		),
-		AccessCount: prometheus.NewDesc(
+		AccessCount: opts.newDesc(
 			"webaccel_access_count",
 			"",
 			labels, nil,
 		),
-		BytesSent: prometheus.NewDesc(
+		BytesSent: opts.newDesc(
 			"webaccel_bytes_sent",
 			"",
 			labels, nil,
 		),
-		CacheMissBytesSent: prometheus.NewDesc(
+		CacheMissBytesSent: opts.newDesc(
 			"webaccel_cache_miss_bytes_sent",
 			"",
 			labels, nil,
 		),
-		CacheHitRatio: prometheus.NewDesc(
+		CacheHitRatio: opts.newDesc(
 			"webaccel_cache_hit_ratio",
 			"",
 			labels, nil,
 		),
-		BytesCacheHitRatio: prometheus.NewDesc(
+		BytesCacheHitRatio: opts.newDesc(
 			"webaccel_bytes_cache_hit_ratio",
 			"",
 			labels, nil,
 		),
-		Price: prometheus.NewDesc(
+		Price: opts.newDesc(
 			"webaccel_price",
 			"",
 			labels, nil,
 		),
-		CertificateExpireDate: prometheus.NewDesc(
+		CertificateExpireDate: opts.newDesc(
 			"webaccel_cert_expire",
 			"Certificate expiration date in seconds since epoch (1970)",
 			labels, nil,
@@ -109,7 +111,12 @@ func (c *WebAccelCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *WebAccelCollector) Collect(ch chan<- prometheus.Metric) {
-	sites, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *WebAccelCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	sites, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("webaccel").Add(1)
 		c.logger.Warn(
@@ -122,7 +129,7 @@ func (c *WebAccelCollector) Collect(ch chan<- prometheus.Metric) {
 	for _, site := range sites {
 		labels := []string{
 			site.ID,
-			site.Name,
+			c.opts.truncateLabelValue(site.Name),
 			site.DomainType,
 			site.Domain,
 			site.Subdomain,
@@ -145,7 +152,7 @@ func (c *WebAccelCollector) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 
-	usage, err := c.client.Usage(c.ctx)
+	usage, err := c.client.Usage(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("webaccel").Add(1)
 		c.logger.Warn(
@@ -38,7 +38,7 @@ func (d *dummyWebAccelClient) Usage(ctx context.Context) (*webaccel.MonthlyUsage
 
 func TestWebAccelCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewWebAccelCollector(context.Background(), testLogger, testErrors, &dummyWebAccelClient{})
+	c := NewWebAccelCollector(context.Background(), testLogger, testErrors, Options{}, &dummyWebAccelClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, 8)
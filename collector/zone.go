@@ -27,13 +27,14 @@ type ZoneCollector struct {
 	ctx    context.Context
 	logger *slog.Logger
 	errors *prometheus.CounterVec
+	opts   Options
 	client platform.ZoneClient
 
 	ZoneInfo *prometheus.Desc
 }
 
 // NewZoneCollector returns a new ZoneCollector.
-func NewZoneCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, client platform.ZoneClient) *ZoneCollector {
+func NewZoneCollector(ctx context.Context, logger *slog.Logger, errors *prometheus.CounterVec, opts Options, client platform.ZoneClient) *ZoneCollector {
 	errors.WithLabelValues("zone").Add(0)
 
 	labels := []string{"id", "name", "description", "region_id", "region_name"}
@@ -42,8 +43,9 @@ func NewZoneCollector(ctx context.Context, logger *slog.Logger, errors *promethe
 		ctx:    ctx,
 		logger: logger,
 		errors: errors,
+		opts:   opts,
 		client: client,
-		ZoneInfo: prometheus.NewDesc(
+		ZoneInfo: opts.newDesc(
 			"sakuracloud_zone_info",
 			"A metric with a constant '1' value labeled by id, name, description, region_id and region_name",
 			labels, nil,
@@ -59,7 +61,12 @@ func (c *ZoneCollector) Describe(ch chan<- *prometheus.Desc) {
 
 // Collect is called by the Prometheus registry when collecting metrics.
 func (c *ZoneCollector) Collect(ch chan<- prometheus.Metric) {
-	zones, err := c.client.Find(c.ctx)
+	c.CollectWithContext(c.ctx, ch)
+}
+
+// CollectWithContext collects metrics with ctx instead of the context passed to the constructor.
+func (c *ZoneCollector) CollectWithContext(ctx context.Context, ch chan<- prometheus.Metric) {
+	zones, err := c.client.Find(ctx)
 	if err != nil {
 		c.errors.WithLabelValues("zone").Add(1)
 		c.logger.Warn(
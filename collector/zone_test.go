@@ -35,7 +35,7 @@ func (d *dummyZoneClient) Find(ctx context.Context) ([]*iaas.Zone, error) {
 
 func TestZoneCollector_Describe(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewZoneCollector(context.Background(), testLogger, testErrors, &dummyZoneClient{})
+	c := NewZoneCollector(context.Background(), testLogger, testErrors, Options{}, &dummyZoneClient{})
 
 	descs := collectDescs(c)
 	require.Len(t, descs, 1)
@@ -43,7 +43,7 @@ func TestZoneCollector_Describe(t *testing.T) {
 
 func TestZoneCollector_Collect(t *testing.T) {
 	initLoggerAndErrors()
-	c := NewZoneCollector(context.Background(), testLogger, testErrors, nil)
+	c := NewZoneCollector(context.Background(), testLogger, testErrors, Options{}, nil)
 
 	cases := []struct {
 		name           string
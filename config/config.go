@@ -17,6 +17,11 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/alexflint/go-arg"
 )
@@ -24,37 +29,107 @@ import (
 const (
 	maximumRateLimit = 10
 	defaultRateLimit = 5
+
+	defaultProxyLBCertSANLimit = 20
 )
 
+// metricPrefixPattern matches the prefixes which keep the metric names valid
+var metricPrefixPattern = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+// headerNamePattern matches the HTTP header names consisting of the token characters
+var headerNamePattern = regexp.MustCompile(`^[a-zA-Z0-9!#$%&'*+.^_|~-]+$`)
+
 // Config gets its content from env and passes it on to different packages
 type Config struct {
-	Trace     bool     `arg:"env:TRACE" help:"Enable output of trace log of Sakura cloud API call"`
-	Debug     bool     `arg:"env:DEBUG" help:"Enable output of debug level log"`
-	FakeMode  string   `arg:"--fake-mode,env:FAKE_MODE" help:"File path to fetch/store fake data. If this flag is specified, enable fake-mode"`
-	Token     string   `arg:"required,env:SAKURACLOUD_ACCESS_TOKEN" help:"Token for using the SakuraCloud API"`
-	Secret    string   `arg:"required,env:SAKURACLOUD_ACCESS_TOKEN_SECRET" help:"Secret for using the SakuraCloud API"`
-	Zones     []string `arg:"-"` // TODO zones parameter is not implements.
-	WebAddr   string   `arg:"env:WEB_ADDR"`
-	WebPath   string   `arg:"env:WEB_PATH"`
-	RateLimit int      `arg:"env:SAKURACLOUD_RATE_LIMIT" help:"Rate limit per second for SakuraCloud API calls"`
+	Trace      bool     `arg:"env:TRACE" help:"Enable output of trace log of Sakura cloud API call"`
+	Debug      bool     `arg:"env:DEBUG" help:"Enable output of debug level log"`
+	FakeMode   string   `arg:"--fake-mode,env:FAKE_MODE" help:"File path to fetch/store fake data. If this flag is specified, enable fake-mode"`
+	Token      string   `arg:"env:SAKURACLOUD_ACCESS_TOKEN" help:"Token for using the SakuraCloud API"`
+	Secret     string   `arg:"env:SAKURACLOUD_ACCESS_TOKEN_SECRET" help:"Secret for using the SakuraCloud API"`
+	TokenFile  string   `arg:"--token-file,env:SAKURACLOUD_ACCESS_TOKEN_FILE" help:"File path to read the token for using the SakuraCloud API. This takes precedence over --token"`
+	SecretFile string   `arg:"--secret-file,env:SAKURACLOUD_ACCESS_TOKEN_SECRET_FILE" help:"File path to read the secret for using the SakuraCloud API. This takes precedence over --secret"`
+	Zones      []string `arg:"env:SAKURACLOUD_ZONES" help:"Target zones for collecting resources. This overrides the default zones list"`
+	WebAddr    string   `arg:"env:WEB_ADDR"`
+	WebPath    string   `arg:"env:WEB_PATH"`
+	RateLimit  int      `arg:"env:SAKURACLOUD_RATE_LIMIT" help:"Rate limit per second for SakuraCloud API calls"`
+	APICAFile  string   `arg:"--api-ca-file,env:SAKURACLOUD_API_CA_FILE" help:"File path to the PEM encoded root CA bundle for verifying the TLS certificate of the SakuraCloud API"`
+
+	APIRequestIDHeader string `arg:"--api-request-id-header,env:SAKURACLOUD_API_REQUEST_ID_HEADER" help:"Name of the header to send a correlation ID with each SakuraCloud API call. The ID is renewed per scrape"`
+
+	SecondaryAPIRootURL string   `arg:"--secondary-api-root-url,env:SAKURACLOUD_SECONDARY_API_ROOT_URL" help:"Root URL of the secondary SakuraCloud API. Resources are reported with the source label"`
+	SecondaryZones      []string `arg:"--secondary-zones,env:SAKURACLOUD_SECONDARY_ZONES" help:"Target zones for collecting resources via the secondary API. If specified, enable the secondary API"`
+
+	WebSocketPath string `arg:"--web-socket-path,env:WEB_SOCKET_PATH" help:"Path of the Unix domain socket to listen on instead of --webaddr"`
+
+	WebHealthPath    string `arg:"--web-health-path,env:WEB_HEALTH_PATH" help:"Path under which to expose the health check endpoint"`
+	WebReadinessPath string `arg:"--web-readiness-path,env:WEB_READINESS_PATH" help:"Path under which to expose the readiness check endpoint"`
+
+	MaxConcurrentScrapes int `arg:"--max-concurrent-scrapes,env:MAX_CONCURRENT_SCRAPES" help:"Maximum number of concurrent scrapes. 0 means no limit"`
 
 	NoCollectorAutoBackup              bool `arg:"--no-collector.auto-backup" help:"Disable the AutoBackup collector"`
 	NoCollectorBill                    bool `arg:"--no-collector.bill" help:"Disable the Bill collector"`
+	NoCollectorBridge                  bool `arg:"--no-collector.bridge" help:"Disable the Bridge collector"`
+	NoCollectorCertificate             bool `arg:"--no-collector.certificate" help:"Disable the Certificate collector"`
 	NoCollectorCoupon                  bool `arg:"--no-collector.coupon" help:"Disable the Coupon collector"`
 	NoCollectorDatabase                bool `arg:"--no-collector.database" help:"Disable the Database collector"`
+	NoCollectorDiskAutoBackup          bool `arg:"--no-collector.disk-autobackup" help:"Disable the DiskAutoBackup collector"`
+	NoCollectorDNS                     bool `arg:"--no-collector.dns" help:"Disable the DNS collector"`
+	NoCollectorDuplicateIP             bool `arg:"--no-collector.duplicate-ip" help:"Disable the DuplicateIP collector"`
 	NoCollectorESME                    bool `arg:"--no-collector.esme" help:"Disable the ESME collector"`
+	NoCollectorGSLB                    bool `arg:"--no-collector.gslb" help:"Disable the GSLB collector"`
 	NoCollectorInternet                bool `arg:"--no-collector.internet" help:"Disable the Internet(Switch+Router) collector"`
 	NoCollectorLoadBalancer            bool `arg:"--no-collector.load-balancer" help:"Disable the LoadBalancer collector"`
-	NoCollectorLocalRouter             bool `arg:"--no-collector.local-router" help:"Disable the LocalRouter collector"`
+	NoCollectorLocalRouter             bool `arg:"--no-collector.local-router" help:"Disable the LocalRouter collector. This is independent of --no-collector.load-balancer"`
 	NoCollectorMobileGateway           bool `arg:"--no-collector.mobile-gateway" help:"Disable the MobileGateway collector"`
 	NoCollectorNFS                     bool `arg:"--no-collector.nfs" help:"Disable the NFS collector"`
 	NoCollectorProxyLB                 bool `arg:"--no-collector.proxy-lb" help:"Disable the ProxyLB(Enhanced LoadBalancer) collector"`
 	NoCollectorServer                  bool `arg:"--no-collector.server" help:"Disable the Server collector"`
 	NoCollectorServerExceptMaintenance bool `arg:"--no-collector.server.except-maintenance" help:"Disable the Server collector except for maintenance information"`
+	NoCollectorServerHygiene           bool `arg:"--no-collector.server-hygiene" help:"Disable the ServerHygiene collector"`
+	NoCollectorSimpleMonitor           bool `arg:"--no-collector.simple-monitor" help:"Disable the SimpleMonitor collector"`
 	NoCollectorSIM                     bool `arg:"--no-collector.sim" help:"Disable the SIM collector"`
 	NoCollectorVPCRouter               bool `arg:"--no-collector.vpc-router" help:"Disable the VPCRouter collector"`
 	NoCollectorZone                    bool `arg:"--no-collector.zone" help:"Disable the Zone collector"`
 	NoCollectorWebAccel                bool `arg:"--no-collector.webaccel" help:"Disable the WebAccel collector"`
+
+	CollectorIntervalBill     time.Duration `arg:"--collector-interval.bill" help:"Interval for refreshing the Bill collector. Cached metrics are served within the interval. 0 means every scrape"`
+	CollectorIntervalCoupon   time.Duration `arg:"--collector-interval.coupon" help:"Interval for refreshing the Coupon collector. Cached metrics are served within the interval. 0 means every scrape"`
+	CollectorIntervalProxyLB  time.Duration `arg:"--collector-interval.proxy-lb" help:"Interval for refreshing the ProxyLB collector. Cached metrics are served within the interval. 0 means every scrape"`
+	CollectorIntervalWebAccel time.Duration `arg:"--collector-interval.webaccel" help:"Interval for refreshing the WebAccel collector. Cached metrics are served within the interval. 0 means every scrape"`
+
+	GoroutineWarnThreshold int `arg:"--goroutine-warn-threshold,env:GOROUTINE_WARN_THRESHOLD" help:"Number of goroutines spawned per collect of a single collector above which a warning is reported. 0 disables it"`
+
+	StaleScrapesResetThreshold int `arg:"--stale-scrapes-reset-threshold,env:STALE_SCRAPES_RESET_THRESHOLD" help:"Number of consecutive scrapes without monitor samples to reset the cached state of the collector. 0 disables it"`
+
+	NetworkUnit string `arg:"--network-unit,env:NETWORK_UNIT" help:"Unit of NIC traffic metrics. bits(Kbps) or bytes(KBps)"`
+
+	EmitZeroOnNil bool `arg:"--emit-zero-on-nil,env:EMIT_ZERO_ON_NIL" help:"Emit 0-valued samples instead of skipping when the monitor API returns no value"`
+
+	ExposeSampleTimestamps bool `arg:"--expose-sample-timestamps,env:EXPOSE_SAMPLE_TIMESTAMPS" help:"Export the time of monitor samples as sakuracloud_<resource>_monitor_sample_timestamp_seconds"`
+
+	HelpLanguage string `arg:"--help-language,env:HELP_LANGUAGE" help:"Language of metric help strings. en or ja"`
+
+	RecentlyCreatedWindow time.Duration `arg:"--resource.recently-created-window" help:"Time window in which resources are reported as recently created. 0 disables it"`
+
+	DeltaMode bool `arg:"--delta-mode,env:DELTA_MODE" help:"[EXPERIMENTAL] Send info metrics only when they have changed since the last scrape"`
+
+	SkipMetrics []string `arg:"--skip-metrics,env:SKIP_METRICS" help:"Metric names to skip. e.g. server_nic_receive,server_nic_send"`
+
+	CountByTags []string `arg:"--count-by-tags,env:COUNT_BY_TAGS" help:"Tag keys to count resources by their values. Tags are parsed as key=value. e.g. env,team"`
+
+	SelfMetricPrefix string `arg:"--self-metric-prefix,env:SELF_METRIC_PREFIX" help:"Prefix prepended to the names of the exporter's self-metrics such as sakuracloud_exporter_errors_total. Resource metrics are not affected"`
+
+	AddRegionLabel bool `arg:"--add-region-label,env:ADD_REGION_LABEL" help:"Add the region label derived from the zone name(e.g. is1a -> is1) to the info metrics of zoned resources"`
+
+	MaxLabelLength int `arg:"--max-label-length,env:MAX_LABEL_LENGTH" help:"Maximum length of label values such as name/description/tags. Longer values are truncated. 0 means no limit"`
+
+	DNSCheckDelegation              bool            `arg:"--dns-check-delegation" help:"Enable checking that the NS records of each DNS zone point at the SakuraCloud name servers. This performs external DNS lookups"`
+	ESMESendRateWindows             []time.Duration `arg:"--esme.send-rate-windows" help:"Time windows for calculating the ESME send rate"`
+	ProxyLBCertSANLimit             int             `arg:"--proxylb.cert-san-limit" help:"Maximum number of subject alternative names exposed per ProxyLB certificate. 0 means no limit"`
+	ProxyLBProbe                    bool            `arg:"--proxylb-probe" help:"Enable probing the VIP(or FQDN) of each ProxyLB bind port by a TCP connect"`
+	ServerTransitionStuckThreshold  time.Duration   `arg:"--server.transition-stuck-threshold" help:"Threshold to report servers staying in a transitional state(e.g. cleaning) as stuck. 0 disables it"`
+	SimpleMonitorAvailabilityWindow time.Duration   `arg:"--simple-monitor.availability-window" help:"Time window for calculating the availability ratio of simple monitors from the observed health checks. 0 disables it"`
+	VPCRouterSessionDetails         bool            `arg:"--vpc-router-session-details" help:"Enable reporting the user and IP address of each L2TP-IPsec/PPTP session of VPCRouters"`
 }
 
 func InitConfig() (Config, error) {
@@ -63,24 +138,166 @@ func InitConfig() (Config, error) {
 		WebAddr:   ":9542",
 		Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
 		RateLimit: defaultRateLimit,
+
+		WebHealthPath:    "/healthz",
+		WebReadinessPath: "/readyz",
+
+		NetworkUnit:           "bits",
+		HelpLanguage:          "en",
+		RecentlyCreatedWindow: time.Hour,
+
+		ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+		ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+		ServerTransitionStuckThreshold:  30 * time.Minute,
+		SimpleMonitorAvailabilityWindow: 24 * time.Hour,
 	}
 	arg.MustParse(&c)
 
+	if c.TokenFile != "" {
+		token, err := readCredentialFile(c.TokenFile)
+		if err != nil {
+			return c, fmt.Errorf("reading --token-file failed: %w", err)
+		}
+		c.Token = token
+	}
+	if c.SecretFile != "" {
+		secret, err := readCredentialFile(c.SecretFile)
+		if err != nil {
+			return c, fmt.Errorf("reading --secret-file failed: %w", err)
+		}
+		c.Secret = secret
+	}
+
 	if c.Token == "" {
 		return c, errors.New("SakuraCloud API Token is required")
 	}
 	if c.Secret == "" {
 		return c, errors.New("SakuraCloud API Secret is required")
 	}
+	if len(c.Zones) == 0 {
+		return c, errors.New("--zones must have at least one zone")
+	}
+	for _, zone := range c.Zones {
+		if zone == "" {
+			return c, errors.New("--zones must not contain an empty zone name")
+		}
+	}
+	if c.SecondaryAPIRootURL != "" && len(c.SecondaryZones) == 0 {
+		return c, errors.New("--secondary-zones is required when --secondary-api-root-url is specified")
+	}
+	if len(c.SecondaryZones) > 0 && c.SecondaryAPIRootURL == "" {
+		return c, errors.New("--secondary-api-root-url is required when --secondary-zones is specified")
+	}
+	if c.SecondaryAPIRootURL != "" {
+		u, err := url.Parse(c.SecondaryAPIRootURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return c, fmt.Errorf("--secondary-api-root-url must be a http(s) URL: %s", c.SecondaryAPIRootURL)
+		}
+	}
+	for _, zone := range c.SecondaryZones {
+		if zone == "" {
+			return c, errors.New("--secondary-zones must not contain an empty zone name")
+		}
+	}
 	if c.RateLimit <= 0 {
 		c.RateLimit = defaultRateLimit
 	}
 	if c.RateLimit > maximumRateLimit {
 		return c, fmt.Errorf("--ratelimit must be 1 to %d", maximumRateLimit)
 	}
+	if err := validateWebPaths(c); err != nil {
+		return c, err
+	}
+	if c.MaxConcurrentScrapes < 0 {
+		return c, errors.New("--max-concurrent-scrapes must be 0 or greater")
+	}
+	for _, w := range c.ESMESendRateWindows {
+		if w <= 0 {
+			return c, fmt.Errorf("--esme.send-rate-windows must be positive durations: %s", w)
+		}
+	}
+	if c.NetworkUnit != "bits" && c.NetworkUnit != "bytes" {
+		return c, fmt.Errorf("--network-unit must be bits or bytes: %s", c.NetworkUnit)
+	}
+	if c.HelpLanguage != "en" && c.HelpLanguage != "ja" {
+		return c, fmt.Errorf("--help-language must be en or ja: %s", c.HelpLanguage)
+	}
+	for _, key := range c.CountByTags {
+		if key == "" {
+			return c, errors.New("--count-by-tags must not contain an empty tag key")
+		}
+	}
+	if c.SelfMetricPrefix != "" && !metricPrefixPattern.MatchString(c.SelfMetricPrefix) {
+		return c, fmt.Errorf("--self-metric-prefix must be a valid metric name prefix: %s", c.SelfMetricPrefix)
+	}
+	if c.APIRequestIDHeader != "" && !headerNamePattern.MatchString(c.APIRequestIDHeader) {
+		return c, fmt.Errorf("--api-request-id-header must be a valid header name: %s", c.APIRequestIDHeader)
+	}
+	if c.GoroutineWarnThreshold < 0 {
+		return c, errors.New("--goroutine-warn-threshold must be 0 or greater")
+	}
+	if c.MaxLabelLength < 0 {
+		return c, errors.New("--max-label-length must be 0 or greater")
+	}
+	if c.RecentlyCreatedWindow < 0 {
+		return c, errors.New("--resource.recently-created-window must be 0 or greater")
+	}
+	if c.ProxyLBCertSANLimit < 0 {
+		return c, errors.New("--proxylb.cert-san-limit must be 0 or greater")
+	}
+	if c.ServerTransitionStuckThreshold < 0 {
+		return c, errors.New("--server.transition-stuck-threshold must be 0 or greater")
+	}
+	if c.SimpleMonitorAvailabilityWindow < 0 {
+		return c, errors.New("--simple-monitor.availability-window must be 0 or greater")
+	}
+	if c.StaleScrapesResetThreshold < 0 {
+		return c, errors.New("--stale-scrapes-reset-threshold must be 0 or greater")
+	}
+	for flag, interval := range map[string]time.Duration{
+		"--collector-interval.bill":     c.CollectorIntervalBill,
+		"--collector-interval.coupon":   c.CollectorIntervalCoupon,
+		"--collector-interval.proxy-lb": c.CollectorIntervalProxyLB,
+		"--collector-interval.webaccel": c.CollectorIntervalWebAccel,
+	} {
+		if interval < 0 {
+			return c, fmt.Errorf("%s must be 0 or greater", flag)
+		}
+	}
 	if c.NoCollectorServerExceptMaintenance && c.NoCollectorServer {
 		return c, fmt.Errorf("--no-collector.server.except-maintenance enabled and --no-collector-server are both enabled")
 	}
 
 	return c, nil
 }
+
+// validateWebPaths validates that the HTTP endpoints don't collide with each other or with the index page
+func validateWebPaths(c Config) error {
+	paths := []struct {
+		flag string
+		path string
+	}{
+		{flag: "--webpath", path: c.WebPath},
+		{flag: "--web-health-path", path: c.WebHealthPath},
+		{flag: "--web-readiness-path", path: c.WebReadinessPath},
+	}
+	seen := make(map[string]string)
+	for _, p := range paths {
+		if !strings.HasPrefix(p.path, "/") || p.path == "/" {
+			return fmt.Errorf("%s must start with '/' and must not be the root path: %s", p.flag, p.path)
+		}
+		if other, ok := seen[p.path]; ok {
+			return fmt.Errorf("%s and %s must not be the same path: %s", other, p.flag, p.path)
+		}
+		seen[p.path] = p.flag
+	}
+	return nil
+}
+
+func readCredentialFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), " \t\r\n"), nil
+}
@@ -16,7 +16,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -43,9 +45,406 @@ func TestInitConfig(t *testing.T) {
 				WebAddr:   ":9542",
 				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
 				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with zones",
+			args: []string{"--token", "token", "--secret", "secret", "--zones", "is1a", "tk1a", "tk2a"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+				Zones:  []string{"is1a", "tk1a", "tk2a"},
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with zones from env",
+			args: []string{"--token", "token", "--secret", "secret"},
+			envs: map[string]string{"SAKURACLOUD_ZONES": "is1b,tk2a"},
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+				Zones:  []string{"is1b", "tk2a"},
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with esme send rate windows",
+			args: []string{"--token", "token", "--secret", "secret", "--esme.send-rate-windows", "30m", "6h"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{30 * time.Minute, 6 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with skip metrics",
+			args: []string{"--token", "token", "--secret", "secret", "--skip-metrics", "server_nic_receive,server_nic_send"},
+			envs: nil,
+			want: Config{
+				Token:       "token",
+				Secret:      "secret",
+				SkipMetrics: []string{"server_nic_receive,server_nic_send"},
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name: "with max concurrent scrapes",
+			args: []string{"--token", "token", "--secret", "secret", "--max-concurrent-scrapes", "2"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				MaxConcurrentScrapes: 2,
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "with negative max concurrent scrapes",
+			args:    []string{"--token", "token", "--secret", "secret", "--max-concurrent-scrapes", "-1"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with negative stale scrapes reset threshold",
+			args:    []string{"--token", "token", "--secret", "secret", "--stale-scrapes-reset-threshold", "-1"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with network unit",
+			args: []string{"--token", "token", "--secret", "secret", "--network-unit", "bytes"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bytes",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "with invalid network unit",
+			args:    []string{"--token", "token", "--secret", "secret", "--network-unit", "octets"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with help language",
+			args: []string{"--token", "token", "--secret", "secret", "--help-language", "ja"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "ja",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "with invalid help language",
+			args:    []string{"--token", "token", "--secret", "secret", "--help-language", "fr"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with health and readiness paths",
+			args: []string{"--token", "token", "--secret", "secret", "--web-health-path", "/-/healthy", "--web-readiness-path", "/-/ready"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/-/healthy",
+				WebReadinessPath: "/-/ready",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+			wantErr: false,
+		},
+		{
+			name:    "with health path colliding with web path",
+			args:    []string{"--token", "token", "--secret", "secret", "--web-health-path", "/metrics"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with readiness path colliding with health path",
+			args:    []string{"--token", "token", "--secret", "secret", "--web-readiness-path", "/healthz"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with root health path",
+			args:    []string{"--token", "token", "--secret", "secret", "--web-health-path", "/"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with negative max label length",
+			args:    []string{"--token", "token", "--secret", "secret", "--max-label-length", "-1"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with negative goroutine warn threshold",
+			args:    []string{"--token", "token", "--secret", "secret", "--goroutine-warn-threshold", "-1"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with invalid self metric prefix",
+			args:    []string{"--token", "token", "--secret", "secret", "--self-metric-prefix", "my-exporter_"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with invalid api request id header",
+			args:    []string{"--token", "token", "--secret", "secret", "--api-request-id-header", "X Request ID"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with empty count-by-tags key",
+			args:    []string{"--token", "token", "--secret", "secret", "--count-by-tags", ""},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with collector interval",
+			args: []string{"--token", "token", "--secret", "secret", "--collector-interval.bill", "5m"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				CollectorIntervalBill: 5 * time.Minute,
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
+			},
+		},
+		{
+			name:    "with negative collector interval",
+			args:    []string{"--token", "token", "--secret", "secret", "--collector-interval.proxy-lb", "-1m"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name: "with secondary api",
+			args: []string{"--token", "token", "--secret", "secret", "--secondary-api-root-url", "https://secondary.example.com/cloud/zone", "--secondary-zones", "tk1b"},
+			envs: nil,
+			want: Config{
+				Token:  "token",
+				Secret: "secret",
+
+				SecondaryAPIRootURL: "https://secondary.example.com/cloud/zone",
+				SecondaryZones:      []string{"tk1b"},
+
+				WebPath:   "/metrics",
+				WebAddr:   ":9542",
+				Zones:     []string{"is1a", "is1b", "tk1a", "tk1b", "tk1v"},
+				RateLimit: defaultRateLimit,
+
+				WebHealthPath:    "/healthz",
+				WebReadinessPath: "/readyz",
+
+				NetworkUnit:           "bits",
+				HelpLanguage:          "en",
+				RecentlyCreatedWindow: time.Hour,
+
+				ESMESendRateWindows:             []time.Duration{time.Hour, 24 * time.Hour},
+				ProxyLBCertSANLimit:             defaultProxyLBCertSANLimit,
+				ServerTransitionStuckThreshold:  30 * time.Minute,
+				SimpleMonitorAvailabilityWindow: 24 * time.Hour,
 			},
 			wantErr: false,
 		},
+		{
+			name:    "with negative simple monitor availability window",
+			args:    []string{"--token", "token", "--secret", "secret", "--simple-monitor.availability-window", "-1h"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with secondary api root url without zones",
+			args:    []string{"--token", "token", "--secret", "secret", "--secondary-api-root-url", "https://secondary.example.com/cloud/zone"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with secondary zones without api root url",
+			args:    []string{"--token", "token", "--secret", "secret", "--secondary-zones", "tk1b"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with invalid secondary api root url",
+			args:    []string{"--token", "token", "--secret", "secret", "--secondary-api-root-url", "secondary.example.com", "--secondary-zones", "tk1b"},
+			envs:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "with empty zone",
+			args:    []string{"--token", "token", "--secret", "secret", "--zones", ""},
+			envs:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -53,17 +452,99 @@ func TestInitConfig(t *testing.T) {
 			for k, v := range tt.envs {
 				os.Setenv(k, v)
 			}
+			defer initEnvVars()
 
 			got, err := InitConfig()
 			if (err != nil) != tt.wantErr {
 				t.Errorf("InitConfig() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantErr {
+				return
+			}
 			require.EqualValues(t, tt.want, got)
 		})
 	}
 }
 
+func TestInitConfig_CredentialFiles(t *testing.T) {
+	initEnvVars()
+	defer initEnvVars()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	secretFile := filepath.Join(dir, "secret")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("token-from-file\n"), 0600))
+	require.NoError(t, os.WriteFile(secretFile, []byte("secret-from-file \r\n"), 0600))
+
+	tests := []struct {
+		name       string
+		args       []string
+		envs       map[string]string
+		wantToken  string
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name:       "from files",
+			args:       []string{"--token-file", tokenFile, "--secret-file", secretFile},
+			wantToken:  "token-from-file",
+			wantSecret: "secret-from-file",
+		},
+		{
+			name: "from env files",
+			envs: map[string]string{
+				"SAKURACLOUD_ACCESS_TOKEN_FILE":        tokenFile,
+				"SAKURACLOUD_ACCESS_TOKEN_SECRET_FILE": secretFile,
+			},
+			wantToken:  "token-from-file",
+			wantSecret: "secret-from-file",
+		},
+		{
+			name:       "files take precedence over flags",
+			args:       []string{"--token", "token", "--secret", "secret", "--token-file", tokenFile, "--secret-file", secretFile},
+			wantToken:  "token-from-file",
+			wantSecret: "secret-from-file",
+		},
+		{
+			name:       "file and flag",
+			args:       []string{"--token-file", tokenFile, "--secret", "secret"},
+			wantToken:  "token-from-file",
+			wantSecret: "secret",
+		},
+		{
+			name:    "file not found",
+			args:    []string{"--token-file", filepath.Join(dir, "not-exists"), "--secret", "secret"},
+			wantErr: true,
+		},
+		{
+			name:    "without token",
+			args:    []string{"--secret", "secret"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Args = append([]string{os.Args[0]}, tt.args...)
+			for k, v := range tt.envs {
+				os.Setenv(k, v)
+			}
+			defer initEnvVars()
+
+			got, err := InitConfig()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InitConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			require.Equal(t, tt.wantToken, got.Token)
+			require.Equal(t, tt.wantSecret, got.Secret)
+		})
+	}
+}
+
 func initEnvVars() {
 	keys := []string{
 		"TRACE",
@@ -71,11 +552,56 @@ func initEnvVars() {
 		"FAKE_MODE",
 		"SAKURACLOUD_ACCESS_TOKEN",
 		"SAKURACLOUD_ACCESS_TOKEN_SECRET",
+		"SAKURACLOUD_ACCESS_TOKEN_FILE",
+		"SAKURACLOUD_ACCESS_TOKEN_SECRET_FILE",
 		"WEB_ADDR",
 		"WEB_PATH",
+		"WEB_HEALTH_PATH",
+		"WEB_READINESS_PATH",
 		"SAKURACLOUD_RATE_LIMIT",
+		"SAKURACLOUD_ZONES",
+		"SAKURACLOUD_SECONDARY_API_ROOT_URL",
+		"SAKURACLOUD_SECONDARY_ZONES",
+		"SKIP_METRICS",
+		"MAX_CONCURRENT_SCRAPES",
+		"NETWORK_UNIT",
+		"HELP_LANGUAGE",
+		"MAX_LABEL_LENGTH",
 	}
 	for _, key := range keys {
 		os.Unsetenv(key)
 	}
 }
+
+func TestValidateWebPaths(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      Config
+		wantErr string
+	}{
+		{
+			name:    "root metrics path",
+			in:      Config{WebPath: "/", WebHealthPath: "/healthz", WebReadinessPath: "/readyz"},
+			wantErr: "--webpath must start with '/' and must not be the root path: /",
+		},
+		{
+			name:    "colliding paths",
+			in:      Config{WebPath: "/healthz", WebHealthPath: "/healthz", WebReadinessPath: "/readyz"},
+			wantErr: "--webpath and --web-health-path must not be the same path: /healthz",
+		},
+		{
+			name: "distinct paths",
+			in:   Config{WebPath: "/metrics", WebHealthPath: "/healthz", WebReadinessPath: "/readyz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWebPaths(tt.in)
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
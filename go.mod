@@ -10,6 +10,7 @@ require (
 	github.com/sacloud/packages-go v0.0.10
 	github.com/sacloud/webaccel-api-go v1.2.0
 	github.com/stretchr/testify v1.10.0
+	go.uber.org/ratelimit v0.3.0
 )
 
 require (
@@ -39,7 +40,6 @@ require (
 	github.com/sacloud/go-http v0.1.8 // indirect
 	github.com/zclconf/go-cty v1.14.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
-	go.uber.org/ratelimit v0.3.0 // indirect
 	golang.org/x/crypto v0.31.0 // indirect
 	golang.org/x/mod v0.17.0 // indirect
 	golang.org/x/sys v0.28.0 // indirect
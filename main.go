@@ -19,9 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
+	"os/signal"
 	"runtime"
+	"syscall"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -67,7 +70,11 @@ func main() {
 		slog.String("goVersion", GoVersion),
 	)
 
-	client := platform.NewSakuraCloudClient(c, Version)
+	client, err := platform.NewSakuraCloudClient(c, Version)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
 	ctx := context.Background()
 
 	if !client.HasValidAPIKeys(ctx) {
@@ -76,11 +83,20 @@ func main() {
 	if !c.NoCollectorWebAccel && !client.HasWebAccelPermission(ctx) {
 		logger.Warn("API key doesn't have webaccel permission")
 	}
+	client.SetAPIPermissions(ctx)
 
-	errs := prometheus.NewCounterVec(prometheus.CounterOpts{
-		Name: "sakuracloud_exporter_errors_total",
-		Help: "The total number of errors per collector",
-	}, []string{"collector"})
+	var secondaryClient *platform.Client
+	if len(c.SecondaryZones) > 0 {
+		secondaryClient, err = platform.NewSecondarySakuraCloudClient(c, Version)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if !secondaryClient.HasValidAPIKeys(ctx) {
+			panic(errors.New("unauthorized: invalid API key is applied to the secondary API"))
+		}
+		secondaryClient.SetAPIPermissions(ctx)
+	}
 
 	r := prometheus.NewRegistry()
 	r.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{
@@ -91,64 +107,230 @@ func main() {
 
 	// collector info
 	r.MustRegister(collectors.NewGoCollector())
-	r.MustRegister(collector.NewExporterCollector(ctx, logger, Version, Revision, GoVersion, StartTime))
-	r.MustRegister(errs)
+	registerExporterCollectors(ctx, logger, r, c)
 
 	// sakuracloud metrics
+	scrapes := collector.NewContextRegistry()
+	if secondaryClient == nil {
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, nil, c, client)
+	} else {
+		// the same metrics are collected from both APIs, so they are distinguished by the source label
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, prometheus.Labels{"source": "primary"}, c, client)
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, prometheus.Labels{"source": "secondary"}, c, secondaryClient)
+	}
+
+	mux := newServeMux(c, r, scrapes, logger)
+
+	listener, err := newListener(c)
+	if err != nil {
+		cancel()
+		logger.Error("http listen error", slog.Any("err", err))
+		os.Exit(2)
+	}
+	logger.Info("listening", slog.String("addr", listener.Addr().String()))
+
+	server := &http.Server{Handler: mux} //nolint
+	go func() {
+		// closing the listener also removes the socket file of the Unix domain socket
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		cancel()
+		server.Shutdown(context.Background()) //nolint:errcheck
+	}()
+	if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		cancel()
+		logger.Error("http serve error", slog.Any("err", err))
+		os.Exit(2)
+	}
+}
+
+// newListener returns the listener on the Unix domain socket if --web-socket-path is specified, or on the TCP address otherwise
+func newListener(c config.Config) (net.Listener, error) {
+	if c.WebSocketPath != "" {
+		if err := removeStaleSocket(c.WebSocketPath); err != nil {
+			return nil, err
+		}
+		return net.Listen("unix", c.WebSocketPath)
+	}
+	return net.Listen("tcp", c.WebAddr)
+}
+
+// removeStaleSocket removes the socket file left behind by a crashed process.
+// Files other than sockets and sockets still accepting connections are kept.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return fmt.Errorf("%s is in use by another process", path)
+	}
+	return os.Remove(path)
+}
+
+// collectorOptions returns the settings shared by the collectors
+func collectorOptions(c config.Config) collector.Options {
+	return collector.Options{
+		MaxLabelLength:         c.MaxLabelLength,
+		NetworkUnit:            c.NetworkUnit,
+		EmitZeroOnNil:          c.EmitZeroOnNil,
+		ExposeSampleTimestamps: c.ExposeSampleTimestamps,
+		HelpLanguage:           c.HelpLanguage,
+		RecentlyCreatedWindow:  c.RecentlyCreatedWindow,
+		CountByTags:            c.CountByTags,
+		GoroutineWarnThreshold: c.GoroutineWarnThreshold,
+		AddRegionLabel:         c.AddRegionLabel,
+	}
+}
+
+// registerExporterCollectors registers the self-metrics of the exporter, prefixed by --self-metric-prefix
+func registerExporterCollectors(ctx context.Context, logger *slog.Logger, r prometheus.Registerer, c config.Config) {
+	self := prometheus.WrapRegistererWithPrefix(c.SelfMetricPrefix, r)
+	self.MustRegister(collector.NewExporterCollector(ctx, logger, collectorOptions(c), Version, Revision, GoVersion, StartTime))
+}
+
+// registerSakuraCloudCollectors registers the collectors of SakuraCloud resources fetched via the client.
+// The metrics are labeled with labels, and the resource collectors are registered to scrapes
+// so that the API calls are issued with the context of each scrape request.
+func registerSakuraCloudCollectors(ctx context.Context, logger *slog.Logger, r prometheus.Registerer, scrapes *collector.ContextRegistry, labels prometheus.Labels, c config.Config, client *platform.Client) {
+	// the errors counter and the instrumentation are self-metrics, but the resource metrics are not prefixed
+	self := prometheus.WrapRegistererWithPrefix(c.SelfMetricPrefix, prometheus.WrapRegistererWith(labels, r))
+
+	errs := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sakuracloud_exporter_errors_total",
+		Help: "The total number of errors per collector",
+	}, []string{"collector"})
+	self.MustRegister(errs)
+
+	instrumentation := collector.NewInstrumentation(c.StaleScrapesResetThreshold)
+	self.MustRegister(instrumentation)
+	self.MustRegister(client.Metrics)
+
+	opts := collectorOptions(c)
+	opts.GoroutineWarning = collector.NewGoroutineWarning()
+	self.MustRegister(opts.GoroutineWarning)
+	opts.LabelsTruncated = collector.NewLabelsTruncated()
+	self.MustRegister(opts.LabelsTruncated)
+	register := func(name string, sc prometheus.Collector) {
+		if c.DeltaMode {
+			sc = collector.NewDeltaCollector(sc)
+		}
+		scrapes.MustRegister(labels, instrumentation.Wrap(name, collector.NewSkipMetricsCollector(sc, c.SkipMetrics)))
+	}
+
+	// servers and AutoBackups are listed by multiple collectors, so the lists are shared within a scrape
+	serverClient := platform.NewSharedServerClient(client.Server)
+	autoBackupClient := platform.NewSharedAutoBackupClient(client.AutoBackup)
+
 	if !c.NoCollectorAutoBackup {
-		r.MustRegister(collector.NewAutoBackupCollector(ctx, logger, errs, client.AutoBackup))
+		register("auto_backup", collector.NewAutoBackupCollector(ctx, instrumentation.Logger("auto_backup", logger), errs, opts, autoBackupClient))
 	}
 	if !c.NoCollectorBill {
-		r.MustRegister(collector.NewBillCollector(ctx, logger, errs, client.Bill))
+		register("bill", collector.NewCachingCollector(collector.NewBillCollector(ctx, instrumentation.Logger("bill", logger), errs, opts, client.Bill), c.CollectorIntervalBill))
+	}
+	if !c.NoCollectorBridge {
+		register("bridge", collector.NewBridgeCollector(ctx, instrumentation.Logger("bridge", logger), errs, opts, client.Bridge))
+	}
+	if !c.NoCollectorCertificate {
+		// certificates of the disabled products are not collected
+		var proxyLBClient platform.ProxyLBClient
+		if !c.NoCollectorProxyLB {
+			proxyLBClient = client.ProxyLB
+		}
+		var webAccelClient platform.WebAccelClient
+		if !c.NoCollectorWebAccel && client.WebAccel != nil {
+			webAccelClient = client.WebAccel
+		}
+		register("certificate", collector.NewCertificateCollector(ctx, instrumentation.Logger("certificate", logger), errs, opts, proxyLBClient, webAccelClient, client.CertificateAuthority))
 	}
 	if !c.NoCollectorCoupon {
-		r.MustRegister(collector.NewCouponCollector(ctx, logger, errs, client.Coupon))
+		register("coupon", collector.NewCachingCollector(collector.NewCouponCollector(ctx, instrumentation.Logger("coupon", logger), errs, opts, client.Coupon), c.CollectorIntervalCoupon))
 	}
 	if !c.NoCollectorDatabase {
-		r.MustRegister(collector.NewDatabaseCollector(ctx, logger, errs, client.Database))
+		register("database", collector.NewDatabaseCollector(ctx, instrumentation.Logger("database", logger), errs, opts, client.Database))
+	}
+	if !c.NoCollectorDiskAutoBackup {
+		register("disk_autobackup", collector.NewDiskAutoBackupCollector(ctx, instrumentation.Logger("disk_autobackup", logger), errs, opts, serverClient, autoBackupClient))
+	}
+	if !c.NoCollectorDNS {
+		// the delegation is checked only if enabled because it performs external DNS lookups
+		var resolver collector.NSResolver
+		if c.DNSCheckDelegation {
+			resolver = net.DefaultResolver
+		}
+		register("dns", collector.NewDNSCollector(ctx, instrumentation.Logger("dns", logger), errs, opts, client.DNS, resolver))
+	}
+	if !c.NoCollectorDuplicateIP {
+		register("duplicate_ip", collector.NewDuplicateIPCollector(ctx, instrumentation.Logger("duplicate_ip", logger), errs, opts, serverClient, client.LoadBalancer, client.VPCRouter, client.NFS, client.Database))
 	}
 	if !c.NoCollectorESME {
-		r.MustRegister(collector.NewESMECollector(ctx, logger, errs, client.ESME))
+		register("esme", collector.NewESMECollector(ctx, instrumentation.Logger("esme", logger), errs, opts, client.ESME, c.ESMESendRateWindows))
+	}
+	if !c.NoCollectorGSLB {
+		register("gslb", collector.NewGSLBCollector(ctx, instrumentation.Logger("gslb", logger), errs, opts, client.GSLB))
 	}
 	if !c.NoCollectorInternet {
-		r.MustRegister(collector.NewInternetCollector(ctx, logger, errs, client.Internet))
+		register("internet", collector.NewInternetCollector(ctx, instrumentation.Logger("internet", logger), errs, opts, client.Internet))
 	}
 	if !c.NoCollectorLoadBalancer {
-		r.MustRegister(collector.NewLoadBalancerCollector(ctx, logger, errs, client.LoadBalancer))
+		register("loadbalancer", collector.NewLoadBalancerCollector(ctx, instrumentation.Logger("loadbalancer", logger), errs, opts, client.LoadBalancer))
 	}
-	if !c.NoCollectorLoadBalancer {
-		r.MustRegister(collector.NewLocalRouterCollector(ctx, logger, errs, client.LocalRouter))
+	if !c.NoCollectorLocalRouter {
+		register("local_router", collector.NewLocalRouterCollector(ctx, instrumentation.Logger("local_router", logger), errs, opts, client.LocalRouter))
 	}
 	if !c.NoCollectorNFS {
-		r.MustRegister(collector.NewNFSCollector(ctx, logger, errs, client.NFS))
+		register("nfs", collector.NewNFSCollector(ctx, instrumentation.Logger("nfs", logger), errs, opts, client.NFS))
 	}
 	if !c.NoCollectorMobileGateway {
-		r.MustRegister(collector.NewMobileGatewayCollector(ctx, logger, errs, client.MobileGateway))
+		register("mobile_gateway", collector.NewMobileGatewayCollector(ctx, instrumentation.Logger("mobile_gateway", logger), errs, opts, client.MobileGateway))
 	}
 	if !c.NoCollectorProxyLB {
-		r.MustRegister(collector.NewProxyLBCollector(ctx, logger, errs, client.ProxyLB))
+		register("proxylb", collector.NewCachingCollector(collector.NewProxyLBCollector(ctx, instrumentation.Logger("proxylb", logger), errs, opts, client.ProxyLB, c.ProxyLBCertSANLimit, c.ProxyLBProbe), c.CollectorIntervalProxyLB))
 	}
 	if !c.NoCollectorServer {
-		r.MustRegister(collector.NewServerCollector(ctx, logger, errs, client.Server, c.NoCollectorServerExceptMaintenance))
+		register("server", collector.NewServerCollector(ctx, instrumentation.Logger("server", logger), errs, opts, serverClient, c.NoCollectorServerExceptMaintenance, c.ServerTransitionStuckThreshold))
+	}
+	if !c.NoCollectorServerHygiene {
+		register("server_hygiene", collector.NewServerHygieneCollector(ctx, instrumentation.Logger("server_hygiene", logger), errs, opts, serverClient, autoBackupClient))
+	}
+	if !c.NoCollectorSimpleMonitor {
+		register("simple_monitor", collector.NewSimpleMonitorCollector(ctx, instrumentation.Logger("simple_monitor", logger), errs, opts, client.SimpleMonitor, c.SimpleMonitorAvailabilityWindow))
 	}
 	if !c.NoCollectorSIM {
-		r.MustRegister(collector.NewSIMCollector(ctx, logger, errs, client.SIM))
+		register("sim", collector.NewSIMCollector(ctx, instrumentation.Logger("sim", logger), errs, opts, client.SIM))
 	}
 	if !c.NoCollectorVPCRouter {
-		r.MustRegister(collector.NewVPCRouterCollector(ctx, logger, errs, client.VPCRouter))
+		register("vpc_router", collector.NewVPCRouterCollector(ctx, instrumentation.Logger("vpc_router", logger), errs, opts, client.VPCRouter, c.VPCRouterSessionDetails))
 	}
 	if !c.NoCollectorZone {
-		r.MustRegister(collector.NewZoneCollector(ctx, logger, errs, client.Zone))
+		register("zone", collector.NewZoneCollector(ctx, instrumentation.Logger("zone", logger), errs, opts, client.Zone))
 	}
-	if !c.NoCollectorWebAccel {
-		r.MustRegister(collector.NewWebAccelCollector(ctx, logger, errs, client.WebAccel))
+	if !c.NoCollectorWebAccel && client.WebAccel != nil {
+		register("webaccel", collector.NewCachingCollector(collector.NewWebAccelCollector(ctx, instrumentation.Logger("webaccel", logger), errs, opts, client.WebAccel), c.CollectorIntervalWebAccel))
 	}
+}
 
-	http.Handle(c.WebPath,
-		promhttp.HandlerFor(r, promhttp.HandlerOpts{}),
-	)
+// newServeMux returns the ServeMux which serves the metrics, health, readiness and index pages.
+// Paths are validated in config.InitConfig so that they don't collide with each other.
+func newServeMux(c config.Config, g prometheus.Gatherer, scrapes *collector.ContextRegistry, logger *slog.Logger) *http.ServeMux {
+	mux := http.NewServeMux()
+	metricsHandler := newMetricsHandler(g, scrapes, c.MaxConcurrentScrapes)
+	if c.APIRequestIDHeader != "" {
+		metricsHandler = newRequestIDHandler(metricsHandler, logger)
+	}
+	mux.Handle(c.WebPath, metricsHandler)
+	mux.HandleFunc(c.WebHealthPath, okHandler)
+	mux.HandleFunc(c.WebReadinessPath, okHandler)
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(`<html>
 			<head><title>SakuraCloud Exporter</title></head>
 			<body>
@@ -157,11 +339,49 @@ func main() {
 			</body>
 			</html>`))
 	})
+	return mux
+}
 
-	logger.Info("listening", slog.String("addr", c.WebAddr))
-	if err := http.ListenAndServe(c.WebAddr, nil); err != nil { //nolint
-		cancel()
-		logger.Error("http listenandserve error", slog.Any("err", err))
-		os.Exit(2)
+// okHandler responds 200 OK.
+// The HTTP server is started after the API key is validated, so the exporter is healthy and ready once it is listening.
+func okHandler(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("OK"))
+}
+
+// newRequestIDHandler returns the handler which generates the correlation ID sent with the API calls per scrape.
+// The ID is stored in the context of the scrape request.
+func newRequestIDHandler(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := platform.NewRequestID()
+		if err != nil {
+			logger.Warn("can't generate the API request ID", slog.Any("err", err))
+		} else {
+			logger.Info("scrape started", slog.String("request_id", id))
+			r = r.WithContext(platform.WithRequestID(r.Context(), id))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newMetricsHandler returns the handler for the metrics path.
+// The metrics of scrapes are collected with the context of each request in addition to the metrics of g.
+// If maxConcurrentScrapes is greater than 0, requests exceeding it are responded with 503.
+func newMetricsHandler(g prometheus.Gatherer, scrapes *collector.ContextRegistry, maxConcurrentScrapes int) http.Handler {
+	// the limit is shared by the requests, so it can't be left to promhttp.HandlerOpts of the handler created per request
+	var inFlight chan struct{}
+	if maxConcurrentScrapes > 0 {
+		inFlight = make(chan struct{}, maxConcurrentScrapes)
 	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if inFlight != nil {
+			select {
+			case inFlight <- struct{}{}:
+				defer func() { <-inFlight }()
+			default:
+				http.Error(w, fmt.Sprintf("Limit of concurrent requests reached (%d), try again later.", maxConcurrentScrapes), http.StatusServiceUnavailable)
+				return
+			}
+		}
+		promhttp.HandlerFor(prometheus.Gatherers{g, scrapes.Gatherer(r.Context())}, promhttp.HandlerOpts{}).ServeHTTP(w, r)
+	})
 }
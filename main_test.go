@@ -0,0 +1,353 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/sacloud/sakuracloud_exporter/collector"
+	"github.com/sacloud/sakuracloud_exporter/config"
+	"github.com/sacloud/sakuracloud_exporter/platform"
+	"github.com/stretchr/testify/require"
+)
+
+type blockingGatherer struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (g *blockingGatherer) Gather() ([]*dto.MetricFamily, error) {
+	g.started <- struct{}{}
+	<-g.release
+	return nil, nil
+}
+
+func TestNewMetricsHandler_MaxConcurrentScrapes(t *testing.T) {
+	const maxConcurrentScrapes = 2
+
+	g := &blockingGatherer{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	handler := newMetricsHandler(g, collector.NewContextRegistry(), maxConcurrentScrapes)
+
+	var wg sync.WaitGroup
+	codes := make(chan int, maxConcurrentScrapes)
+	for i := 0; i < maxConcurrentScrapes; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			codes <- rec.Code
+		}()
+	}
+	for i := 0; i < maxConcurrentScrapes; i++ {
+		<-g.started
+	}
+
+	// exceeds the cap while other scrapes are in flight
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	close(g.release)
+	wg.Wait()
+	close(codes)
+	for code := range codes {
+		require.Equal(t, http.StatusOK, code)
+	}
+}
+
+func TestNewMetricsHandler_Unlimited(t *testing.T) {
+	handler := newMetricsHandler(prometheus.NewRegistry(), collector.NewContextRegistry(), 0)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+}
+
+// requestIDRecordingCollector records the correlation ID of the context passed to CollectWithContext
+type requestIDRecordingCollector struct {
+	mu         sync.Mutex
+	requestIDs []string
+}
+
+func (c *requestIDRecordingCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (c *requestIDRecordingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.CollectWithContext(context.Background(), ch)
+}
+
+func (c *requestIDRecordingCollector) CollectWithContext(ctx context.Context, _ chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requestIDs = append(c.requestIDs, platform.RequestIDFromContext(ctx))
+}
+
+func TestNewRequestIDHandler(t *testing.T) {
+	recorder := &requestIDRecordingCollector{}
+	scrapes := collector.NewContextRegistry()
+	scrapes.MustRegister(nil, recorder)
+
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := newRequestIDHandler(newMetricsHandler(prometheus.NewRegistry(), scrapes, 0), logger)
+
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+	}
+
+	// each scrape is collected with its own ID
+	require.Len(t, recorder.requestIDs, 2)
+	require.NotEmpty(t, recorder.requestIDs[0])
+	require.NotEmpty(t, recorder.requestIDs[1])
+	require.NotEqual(t, recorder.requestIDs[0], recorder.requestIDs[1])
+}
+
+func TestNewServeMux_CustomPaths(t *testing.T) {
+	mux := newServeMux(config.Config{
+		WebPath:          "/custom-metrics",
+		WebHealthPath:    "/-/healthy",
+		WebReadinessPath: "/-/ready",
+	}, prometheus.NewRegistry(), collector.NewContextRegistry(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	cases := []struct {
+		path     string
+		wantBody string
+	}{
+		{path: "/-/healthy", wantBody: "OK"},
+		{path: "/-/ready", wantBody: "OK"},
+		{path: "/custom-metrics", wantBody: ""},
+	}
+	for _, tc := range cases {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		require.Equal(t, http.StatusOK, rec.Code, tc.path)
+		require.Equal(t, tc.wantBody, rec.Body.String(), tc.path)
+	}
+
+	// the index page links to the metrics path
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.Contains(t, rec.Body.String(), `href="/custom-metrics"`)
+}
+
+func TestNewListener_UnixSocket(t *testing.T) {
+	c := config.Config{
+		WebPath:          "/metrics",
+		WebHealthPath:    "/healthz",
+		WebReadinessPath: "/readyz",
+		WebSocketPath:    filepath.Join(t.TempDir(), "exporter.sock"),
+	}
+	listener, err := newListener(c)
+	require.NoError(t, err)
+
+	server := &http.Server{Handler: newServeMux(c, prometheus.NewRegistry(), collector.NewContextRegistry(), slog.New(slog.NewTextHandler(io.Discard, nil)))} //nolint
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(listener) }()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", c.WebSocketPath)
+			},
+		},
+	}
+	res, err := client.Get("http://unix/metrics")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	// the socket file is removed on shutdown
+	require.NoError(t, server.Shutdown(context.Background()))
+	require.ErrorIs(t, <-done, http.ErrServerClosed)
+	_, err = os.Stat(c.WebSocketPath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestNewListener_StaleUnixSocket(t *testing.T) {
+	c := config.Config{
+		WebSocketPath: filepath.Join(t.TempDir(), "exporter.sock"),
+	}
+
+	// a socket file left behind by a crashed process
+	stale, err := net.Listen("unix", c.WebSocketPath)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	listener, err := newListener(c)
+	require.NoError(t, err)
+
+	// the socket in use is not removed
+	_, err = newListener(c)
+	require.Error(t, err)
+	require.NoError(t, listener.Close())
+
+	// files other than sockets are not removed
+	require.NoError(t, os.WriteFile(c.WebSocketPath, nil, 0600))
+	_, err = newListener(c)
+	require.Error(t, err)
+	_, err = os.Stat(c.WebSocketPath)
+	require.NoError(t, err)
+}
+
+// describingRegisterer records the names of the metrics described by the registered collectors
+type describingRegisterer struct {
+	names []string
+}
+
+func (r *describingRegisterer) Register(c prometheus.Collector) error {
+	r.record(c)
+	return nil
+}
+
+// record records the names of the metrics described by d, such as collector.ContextRegistry
+func (r *describingRegisterer) record(d interface{ Describe(chan<- *prometheus.Desc) }) {
+	ch := make(chan *prometheus.Desc)
+	go func() {
+		d.Describe(ch)
+		close(ch)
+	}()
+	for desc := range ch {
+		// Desc.String() is formatted as: Desc{fqName: "name", ...}
+		name, _, _ := strings.Cut(strings.TrimPrefix(desc.String(), `Desc{fqName: "`), `"`)
+		r.names = append(r.names, name)
+	}
+}
+
+func (r *describingRegisterer) MustRegister(cs ...prometheus.Collector) {
+	for _, c := range cs {
+		r.Register(c) //nolint:errcheck
+	}
+}
+
+func (r *describingRegisterer) Unregister(prometheus.Collector) bool {
+	return true
+}
+
+func TestRegisterCollectors_SelfMetricPrefix(t *testing.T) {
+	c := config.Config{
+		Token:            "dummy",
+		Secret:           "dummy",
+		Zones:            []string{"is1a"},
+		RateLimit:        10,
+		SelfMetricPrefix: "custom_",
+	}
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	client, err := platform.NewSakuraCloudClient(c, "test")
+	require.NoError(t, err)
+
+	r := &describingRegisterer{}
+	scrapes := collector.NewContextRegistry()
+	registerExporterCollectors(ctx, logger, r, c)
+	registerSakuraCloudCollectors(ctx, logger, r, scrapes, nil, c, client)
+	r.record(scrapes)
+
+	// self-metrics are renamed
+	require.Contains(t, r.names, "custom_sakuracloud_exporter_errors_total")
+	require.Contains(t, r.names, "custom_sakuracloud_exporter_start_time")
+	require.Contains(t, r.names, "custom_sakuracloud_exporter_api_request_duration_seconds")
+	require.Contains(t, r.names, "custom_sakuracloud_exporter_collector_ready")
+	require.NotContains(t, r.names, "sakuracloud_exporter_errors_total")
+
+	// resource metrics are not
+	require.Contains(t, r.names, "sakuracloud_server_up")
+	require.NotContains(t, r.names, "custom_sakuracloud_server_up")
+}
+
+func TestRegisterSakuraCloudCollectors_LocalRouter(t *testing.T) {
+	cases := []struct {
+		name                    string
+		noCollectorLoadBalancer bool
+		noCollectorLocalRouter  bool
+		wantLoadBalancer        bool
+		wantLocalRouter         bool
+	}{
+		{name: "default", wantLoadBalancer: true, wantLocalRouter: true},
+		{name: "load balancer disabled", noCollectorLoadBalancer: true, wantLocalRouter: true},
+		{name: "local router disabled", noCollectorLocalRouter: true, wantLoadBalancer: true},
+	}
+	for _, tc := range cases {
+		c := config.Config{
+			Token:                   "dummy",
+			Secret:                  "dummy",
+			Zones:                   []string{"is1a"},
+			RateLimit:               10,
+			NoCollectorLoadBalancer: tc.noCollectorLoadBalancer,
+			NoCollectorLocalRouter:  tc.noCollectorLocalRouter,
+		}
+		ctx := context.Background()
+		logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+		client, err := platform.NewSakuraCloudClient(c, "test")
+		require.NoError(t, err)
+
+		r := &describingRegisterer{}
+		scrapes := collector.NewContextRegistry()
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, nil, c, client)
+		r.record(scrapes)
+
+		require.Equal(t, tc.wantLoadBalancer, slices.Contains(r.names, "sakuracloud_loadbalancer_up"), tc.name)
+		require.Equal(t, tc.wantLocalRouter, slices.Contains(r.names, "sakuracloud_local_router_up"), tc.name)
+	}
+}
+
+func TestRegisterSakuraCloudCollectors_Secondary(t *testing.T) {
+	c := config.Config{
+		Token:               "dummy",
+		Secret:              "dummy",
+		Zones:               []string{"is1a"},
+		SecondaryAPIRootURL: "https://secondary.example.com/cloud/zone",
+		SecondaryZones:      []string{"tk1a"},
+		RateLimit:           10,
+	}
+	ctx := context.Background()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	client, err := platform.NewSakuraCloudClient(c, "test")
+	require.NoError(t, err)
+	secondaryClient, err := platform.NewSecondarySakuraCloudClient(c, "test")
+	require.NoError(t, err)
+
+	// registering the collectors for both APIs must not cause duplicate registration errors
+	r := prometheus.NewRegistry()
+	require.NotPanics(t, func() {
+		scrapes := collector.NewContextRegistry()
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, prometheus.Labels{"source": "primary"}, c, client)
+		registerSakuraCloudCollectors(ctx, logger, r, scrapes, prometheus.Labels{"source": "secondary"}, c, secondaryClient)
+	})
+
+	// the self-metrics of the API calls are registered per API
+	require.NotSame(t, client.Metrics, secondaryClient.Metrics)
+}
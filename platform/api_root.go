@@ -0,0 +1,56 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// apiRootRoundTripper is a http.RoundTripper which sends requests for the API root URL to another root URL
+//
+// iaas.SakuraCloudAPIRoot is a package-level variable shared by all callers,
+// so the secondary API root is applied by rewriting requests instead.
+type apiRootRoundTripper struct {
+	transport http.RoundTripper
+	rootURL   string
+}
+
+func newAPIRootRoundTripper(transport http.RoundTripper, rootURL string) *apiRootRoundTripper {
+	return &apiRootRoundTripper{
+		transport: transport,
+		rootURL:   strings.TrimRight(rootURL, "/"),
+	}
+}
+
+func (r *apiRootRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	original := req.URL.String()
+	if !strings.HasPrefix(original, iaas.SakuraCloudAPIRoot) {
+		return r.transport.RoundTrip(req)
+	}
+
+	u, err := url.Parse(r.rootURL + strings.TrimPrefix(original, iaas.SakuraCloudAPIRoot))
+	if err != nil {
+		return nil, err
+	}
+	// RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	req.URL = u
+	req.Host = u.Host
+	return r.transport.RoundTrip(req)
+}
@@ -0,0 +1,44 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIRootRoundTripper(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newAPIRootRoundTripper(http.DefaultTransport, server.URL+"/secondary/")}
+
+	res, err := client.Get(iaas.SakuraCloudAPIRoot + "/is1a/api/cloud/1.1/server")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	res, err = client.Get(server.URL + "/other")
+	require.NoError(t, err)
+	res.Body.Close()
+
+	require.Equal(t, []string{"/secondary/is1a/api/cloud/1.1/server", "/other"}, requested)
+}
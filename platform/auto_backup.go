@@ -17,6 +17,7 @@ package platform
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/search"
@@ -29,14 +30,16 @@ type AutoBackupClient interface {
 	ListBackups(ctx context.Context, zone string, autoBackupID types.ID) ([]*iaas.Archive, error)
 }
 
-func getAutoBackupClient(caller iaas.APICaller, zones []string) AutoBackupClient {
+func getAutoBackupClient(caller iaas.APICaller, zones []string, metrics *Metrics) AutoBackupClient {
 	return &autoBackupClient{
-		caller: caller,
+		caller:  caller,
+		metrics: metrics,
 	}
 }
 
 type autoBackupClient struct {
-	caller iaas.APICaller
+	caller  iaas.APICaller
+	metrics *Metrics
 }
 
 func (c *autoBackupClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -55,6 +58,7 @@ func (c *autoBackupClient) find(ctx context.Context, zone string) ([]interface{}
 }
 
 func (c *autoBackupClient) Find(ctx context.Context) ([]*iaas.AutoBackup, error) {
+	defer c.metrics.observeAPIRequest("auto_backup", "Find", time.Now())
 	res, err := c.find(ctx, "is1a")
 	if err != nil {
 		return nil, err
@@ -67,6 +71,7 @@ func (c *autoBackupClient) Find(ctx context.Context) ([]*iaas.AutoBackup, error)
 }
 
 func (c *autoBackupClient) ListBackups(ctx context.Context, zone string, autoBackupID types.ID) ([]*iaas.Archive, error) {
+	defer c.metrics.observeAPIRequest("auto_backup", "ListBackups", time.Now())
 	client := iaas.NewArchiveOp(c.caller)
 	tagName := fmt.Sprintf("autobackup-%d", autoBackupID)
 
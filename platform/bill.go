@@ -35,10 +35,11 @@ type BillClient interface {
 	Read(context.Context) (*iaas.Bill, error)
 }
 
-func getBillClient(caller iaas.APICaller) BillClient {
+func getBillClient(caller iaas.APICaller, metrics *Metrics) BillClient {
 	return &billClient{
-		caller: caller,
-		cache:  newCache(30 * time.Minute),
+		caller:  caller,
+		cache:   newCache(30 * time.Minute),
+		metrics: metrics,
 	}
 }
 
@@ -47,6 +48,7 @@ type billClient struct {
 	accountID types.ID
 	once      sync.Once
 	cache     *cache
+	metrics   *Metrics
 }
 
 func (c *billClient) Read(ctx context.Context) (*iaas.Bill, error) {
@@ -54,6 +56,7 @@ func (c *billClient) Read(ctx context.Context) (*iaas.Bill, error) {
 	if ca != nil {
 		return ca.(*iaas.Bill), nil
 	}
+	defer c.metrics.observeAPIRequest("bill", "Read", time.Now())
 
 	var err error
 	c.once.Do(func() {
@@ -0,0 +1,74 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+type BridgeClient interface {
+	Find(ctx context.Context) ([]*iaas.Bridge, error)
+}
+
+func getBridgeClient(caller iaas.APICaller, zones []string, metrics *Metrics) BridgeClient {
+	return &bridgeClient{
+		client:  iaas.NewBridgeOp(caller),
+		zones:   zones,
+		metrics: metrics,
+	}
+}
+
+type bridgeClient struct {
+	client  iaas.BridgeAPI
+	zones   []string
+	metrics *Metrics
+}
+
+func (c *bridgeClient) find(ctx context.Context, zone string) ([]interface{}, error) {
+	var results []interface{}
+	res, err := c.client.Find(ctx, zone, &iaas.FindCondition{
+		Count: 10000,
+	})
+	if err != nil {
+		return results, err
+	}
+	for _, bridge := range res.Bridges {
+		results = append(results, bridge)
+	}
+	return results, err
+}
+
+// Find returns the bridges found in the zones.
+//
+// A bridge connects switches across zones and is listed in each of them, so the duplicates are removed.
+func (c *bridgeClient) Find(ctx context.Context) ([]*iaas.Bridge, error) {
+	defer c.metrics.observeAPIRequest("bridge", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "bridge", c.zones, c.find)
+	var results []*iaas.Bridge
+	found := make(map[types.ID]bool)
+	for _, s := range res {
+		bridge := s.(*iaas.Bridge)
+		if found[bridge.ID] {
+			continue
+		}
+		found[bridge.ID] = true
+		results = append(results, bridge)
+	}
+	return results, err
+}
@@ -0,0 +1,61 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+type CertificateAuthorityClient interface {
+	Find(ctx context.Context) ([]*iaas.CertificateAuthority, error)
+	ListServers(ctx context.Context, id types.ID) ([]*iaas.CertificateAuthorityServer, error)
+}
+
+func getCertificateAuthorityClient(caller iaas.APICaller, metrics *Metrics) CertificateAuthorityClient {
+	return &certificateAuthorityClient{
+		client:  iaas.NewCertificateAuthorityOp(caller),
+		metrics: metrics,
+	}
+}
+
+type certificateAuthorityClient struct {
+	client  iaas.CertificateAuthorityAPI
+	metrics *Metrics
+}
+
+func (c *certificateAuthorityClient) Find(ctx context.Context) ([]*iaas.CertificateAuthority, error) {
+	defer c.metrics.observeAPIRequest("certificate_authority", "Find", time.Now())
+	var results []*iaas.CertificateAuthority
+	res, err := c.client.Find(ctx, &iaas.FindCondition{
+		Count: 10000,
+	})
+	if err != nil {
+		return results, err
+	}
+	return res.CertificateAuthorities, nil
+}
+
+func (c *certificateAuthorityClient) ListServers(ctx context.Context, id types.ID) ([]*iaas.CertificateAuthorityServer, error) {
+	defer c.metrics.observeAPIRequest("certificate_authority", "ListServers", time.Now())
+	res, err := c.client.ListServers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return res.CertificateAuthority, nil
+}
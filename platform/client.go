@@ -17,38 +17,94 @@ package platform
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 
 	client "github.com/sacloud/api-client-go"
+	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/fake"
 	"github.com/sacloud/iaas-api-go/helper/api"
+	"github.com/sacloud/iaas-api-go/types"
 	"github.com/sacloud/sakuracloud_exporter/config"
 	"github.com/sacloud/webaccel-api-go"
 )
 
 type Client struct {
-	authStatus    authStatusClient
-	AutoBackup    AutoBackupClient
-	Bill          BillClient
-	Coupon        CouponClient
-	Database      DatabaseClient
-	ESME          ESMEClient
-	Internet      InternetClient
-	LoadBalancer  LoadBalancerClient
-	LocalRouter   LocalRouterClient
-	MobileGateway MobileGatewayClient
-	NFS           NFSClient
-	ProxyLB       ProxyLBClient
-	Server        ServerClient
-	SIM           SIMClient
-	VPCRouter     VPCRouterClient
-	Zone          ZoneClient
+	authStatus           authStatusClient
+	AutoBackup           AutoBackupClient
+	Bill                 BillClient
+	Bridge               BridgeClient
+	CertificateAuthority CertificateAuthorityClient
+	Coupon               CouponClient
+	Database             DatabaseClient
+	DNS                  DNSClient
+	ESME                 ESMEClient
+	GSLB                 GSLBClient
+	Internet             InternetClient
+	LoadBalancer         LoadBalancerClient
+	LocalRouter          LocalRouterClient
+	MobileGateway        MobileGatewayClient
+	NFS                  NFSClient
+	ProxyLB              ProxyLBClient
+	Server               ServerClient
+	SimpleMonitor        SimpleMonitorClient
+	SIM                  SIMClient
+	VPCRouter            VPCRouterClient
+	Zone                 ZoneClient
 
 	WebAccel WebAccelClient
+
+	// Metrics are the self-metrics of the API calls issued by this client
+	Metrics *Metrics
+}
+
+func NewSakuraCloudClient(c config.Config, version string) (*Client, error) {
+	transport, err := newAPITransport(c.APICAFile)
+	if err != nil {
+		return nil, err
+	}
+	if c.APIRequestIDHeader != "" {
+		transport = newRequestIDRoundTripper(transport, c.APIRequestIDHeader)
+	}
+	metrics := newMetrics()
+	return newSakuraCloudClient(c, c.Zones, newRateLimitedHTTPClient(transport, c.RateLimit, metrics.RateLimitWaitSeconds), transport, version, metrics), nil
+}
+
+// NewSecondarySakuraCloudClient returns the Client for the secondary API specified by --secondary-api-root-url and --secondary-zones.
+//
+// WebAccel is nil because the WebAccel API is not served under the (secondary) API root URL.
+func NewSecondarySakuraCloudClient(c config.Config, version string) (*Client, error) {
+	transport, err := newAPITransport(c.APICAFile)
+	if err != nil {
+		return nil, err
+	}
+	if c.APIRequestIDHeader != "" {
+		transport = newRequestIDRoundTripper(transport, c.APIRequestIDHeader)
+	}
+	metrics := newMetrics()
+	httpClient := newRateLimitedHTTPClient(transport, c.RateLimit, metrics.RateLimitWaitSeconds)
+	if c.SecondaryAPIRootURL != "" {
+		httpClient.Transport = newAPIRootRoundTripper(httpClient.Transport, c.SecondaryAPIRootURL)
+	}
+
+	client := newSakuraCloudClient(c, c.SecondaryZones, httpClient, transport, version, metrics)
+	client.WebAccel = nil
+	return client, nil
 }
 
-func NewSakuraCloudClient(c config.Config, version string) *Client {
+// SDKVersion is the version of iaas-api-go used for calling SakuraCloud API
+const SDKVersion = iaas.Version
+
+// UserAgent returns the User-Agent sent to SakuraCloud API
+func UserAgent(version string) string {
+	return fmt.Sprintf("sakuracloud_exporter/%s", version)
+}
+
+// newSakuraCloudClient returns the Client calling the API via httpClient
+//
+// The WebAccel API is called via its own rate limited client built on the base transport.
+func newSakuraCloudClient(c config.Config, zones []string, httpClient *http.Client, transport http.RoundTripper, version string, metrics *Metrics) *Client {
 	fakeStorePath := c.FakeMode
 	if stat, err := os.Stat(fakeStorePath); err == nil {
 		if stat.IsDir() {
@@ -59,8 +115,9 @@ func NewSakuraCloudClient(c config.Config, version string) *Client {
 		Options: &client.Options{
 			AccessToken:          c.Token,
 			AccessTokenSecret:    c.Secret,
-			HttpRequestRateLimit: c.RateLimit,
-			UserAgent:            fmt.Sprintf("sakuracloud_exporter/%s", version),
+			HttpClient:           httpClient,
+			HttpRequestRateLimit: sdkRateLimitDisabled,
+			UserAgent:            UserAgent(version),
 			Trace:                c.Trace,
 		},
 		TraceAPI:      c.Debug,
@@ -75,31 +132,39 @@ func NewSakuraCloudClient(c config.Config, version string) *Client {
 		Options: &client.Options{
 			AccessToken:          c.Token,
 			AccessTokenSecret:    c.Secret,
-			HttpRequestRateLimit: c.RateLimit,
-			UserAgent:            fmt.Sprintf("sakuracloud_exporter/%s", version),
+			HttpClient:           newRateLimitedHTTPClient(transport, c.RateLimit, metrics.RateLimitWaitSeconds),
+			HttpRequestRateLimit: sdkRateLimitDisabled,
+			UserAgent:            UserAgent(version),
 			Trace:                c.Trace,
 		},
 	}
 
 	return &Client{
-		authStatus:    getAuthStatusClient(caller),
-		AutoBackup:    getAutoBackupClient(caller, c.Zones),
-		Bill:          getBillClient(caller),
-		Coupon:        getCouponClient(caller),
-		Database:      getDatabaseClient(caller, c.Zones),
-		ESME:          getESMEClient(caller),
-		Internet:      getInternetClient(caller, c.Zones),
-		LoadBalancer:  getLoadBalancerClient(caller, c.Zones),
-		LocalRouter:   getLocalRouterClient(caller),
-		MobileGateway: getMobileGatewayClient(caller, c.Zones),
-		NFS:           getNFSClient(caller, c.Zones),
-		ProxyLB:       getProxyLBClient(caller),
-		Server:        getServerClient(caller, c.Zones),
-		SIM:           getSIMClient(caller),
-		VPCRouter:     getVPCRouterClient(caller, c.Zones),
-		Zone:          getZoneClient(caller),
-
-		WebAccel: getWebAccelClient(webaccelCaller),
+		authStatus:           getAuthStatusClient(caller),
+		AutoBackup:           getAutoBackupClient(caller, zones, metrics),
+		Bill:                 getBillClient(caller, metrics),
+		Bridge:               getBridgeClient(caller, zones, metrics),
+		CertificateAuthority: getCertificateAuthorityClient(caller, metrics),
+		Coupon:               getCouponClient(caller, metrics),
+		Database:             getDatabaseClient(caller, zones, metrics),
+		DNS:                  getDNSClient(caller, metrics),
+		ESME:                 getESMEClient(caller, metrics),
+		GSLB:                 getGSLBClient(caller, metrics),
+		Internet:             getInternetClient(caller, zones, metrics),
+		LoadBalancer:         getLoadBalancerClient(caller, zones, metrics),
+		LocalRouter:          getLocalRouterClient(caller, metrics),
+		MobileGateway:        getMobileGatewayClient(caller, zones, metrics),
+		NFS:                  getNFSClient(caller, zones, metrics),
+		ProxyLB:              getProxyLBClient(caller, metrics),
+		Server:               getServerClient(caller, zones, metrics),
+		SimpleMonitor:        getSimpleMonitorClient(caller, metrics),
+		SIM:                  getSIMClient(caller, metrics),
+		VPCRouter:            getVPCRouterClient(caller, zones, metrics),
+		Zone:                 getZoneClient(caller, metrics),
+
+		WebAccel: getWebAccelClient(webaccelCaller, metrics),
+
+		Metrics: metrics,
 	}
 }
 
@@ -116,3 +181,40 @@ func (c *Client) HasWebAccelPermission(ctx context.Context) bool {
 
 	return res.ExternalPermission.PermittedWebAccel()
 }
+
+// apiPermissions is the list of the permissions reported by APIPermissions with the checks for each of them
+var apiPermissions = []struct {
+	name      string
+	permitted func(p *types.ExternalPermission) bool
+}{
+	{name: "apprun", permitted: (*types.ExternalPermission).PermittedAppRun},
+	{name: "billing", permitted: (*types.ExternalPermission).PermittedBill},
+	{name: "eventlog", permitted: (*types.ExternalPermission).PermittedEventLog},
+	{name: "koukaryoku_dok", permitted: (*types.ExternalPermission).PermittedKoukaryokuDOK},
+	{name: "object_storage", permitted: (*types.ExternalPermission).PermittedObjectStorage},
+	{name: "phy", permitted: (*types.ExternalPermission).PermittedPHY},
+	{name: "webaccel", permitted: (*types.ExternalPermission).PermittedWebAccel},
+}
+
+// APIPermissions returns the names of the permissions for the external services the API key holds
+func (c *Client) APIPermissions(ctx context.Context) []string {
+	res, err := c.authStatus.Read(ctx)
+	if res == nil || err != nil {
+		return nil
+	}
+
+	var permissions []string
+	for _, p := range apiPermissions {
+		if p.permitted(&res.ExternalPermission) {
+			permissions = append(permissions, p.name)
+		}
+	}
+	return permissions
+}
+
+// SetAPIPermissions sets the permissions the API key holds to Metrics.APIPermission
+func (c *Client) SetAPIPermissions(ctx context.Context) {
+	for _, permission := range c.APIPermissions(ctx) {
+		c.Metrics.APIPermission.WithLabelValues(permission).Set(1)
+	}
+}
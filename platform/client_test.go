@@ -0,0 +1,98 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyAuthStatusClient struct {
+	authStatus *iaas.AuthStatus
+	err        error
+}
+
+func (d *dummyAuthStatusClient) Read(context.Context) (*iaas.AuthStatus, error) {
+	return d.authStatus, d.err
+}
+
+func TestClient_APIPermissions(t *testing.T) {
+	cases := []struct {
+		name string
+		in   authStatusClient
+		want []string
+	}{
+		{
+			name: "a key with a subset of permissions",
+			in: &dummyAuthStatusClient{
+				authStatus: &iaas.AuthStatus{
+					ExternalPermission: types.ExternalPermission("bill+cdn"),
+				},
+			},
+			want: []string{"billing", "webaccel"},
+		},
+		{
+			name: "a key without permissions",
+			in: &dummyAuthStatusClient{
+				authStatus: &iaas.AuthStatus{},
+			},
+			want: nil,
+		},
+		{
+			name: "auth status API returns error",
+			in: &dummyAuthStatusClient{
+				err: errors.New("dummy"),
+			},
+			want: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Client{authStatus: tc.in}
+			require.Equal(t, tc.want, c.APIPermissions(context.Background()))
+		})
+	}
+}
+
+func TestClient_SetAPIPermissions(t *testing.T) {
+	primary := &Client{
+		authStatus: &dummyAuthStatusClient{
+			authStatus: &iaas.AuthStatus{ExternalPermission: types.ExternalPermission("bill")},
+		},
+		Metrics: newMetrics(),
+	}
+	secondary := &Client{
+		authStatus: &dummyAuthStatusClient{
+			authStatus: &iaas.AuthStatus{ExternalPermission: types.ExternalPermission("cdn")},
+		},
+		Metrics: newMetrics(),
+	}
+
+	primary.SetAPIPermissions(context.Background())
+	secondary.SetAPIPermissions(context.Background())
+
+	// the permissions of each API key are reported to the metrics of its own client
+	require.Equal(t, 1, testutil.CollectAndCount(primary.Metrics.APIPermission))
+	require.Equal(t, float64(1), testutil.ToFloat64(primary.Metrics.APIPermission.WithLabelValues("billing")))
+	require.Equal(t, 1, testutil.CollectAndCount(secondary.Metrics.APIPermission))
+	require.Equal(t, float64(1), testutil.ToFloat64(secondary.Metrics.APIPermission.WithLabelValues("webaccel")))
+}
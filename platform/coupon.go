@@ -29,10 +29,11 @@ type CouponClient interface {
 	Find(context.Context) ([]*iaas.Coupon, error)
 }
 
-func getCouponClient(caller iaas.APICaller) CouponClient {
+func getCouponClient(caller iaas.APICaller, metrics *Metrics) CouponClient {
 	return &couponClient{
-		caller: caller,
-		cache:  *newCache(30 * time.Minute),
+		caller:  caller,
+		cache:   *newCache(30 * time.Minute),
+		metrics: metrics,
 	}
 }
 
@@ -41,6 +42,7 @@ type couponClient struct {
 	accountID types.ID
 	once      sync.Once
 	cache     cache
+	metrics   *Metrics
 }
 
 func (c *couponClient) Find(ctx context.Context) ([]*iaas.Coupon, error) {
@@ -48,6 +50,7 @@ func (c *couponClient) Find(ctx context.Context) ([]*iaas.Coupon, error) {
 	if ca != nil {
 		return ca.([]*iaas.Coupon), nil
 	}
+	defer c.metrics.observeAPIRequest("coupon", "Find", time.Now())
 
 	var err error
 	c.once.Do(func() {
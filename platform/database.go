@@ -37,16 +37,18 @@ type DatabaseClient interface {
 	MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error)
 }
 
-func getDatabaseClient(caller iaas.APICaller, zones []string) DatabaseClient {
+func getDatabaseClient(caller iaas.APICaller, zones []string, metrics *Metrics) DatabaseClient {
 	return &databaseClient{
-		client: iaas.NewDatabaseOp(caller),
-		zones:  zones,
+		client:  iaas.NewDatabaseOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type databaseClient struct {
-	client iaas.DatabaseAPI
-	zones  []string
+	client  iaas.DatabaseAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *databaseClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -67,18 +69,17 @@ func (c *databaseClient) find(ctx context.Context, zone string) ([]interface{},
 }
 
 func (c *databaseClient) Find(ctx context.Context) ([]*Database, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("database", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "database", c.zones, c.find)
 	var results []*Database
 	for _, s := range res {
 		results = append(results, s.(*Database))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *databaseClient) MonitorDatabase(ctx context.Context, zone string, databaseID types.ID, end time.Time) (*iaas.MonitorDatabaseValue, error) {
+	defer c.metrics.observeAPIRequest("database", "MonitorDatabase", time.Now())
 	mvs, err := c.client.MonitorDatabase(ctx, zone, databaseID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -87,6 +88,7 @@ func (c *databaseClient) MonitorDatabase(ctx context.Context, zone string, datab
 }
 
 func (c *databaseClient) MonitorCPU(ctx context.Context, zone string, databaseID types.ID, end time.Time) (*iaas.MonitorCPUTimeValue, error) {
+	defer c.metrics.observeAPIRequest("database", "MonitorCPU", time.Now())
 	mvs, err := c.client.MonitorCPU(ctx, zone, databaseID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -95,6 +97,7 @@ func (c *databaseClient) MonitorCPU(ctx context.Context, zone string, databaseID
 }
 
 func (c *databaseClient) MonitorDisk(ctx context.Context, zone string, databaseID types.ID, end time.Time) (*iaas.MonitorDiskValue, error) {
+	defer c.metrics.observeAPIRequest("database", "MonitorDisk", time.Now())
 	mvs, err := c.client.MonitorDisk(ctx, zone, databaseID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -103,6 +106,7 @@ func (c *databaseClient) MonitorDisk(ctx context.Context, zone string, databaseI
 }
 
 func (c *databaseClient) MonitorNIC(ctx context.Context, zone string, databaseID types.ID, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("database", "MonitorNIC", time.Now())
 	mvs, err := c.client.MonitorInterface(ctx, zone, databaseID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -16,6 +16,7 @@ package platform
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacloud/iaas-api-go"
 	"github.com/sacloud/iaas-api-go/types"
@@ -26,17 +27,20 @@ type ESMEClient interface {
 	Logs(ctx context.Context, esmeID types.ID) ([]*iaas.ESMELogs, error)
 }
 
-func getESMEClient(caller iaas.APICaller) ESMEClient {
+func getESMEClient(caller iaas.APICaller, metrics *Metrics) ESMEClient {
 	return &esmeClient{
-		caller: caller,
+		caller:  caller,
+		metrics: metrics,
 	}
 }
 
 type esmeClient struct {
-	caller iaas.APICaller
+	caller  iaas.APICaller
+	metrics *Metrics
 }
 
 func (c *esmeClient) Find(ctx context.Context) ([]*iaas.ESME, error) {
+	defer c.metrics.observeAPIRequest("esme", "Find", time.Now())
 	client := iaas.NewESMEOp(c.caller)
 	searched, err := client.Find(ctx, &iaas.FindCondition{})
 	if err != nil {
@@ -46,6 +50,7 @@ func (c *esmeClient) Find(ctx context.Context) ([]*iaas.ESME, error) {
 }
 
 func (c *esmeClient) Logs(ctx context.Context, esmeID types.ID) ([]*iaas.ESMELogs, error) {
+	defer c.metrics.observeAPIRequest("esme", "Logs", time.Now())
 	client := iaas.NewESMEOp(c.caller)
 	return client.Logs(ctx, esmeID)
 }
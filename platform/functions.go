@@ -16,48 +16,48 @@ package platform
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"time"
 )
 
 type perZoneQueryFunc func(ctx context.Context, zone string) ([]interface{}, error)
 
-func queryToZones(ctx context.Context, zones []string, query perZoneQueryFunc) ([]interface{}, error) {
+// queryToZones calls query for each zone in parallel.
+//
+// Even if the query fails in some zones, the results from other zones are returned
+// together with the joined errors. The status of each zone is recorded to ZoneScrapeFailed of metrics,
+// and the latency of each zone is recorded to ZoneAPIDuration of metrics.
+func queryToZones(ctx context.Context, metrics *Metrics, collector string, zones []string, query perZoneQueryFunc) ([]interface{}, error) {
 	var wg sync.WaitGroup
 	wg.Add(len(zones))
 
-	type result struct {
-		results []interface{}
-		err     error
-	}
-
-	resCh := make(chan *result)
-	defer close(resCh)
+	var mu sync.Mutex
+	var results []interface{}
+	var errs []error
 
 	for i := range zones {
 		go func(zone string) {
+			defer wg.Done()
+
+			start := time.Now()
 			res, err := query(ctx, zone)
-			resCh <- &result{
-				results: res,
-				err:     err,
+			metrics.ZoneAPIDuration.WithLabelValues(zone, collector).Observe(time.Since(start).Seconds())
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				metrics.ZoneScrapeFailed.WithLabelValues(zone, collector).Set(1)
+				errs = append(errs, fmt.Errorf("zone %s: %w", zone, err))
+				return
 			}
+			metrics.ZoneScrapeFailed.WithLabelValues(zone, collector).Set(0)
+			results = append(results, res...)
 		}(zones[i])
 	}
 
-	var results []interface{}
-	var err error
-	go func() {
-		for res := range resCh {
-			if err == nil {
-				if res.err != nil {
-					err = res.err
-				} else {
-					results = append(results, res.results...)
-				}
-			}
-			wg.Done()
-		}
-	}()
-
 	wg.Wait()
-	return results, err
+	return results, errors.Join(errs...)
 }
@@ -16,8 +16,12 @@ package platform
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sacloud/iaas-api-go"
 	"github.com/stretchr/testify/require"
 )
@@ -53,7 +57,47 @@ func TestFunctions_queryPerZone(t *testing.T) {
 		return results, nil
 	}
 
-	results, err := queryToZones(context.Background(), []string{"is1a", "is1b"}, findFunc)
+	results, err := queryToZones(context.Background(), newMetrics(), "server", []string{"is1a", "is1b"}, findFunc)
 	require.NoError(t, err)
 	require.Len(t, results, 2)
 }
+
+func TestFunctions_queryPerZoneWithFailedZone(t *testing.T) {
+	findFunc := func(ctx context.Context, zone string) ([]interface{}, error) {
+		if zone == "tk1a" {
+			return nil, errors.New("dummy")
+		}
+		return []interface{}{zone}, nil
+	}
+
+	metrics := newMetrics()
+	results, err := queryToZones(context.Background(), metrics, "test", []string{"is1a", "is1b", "tk1a"}, findFunc)
+	require.Error(t, err)
+	require.ElementsMatch(t, []interface{}{"is1a", "is1b"}, results)
+
+	require.Equal(t, float64(0), testutil.ToFloat64(metrics.ZoneScrapeFailed.WithLabelValues("is1a", "test")))
+	require.Equal(t, float64(0), testutil.ToFloat64(metrics.ZoneScrapeFailed.WithLabelValues("is1b", "test")))
+	require.Equal(t, float64(1), testutil.ToFloat64(metrics.ZoneScrapeFailed.WithLabelValues("tk1a", "test")))
+}
+
+func TestFunctions_queryPerZoneObservesDuration(t *testing.T) {
+	findFunc := func(ctx context.Context, zone string) ([]interface{}, error) {
+		if zone == "is1b" {
+			return nil, errors.New("dummy")
+		}
+		return []interface{}{zone}, nil
+	}
+
+	metrics := newMetrics()
+	for i := 0; i < 2; i++ {
+		_, err := queryToZones(context.Background(), metrics, "test_duration", []string{"is1a", "is1b"}, findFunc)
+		require.Error(t, err)
+	}
+
+	// failed queries are also observed
+	for _, zone := range []string{"is1a", "is1b"} {
+		m := &dto.Metric{}
+		require.NoError(t, metrics.ZoneAPIDuration.WithLabelValues(zone, "test_duration").(prometheus.Metric).Write(m))
+		require.Equal(t, uint64(2), m.GetHistogram().GetSampleCount(), zone)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+type GSLBClient interface {
+	Find(ctx context.Context) ([]*iaas.GSLB, error)
+}
+
+func getGSLBClient(caller iaas.APICaller, metrics *Metrics) GSLBClient {
+	return &gslbClient{
+		client:  iaas.NewGSLBOp(caller),
+		metrics: metrics,
+	}
+}
+
+type gslbClient struct {
+	client  iaas.GSLBAPI
+	metrics *Metrics
+}
+
+func (c *gslbClient) Find(ctx context.Context) ([]*iaas.GSLB, error) {
+	defer c.metrics.observeAPIRequest("gslb", "Find", time.Now())
+	var results []*iaas.GSLB
+	res, err := c.client.Find(ctx, &iaas.FindCondition{
+		Count: 10000,
+	})
+	if err != nil {
+		return results, err
+	}
+	return res.GSLBs, nil
+}
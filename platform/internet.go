@@ -32,16 +32,18 @@ type InternetClient interface {
 	MonitorTraffic(ctx context.Context, zone string, internetID types.ID, end time.Time) (*iaas.MonitorRouterValue, error)
 }
 
-func getInternetClient(caller iaas.APICaller, zones []string) InternetClient {
+func getInternetClient(caller iaas.APICaller, zones []string, metrics *Metrics) InternetClient {
 	return &internetClient{
-		client: iaas.NewInternetOp(caller),
-		zones:  zones,
+		client:  iaas.NewInternetOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type internetClient struct {
-	client iaas.InternetAPI
-	zones  []string
+	client  iaas.InternetAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *internetClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -62,18 +64,17 @@ func (c *internetClient) find(ctx context.Context, zone string) ([]interface{},
 }
 
 func (c *internetClient) Find(ctx context.Context) ([]*Internet, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("internet", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "internet", c.zones, c.find)
 	var results []*Internet
 	for _, s := range res {
 		results = append(results, s.(*Internet))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *internetClient) MonitorTraffic(ctx context.Context, zone string, internetID types.ID, end time.Time) (*iaas.MonitorRouterValue, error) {
+	defer c.metrics.observeAPIRequest("internet", "MonitorTraffic", time.Now())
 	mvs, err := c.client.Monitor(ctx, zone, internetID, monitorCondition(end))
 	if err != nil {
 		return nil, err
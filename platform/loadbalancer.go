@@ -35,16 +35,18 @@ type LoadBalancerClient interface {
 	MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error)
 }
 
-func getLoadBalancerClient(caller iaas.APICaller, zones []string) LoadBalancerClient {
+func getLoadBalancerClient(caller iaas.APICaller, zones []string, metrics *Metrics) LoadBalancerClient {
 	return &loadBalancerClient{
-		client: iaas.NewLoadBalancerOp(caller),
-		zones:  zones,
+		client:  iaas.NewLoadBalancerOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type loadBalancerClient struct {
-	client iaas.LoadBalancerAPI
-	zones  []string
+	client  iaas.LoadBalancerAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *loadBalancerClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -65,18 +67,17 @@ func (c *loadBalancerClient) find(ctx context.Context, zone string) ([]interface
 }
 
 func (c *loadBalancerClient) Find(ctx context.Context) ([]*LoadBalancer, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("loadbalancer", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "loadbalancer", c.zones, c.find)
 	var results []*LoadBalancer
 	for _, s := range res {
 		results = append(results, s.(*LoadBalancer))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *loadBalancerClient) MonitorNIC(ctx context.Context, zone string, id types.ID, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("loadbalancer", "MonitorNIC", time.Now())
 	mvs, err := c.client.MonitorInterface(ctx, zone, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -85,6 +86,7 @@ func (c *loadBalancerClient) MonitorNIC(ctx context.Context, zone string, id typ
 }
 
 func (c *loadBalancerClient) Status(ctx context.Context, zone string, id types.ID) ([]*iaas.LoadBalancerStatus, error) {
+	defer c.metrics.observeAPIRequest("loadbalancer", "Status", time.Now())
 	res, err := c.client.Status(ctx, zone, id)
 	if err != nil {
 		return nil, err
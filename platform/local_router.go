@@ -28,17 +28,20 @@ type LocalRouterClient interface {
 	Monitor(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorLocalRouterValue, error)
 }
 
-func getLocalRouterClient(caller iaas.APICaller) LocalRouterClient {
+func getLocalRouterClient(caller iaas.APICaller, metrics *Metrics) LocalRouterClient {
 	return &localRouterClient{
-		client: iaas.NewLocalRouterOp(caller),
+		client:  iaas.NewLocalRouterOp(caller),
+		metrics: metrics,
 	}
 }
 
 type localRouterClient struct {
-	client iaas.LocalRouterAPI
+	client  iaas.LocalRouterAPI
+	metrics *Metrics
 }
 
 func (c *localRouterClient) Find(ctx context.Context) ([]*iaas.LocalRouter, error) {
+	defer c.metrics.observeAPIRequest("local_router", "Find", time.Now())
 	var results []*iaas.LocalRouter
 	res, err := c.client.Find(ctx, &iaas.FindCondition{
 		Count: 10000,
@@ -50,10 +53,12 @@ func (c *localRouterClient) Find(ctx context.Context) ([]*iaas.LocalRouter, erro
 }
 
 func (c *localRouterClient) Health(ctx context.Context, id types.ID) (*iaas.LocalRouterHealth, error) {
+	defer c.metrics.observeAPIRequest("local_router", "Health", time.Now())
 	return c.client.HealthStatus(ctx, id)
 }
 
 func (c *localRouterClient) Monitor(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorLocalRouterValue, error) {
+	defer c.metrics.observeAPIRequest("local_router", "Monitor", time.Now())
 	mvs, err := c.client.MonitorLocalRouter(ctx, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
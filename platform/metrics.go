@@ -0,0 +1,90 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics are the self-metrics of the API calls issued by a Client.
+//
+// Each Client has its own Metrics, so the metrics of the primary and the secondary API
+// are distinguished by the labels of the registerer they are registered to.
+type Metrics struct {
+	// APIRequestDuration records the latency of SakuraCloud API calls issued by the platform clients
+	APIRequestDuration *prometheus.HistogramVec
+	// ZoneScrapeFailed indicates whether the last query to each zone was failed
+	ZoneScrapeFailed *prometheus.GaugeVec
+	// ZoneAPIDuration records the latency of the per-zone queries to detect slowness of a specific zone
+	ZoneAPIDuration *prometheus.HistogramVec
+	// RateLimitWaitSeconds accumulates the time spent blocked by the client-side rate limiter of SakuraCloud API requests
+	RateLimitWaitSeconds prometheus.Counter
+	// APIPermission indicates the permissions for the external services the API key holds, set by SetAPIPermissions at startup
+	APIPermission *prometheus.GaugeVec
+}
+
+func newMetrics() *Metrics {
+	return &Metrics{
+		APIRequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sakuracloud_exporter_api_request_duration_seconds",
+			Help:    "Duration of SakuraCloud API requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"collector", "operation"}),
+		ZoneScrapeFailed: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_zone_scrape_failed",
+			Help: "If 1 the last query to the zone was failed, 0 otherwise",
+		}, []string{"zone", "collector"}),
+		ZoneAPIDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "sakuracloud_exporter_zone_api_duration_seconds",
+			Help:    "Duration of SakuraCloud API requests per zone in seconds",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"zone", "collector"}),
+		RateLimitWaitSeconds: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "sakuracloud_exporter_rate_limit_wait_seconds_total",
+			Help: "Total time spent waiting for the client-side rate limiter of SakuraCloud API requests in seconds",
+		}),
+		APIPermission: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "sakuracloud_exporter_api_permission",
+			Help: "A metric with a constant '1' value for each permission the API key holds",
+		}, []string{"permission"}),
+	}
+}
+
+// Describe sends the descriptors of the metrics to the prometheus desc channel.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.APIRequestDuration.Describe(ch)
+	m.ZoneScrapeFailed.Describe(ch)
+	m.ZoneAPIDuration.Describe(ch)
+	m.RateLimitWaitSeconds.Describe(ch)
+	m.APIPermission.Describe(ch)
+}
+
+// Collect sends the metrics to the prometheus metric channel.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.APIRequestDuration.Collect(ch)
+	m.ZoneScrapeFailed.Collect(ch)
+	m.ZoneAPIDuration.Collect(ch)
+	m.RateLimitWaitSeconds.Collect(ch)
+	m.APIPermission.Collect(ch)
+}
+
+// observeAPIRequest records the elapsed time since start to APIRequestDuration
+//
+// Use with defer at the beginning of the API call: defer c.metrics.observeAPIRequest("server", "Find", time.Now())
+func (m *Metrics) observeAPIRequest(collector, operation string, start time.Time) {
+	m.APIRequestDuration.WithLabelValues(collector, operation).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,42 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics_observeAPIRequest(t *testing.T) {
+	metrics := newMetrics()
+	sampleCount := func() uint64 {
+		m := &dto.Metric{}
+		err := metrics.APIRequestDuration.WithLabelValues("zone", "Find").(prometheus.Histogram).Write(m)
+		require.NoError(t, err)
+		return m.Histogram.GetSampleCount()
+	}
+
+	before := sampleCount()
+
+	client := getZoneClient(testCaller, metrics)
+	_, err := client.Find(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, before+1, sampleCount())
+}
@@ -32,20 +32,23 @@ type MobileGatewayClient interface {
 	Find(ctx context.Context) ([]*MobileGateway, error)
 	TrafficStatus(ctx context.Context, zone string, id types.ID) (*iaas.MobileGatewayTrafficStatus, error)
 	TrafficControl(ctx context.Context, zone string, id types.ID) (*iaas.MobileGatewayTrafficControl, error)
+	ListSIM(ctx context.Context, zone string, id types.ID) (iaas.MobileGatewaySIMs, error)
 	MonitorNIC(ctx context.Context, zone string, id types.ID, index int, end time.Time) (*iaas.MonitorInterfaceValue, error)
 	MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error)
 }
 
-func getMobileGatewayClient(caller iaas.APICaller, zones []string) MobileGatewayClient {
+func getMobileGatewayClient(caller iaas.APICaller, zones []string, metrics *Metrics) MobileGatewayClient {
 	return &mobileGatewayClient{
-		client: iaas.NewMobileGatewayOp(caller),
-		zones:  zones,
+		client:  iaas.NewMobileGatewayOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type mobileGatewayClient struct {
-	client iaas.MobileGatewayAPI
-	zones  []string
+	client  iaas.MobileGatewayAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *mobileGatewayClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -66,18 +69,17 @@ func (c *mobileGatewayClient) find(ctx context.Context, zone string) ([]interfac
 }
 
 func (c *mobileGatewayClient) Find(ctx context.Context) ([]*MobileGateway, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("mobile_gateway", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "mobile_gateway", c.zones, c.find)
 	var results []*MobileGateway
 	for _, s := range res {
 		results = append(results, s.(*MobileGateway))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *mobileGatewayClient) MonitorNIC(ctx context.Context, zone string, id types.ID, index int, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("mobile_gateway", "MonitorNIC", time.Now())
 	mvs, err := c.client.MonitorInterface(ctx, zone, id, index, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -86,13 +88,20 @@ func (c *mobileGatewayClient) MonitorNIC(ctx context.Context, zone string, id ty
 }
 
 func (c *mobileGatewayClient) TrafficStatus(ctx context.Context, zone string, id types.ID) (*iaas.MobileGatewayTrafficStatus, error) {
+	defer c.metrics.observeAPIRequest("mobile_gateway", "TrafficStatus", time.Now())
 	return c.client.TrafficStatus(ctx, zone, id)
 }
 
 func (c *mobileGatewayClient) TrafficControl(ctx context.Context, zone string, id types.ID) (*iaas.MobileGatewayTrafficControl, error) {
+	defer c.metrics.observeAPIRequest("mobile_gateway", "TrafficControl", time.Now())
 	return c.client.GetTrafficConfig(ctx, zone, id)
 }
 
+func (c *mobileGatewayClient) ListSIM(ctx context.Context, zone string, id types.ID) (iaas.MobileGatewaySIMs, error) {
+	defer c.metrics.observeAPIRequest("mobile_gateway", "ListSIM", time.Now())
+	return c.client.ListSIM(ctx, zone, id)
+}
+
 func (c *mobileGatewayClient) MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error) {
 	return newsfeed.GetByURL(infoURL)
 }
@@ -110,3 +110,12 @@ func monitorLocalRouterValue(values []*iaas.MonitorLocalRouterValue) *iaas.Monit
 	}
 	return nil
 }
+
+func monitorResponseTimeSecValue(values []*iaas.MonitorResponseTimeSecValue) *iaas.MonitorResponseTimeSecValue {
+	if len(values) > 1 {
+		// Descending
+		sort.Slice(values, func(i, j int) bool { return values[i].Time.After(values[j].Time) })
+		return values[1]
+	}
+	return nil
+}
@@ -38,18 +38,20 @@ type NFSClient interface {
 	MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error)
 }
 
-func getNFSClient(caller iaas.APICaller, zones []string) NFSClient {
+func getNFSClient(caller iaas.APICaller, zones []string, metrics *Metrics) NFSClient {
 	return &nfsClient{
-		noteOp: iaas.NewNoteOp(caller),
-		nfsOp:  iaas.NewNFSOp(caller),
-		zones:  zones,
+		noteOp:  iaas.NewNoteOp(caller),
+		nfsOp:   iaas.NewNFSOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type nfsClient struct {
-	noteOp iaas.NoteAPI
-	nfsOp  iaas.NFSAPI
-	zones  []string
+	noteOp  iaas.NoteAPI
+	nfsOp   iaas.NFSAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *nfsClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -83,18 +85,17 @@ func (c *nfsClient) find(ctx context.Context, zone string) ([]interface{}, error
 }
 
 func (c *nfsClient) Find(ctx context.Context) ([]*NFS, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("nfs", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "nfs", c.zones, c.find)
 	var results []*NFS
 	for _, s := range res {
 		results = append(results, s.(*NFS))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *nfsClient) MonitorFreeDiskSize(ctx context.Context, zone string, id types.ID, end time.Time) (*iaas.MonitorFreeDiskSizeValue, error) {
+	defer c.metrics.observeAPIRequest("nfs", "MonitorFreeDiskSize", time.Now())
 	mvs, err := c.nfsOp.MonitorFreeDiskSize(ctx, zone, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -103,6 +104,7 @@ func (c *nfsClient) MonitorFreeDiskSize(ctx context.Context, zone string, id typ
 }
 
 func (c *nfsClient) MonitorNIC(ctx context.Context, zone string, id types.ID, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("nfs", "MonitorNIC", time.Now())
 	mvs, err := c.nfsOp.MonitorInterface(ctx, zone, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -28,17 +28,20 @@ type ProxyLBClient interface {
 	Monitor(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorConnectionValue, error)
 }
 
-func getProxyLBClient(caller iaas.APICaller) ProxyLBClient {
+func getProxyLBClient(caller iaas.APICaller, metrics *Metrics) ProxyLBClient {
 	return &proxyLBClient{
-		client: iaas.NewProxyLBOp(caller),
+		client:  iaas.NewProxyLBOp(caller),
+		metrics: metrics,
 	}
 }
 
 type proxyLBClient struct {
-	client iaas.ProxyLBAPI
+	client  iaas.ProxyLBAPI
+	metrics *Metrics
 }
 
 func (c *proxyLBClient) Find(ctx context.Context) ([]*iaas.ProxyLB, error) {
+	defer c.metrics.observeAPIRequest("proxylb", "Find", time.Now())
 	var results []*iaas.ProxyLB
 	res, err := c.client.Find(ctx, &iaas.FindCondition{
 		Count: 10000,
@@ -50,10 +53,12 @@ func (c *proxyLBClient) Find(ctx context.Context) ([]*iaas.ProxyLB, error) {
 }
 
 func (c *proxyLBClient) GetCertificate(ctx context.Context, id types.ID) (*iaas.ProxyLBCertificates, error) {
+	defer c.metrics.observeAPIRequest("proxylb", "GetCertificate", time.Now())
 	return c.client.GetCertificates(ctx, id)
 }
 
 func (c *proxyLBClient) Monitor(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorConnectionValue, error) {
+	defer c.metrics.observeAPIRequest("proxylb", "Monitor", time.Now())
 	mvs, err := c.client.MonitorConnection(ctx, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
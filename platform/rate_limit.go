@@ -0,0 +1,61 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/ratelimit"
+)
+
+// sdkRateLimitDisabled is passed to the SDK as HttpRequestRateLimit.
+//
+// The SDK always wraps the transport with its own rate limiter and doesn't expose the wait time.
+// So the exporter applies the rate limit by rateLimitRoundTripper, and makes the SDK's limiter non-blocking.
+const sdkRateLimitDisabled = math.MaxInt32
+
+// newRateLimitedHTTPClient returns the http.Client which limits requests to rateLimit per second
+func newRateLimitedHTTPClient(transport http.RoundTripper, rateLimit int, waitSeconds prometheus.Counter) *http.Client {
+	return &http.Client{
+		Transport: newRateLimitRoundTripper(transport, rateLimit, waitSeconds),
+	}
+}
+
+// rateLimitRoundTripper is a http.RoundTripper which limits requests per second
+// and records the time spent waiting for the limiter
+type rateLimitRoundTripper struct {
+	transport   http.RoundTripper
+	limiter     ratelimit.Limiter
+	waitSeconds prometheus.Counter
+}
+
+func newRateLimitRoundTripper(transport http.RoundTripper, rateLimit int, waitSeconds prometheus.Counter) *rateLimitRoundTripper {
+	return &rateLimitRoundTripper{
+		transport:   transport,
+		limiter:     ratelimit.New(rateLimit),
+		waitSeconds: waitSeconds,
+	}
+}
+
+func (r *rateLimitRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	r.limiter.Take()
+	r.waitSeconds.Add(time.Since(start).Seconds())
+
+	return r.transport.RoundTrip(req)
+}
@@ -0,0 +1,52 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type dummyRoundTripper struct{}
+
+func (d *dummyRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	rec.WriteHeader(http.StatusOK)
+	return rec.Result(), nil
+}
+
+func TestRateLimitRoundTripper(t *testing.T) {
+	waitSeconds := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_rate_limit_wait_seconds_total"})
+	rt := newRateLimitRoundTripper(&dummyRoundTripper{}, 10, waitSeconds)
+
+	// the first request isn't throttled
+	res, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	before := testutil.ToFloat64(waitSeconds)
+	require.Less(t, before, 0.05)
+
+	// subsequent requests are throttled to 10 per second
+	for i := 0; i < 3; i++ {
+		_, err := rt.RoundTrip(httptest.NewRequest(http.MethodGet, "/", nil))
+		require.NoError(t, err)
+	}
+	require.Greater(t, testutil.ToFloat64(waitSeconds)-before, 0.2)
+}
@@ -0,0 +1,72 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+)
+
+type requestIDKey struct{}
+
+// NewRequestID generates a new correlation ID(UUID version 4) sent with the API requests.
+func NewRequestID() (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40 // version 4
+	b[8] = (b[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16]), nil
+}
+
+// WithRequestID returns a copy of ctx with the correlation ID.
+// The API requests issued with the returned context are sent with the ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the correlation ID of ctx, or empty if ctx has no ID.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestIDRoundTripper is a http.RoundTripper which sets the correlation ID of the request context to the header of requests
+type requestIDRoundTripper struct {
+	transport http.RoundTripper
+	header    string
+}
+
+func newRequestIDRoundTripper(transport http.RoundTripper, header string) *requestIDRoundTripper {
+	return &requestIDRoundTripper{
+		transport: transport,
+		header:    header,
+	}
+}
+
+func (r *requestIDRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := RequestIDFromContext(req.Context())
+	if id == "" {
+		return r.transport.RoundTrip(req)
+	}
+	// RoundTripper must not modify the original request
+	req = req.Clone(req.Context())
+	req.Header.Set(r.header, id)
+	return r.transport.RoundTrip(req)
+}
@@ -0,0 +1,62 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDRoundTripper(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.Header.Get("X-Request-ID"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRequestIDRoundTripper(http.DefaultTransport, "X-Request-ID")}
+	get := func(ctx context.Context) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		res.Body.Close()
+	}
+
+	first, err := NewRequestID()
+	require.NoError(t, err)
+	require.Regexp(t, regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), first)
+
+	second, err := NewRequestID()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	// the requests of concurrent scrapes are sent with the ID of their own context
+	firstCtx := WithRequestID(context.Background(), first)
+	secondCtx := WithRequestID(context.Background(), second)
+	get(firstCtx)
+	get(secondCtx)
+	get(firstCtx)
+
+	// no header without the ID
+	get(context.Background())
+
+	require.Equal(t, []string{first, second, first, ""}, requested)
+}
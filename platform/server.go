@@ -37,12 +37,13 @@ type Server struct {
 	ZoneName string
 }
 
-func getServerClient(caller iaas.APICaller, zones []string) ServerClient {
+func getServerClient(caller iaas.APICaller, zones []string, metrics *Metrics) ServerClient {
 	return &serverClient{
 		serverOp:    iaas.NewServerOp(caller),
 		diskOp:      iaas.NewDiskOp(caller),
 		interfaceOp: iaas.NewInterfaceOp(caller),
 		zones:       zones,
+		metrics:     metrics,
 	}
 }
 
@@ -51,6 +52,7 @@ type serverClient struct {
 	diskOp      iaas.DiskAPI
 	interfaceOp iaas.InterfaceAPI
 	zones       []string
+	metrics     *Metrics
 }
 
 func (c *serverClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -71,22 +73,22 @@ func (c *serverClient) find(ctx context.Context, zone string) ([]interface{}, er
 }
 
 func (c *serverClient) Find(ctx context.Context) ([]*Server, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("server", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "server", c.zones, c.find)
 	var results []*Server
 	for _, s := range res {
 		results = append(results, s.(*Server))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *serverClient) ReadDisk(ctx context.Context, zone string, diskID types.ID) (*iaas.Disk, error) {
+	defer c.metrics.observeAPIRequest("server", "ReadDisk", time.Now())
 	return c.diskOp.Read(ctx, zone, diskID)
 }
 
 func (c *serverClient) MonitorCPU(ctx context.Context, zone string, id types.ID, end time.Time) (*iaas.MonitorCPUTimeValue, error) {
+	defer c.metrics.observeAPIRequest("server", "MonitorCPU", time.Now())
 	mvs, err := c.serverOp.Monitor(ctx, zone, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -95,6 +97,7 @@ func (c *serverClient) MonitorCPU(ctx context.Context, zone string, id types.ID,
 }
 
 func (c *serverClient) MonitorDisk(ctx context.Context, zone string, diskID types.ID, end time.Time) (*iaas.MonitorDiskValue, error) {
+	defer c.metrics.observeAPIRequest("server", "MonitorDisk", time.Now())
 	mvs, err := c.diskOp.Monitor(ctx, zone, diskID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -103,6 +106,7 @@ func (c *serverClient) MonitorDisk(ctx context.Context, zone string, diskID type
 }
 
 func (c *serverClient) MonitorNIC(ctx context.Context, zone string, nicID types.ID, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("server", "MonitorNIC", time.Now())
 	mvs, err := c.interfaceOp.Monitor(ctx, zone, nicID, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -0,0 +1,48 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerClient_FindWithCustomZones(t *testing.T) {
+	ctx := context.Background()
+	extraZone := "tk2a" // a zone which isn't included in the default zones
+
+	created, err := iaas.NewServerOp(testCaller).Create(ctx, extraZone, &iaas.ServerCreateRequest{
+		Name:     "server-on-extra-zone",
+		CPU:      1,
+		MemoryMB: 1024,
+	})
+	require.NoError(t, err)
+
+	client := getServerClient(testCaller, []string{"is1a", extraZone}, newMetrics())
+	servers, err := client.Find(ctx)
+	require.NoError(t, err)
+
+	var found *Server
+	for _, s := range servers {
+		if s.ID == created.ID {
+			found = s
+		}
+	}
+	require.NotNil(t, found)
+	require.Equal(t, extraZone, found.ZoneName)
+}
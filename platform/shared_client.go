@@ -0,0 +1,91 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+)
+
+// sharedFindTTL is the period in which the result of Find is shared.
+// This is shorter than the usual scrape interval, so only the collectors in the same scrape share the result.
+const sharedFindTTL = 5 * time.Second
+
+// NewSharedServerClient returns a ServerClient that shares the result of Find among the collectors calling it.
+// Other methods are passed through to the client.
+func NewSharedServerClient(client ServerClient) ServerClient {
+	return &sharedServerClient{
+		ServerClient: client,
+		cache:        newCache(sharedFindTTL),
+	}
+}
+
+type sharedServerClient struct {
+	ServerClient
+	mu    sync.Mutex
+	cache *cache
+}
+
+func (c *sharedServerClient) Find(ctx context.Context) ([]*Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ca := c.cache.get(); ca != nil {
+		return ca.([]*Server), nil
+	}
+	servers, err := c.ServerClient.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.set(servers, time.Now().Add(sharedFindTTL)); err != nil {
+		return nil, err
+	}
+	return servers, nil
+}
+
+// NewSharedAutoBackupClient returns an AutoBackupClient that shares the result of Find among the collectors calling it.
+// Other methods are passed through to the client.
+func NewSharedAutoBackupClient(client AutoBackupClient) AutoBackupClient {
+	return &sharedAutoBackupClient{
+		AutoBackupClient: client,
+		cache:            newCache(sharedFindTTL),
+	}
+}
+
+type sharedAutoBackupClient struct {
+	AutoBackupClient
+	mu    sync.Mutex
+	cache *cache
+}
+
+func (c *sharedAutoBackupClient) Find(ctx context.Context) ([]*iaas.AutoBackup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if ca := c.cache.get(); ca != nil {
+		return ca.([]*iaas.AutoBackup), nil
+	}
+	autoBackups, err := c.AutoBackupClient.Find(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cache.set(autoBackups, time.Now().Add(sharedFindTTL)); err != nil {
+		return nil, err
+	}
+	return autoBackups, nil
+}
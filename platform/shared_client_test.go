@@ -0,0 +1,96 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/stretchr/testify/require"
+)
+
+type countingServerClient struct {
+	ServerClient
+	mu    sync.Mutex
+	count int
+	err   error
+}
+
+func (c *countingServerClient) Find(ctx context.Context) ([]*Server, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.count++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return []*Server{{ZoneName: "is1a", Server: &iaas.Server{ID: 101}}}, nil
+}
+
+type countingAutoBackupClient struct {
+	AutoBackupClient
+	count int
+}
+
+func (c *countingAutoBackupClient) Find(ctx context.Context) ([]*iaas.AutoBackup, error) {
+	c.count++
+	return nil, nil
+}
+
+func TestSharedServerClient_Find(t *testing.T) {
+	inner := &countingServerClient{}
+	client := NewSharedServerClient(inner)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			servers, err := client.Find(context.Background())
+			require.NoError(t, err)
+			require.Len(t, servers, 1)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, inner.count)
+}
+
+func TestSharedServerClient_FindError(t *testing.T) {
+	inner := &countingServerClient{err: errors.New("dummy")}
+	client := NewSharedServerClient(inner)
+
+	// errors are not shared
+	for i := 0; i < 2; i++ {
+		_, err := client.Find(context.Background())
+		require.Error(t, err)
+	}
+	require.Equal(t, 2, inner.count)
+}
+
+func TestSharedAutoBackupClient_Find(t *testing.T) {
+	inner := &countingAutoBackupClient{}
+	client := NewSharedAutoBackupClient(inner)
+
+	// an empty result is shared as well
+	for i := 0; i < 2; i++ {
+		autoBackups, err := client.Find(context.Background())
+		require.NoError(t, err)
+		require.Empty(t, autoBackups)
+	}
+	require.Equal(t, 1, inner.count)
+}
@@ -26,19 +26,23 @@ type SIMClient interface {
 	Find(ctx context.Context) ([]*iaas.SIM, error)
 	GetNetworkOperatorConfig(ctx context.Context, id types.ID) ([]*iaas.SIMNetworkOperatorConfig, error)
 	MonitorTraffic(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorLinkValue, error)
+	Logs(ctx context.Context, id types.ID) ([]*iaas.SIMLog, error)
 }
 
-func getSIMClient(caller iaas.APICaller) SIMClient {
+func getSIMClient(caller iaas.APICaller, metrics *Metrics) SIMClient {
 	return &simClient{
-		client: iaas.NewSIMOp(caller),
+		client:  iaas.NewSIMOp(caller),
+		metrics: metrics,
 	}
 }
 
 type simClient struct {
-	client iaas.SIMAPI
+	client  iaas.SIMAPI
+	metrics *Metrics
 }
 
 func (c *simClient) Find(ctx context.Context) ([]*iaas.SIM, error) {
+	defer c.metrics.observeAPIRequest("sim", "Find", time.Now())
 	var results []*iaas.SIM
 	res, err := c.client.Find(ctx, &iaas.FindCondition{
 		Include: []string{"*", "Status.sim"},
@@ -51,13 +55,24 @@ func (c *simClient) Find(ctx context.Context) ([]*iaas.SIM, error) {
 }
 
 func (c *simClient) GetNetworkOperatorConfig(ctx context.Context, id types.ID) ([]*iaas.SIMNetworkOperatorConfig, error) {
+	defer c.metrics.observeAPIRequest("sim", "GetNetworkOperatorConfig", time.Now())
 	return c.client.GetNetworkOperator(ctx, id)
 }
 
 func (c *simClient) MonitorTraffic(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorLinkValue, error) {
+	defer c.metrics.observeAPIRequest("sim", "MonitorTraffic", time.Now())
 	mvs, err := c.client.MonitorSIM(ctx, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
 	}
 	return monitorLinkValue(mvs.Values), nil
 }
+
+func (c *simClient) Logs(ctx context.Context, id types.ID) ([]*iaas.SIMLog, error) {
+	defer c.metrics.observeAPIRequest("sim", "Logs", time.Now())
+	res, err := c.client.Logs(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return res.Logs, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"context"
+	"time"
+
+	"github.com/sacloud/iaas-api-go"
+	"github.com/sacloud/iaas-api-go/types"
+)
+
+type SimpleMonitorClient interface {
+	Find(ctx context.Context) ([]*iaas.SimpleMonitor, error)
+	HealthStatus(ctx context.Context, id types.ID) (*iaas.SimpleMonitorHealthStatus, error)
+	MonitorResponseTime(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorResponseTimeSecValue, error)
+}
+
+func getSimpleMonitorClient(caller iaas.APICaller, metrics *Metrics) SimpleMonitorClient {
+	return &simpleMonitorClient{
+		client:  iaas.NewSimpleMonitorOp(caller),
+		metrics: metrics,
+	}
+}
+
+type simpleMonitorClient struct {
+	client  iaas.SimpleMonitorAPI
+	metrics *Metrics
+}
+
+func (c *simpleMonitorClient) Find(ctx context.Context) ([]*iaas.SimpleMonitor, error) {
+	defer c.metrics.observeAPIRequest("simple_monitor", "Find", time.Now())
+	var results []*iaas.SimpleMonitor
+	res, err := c.client.Find(ctx, &iaas.FindCondition{
+		Count: 10000,
+	})
+	if err != nil {
+		return results, err
+	}
+	return res.SimpleMonitors, nil
+}
+
+func (c *simpleMonitorClient) HealthStatus(ctx context.Context, id types.ID) (*iaas.SimpleMonitorHealthStatus, error) {
+	defer c.metrics.observeAPIRequest("simple_monitor", "HealthStatus", time.Now())
+	return c.client.HealthStatus(ctx, id)
+}
+
+func (c *simpleMonitorClient) MonitorResponseTime(ctx context.Context, id types.ID, end time.Time) (*iaas.MonitorResponseTimeSecValue, error) {
+	defer c.metrics.observeAPIRequest("simple_monitor", "MonitorResponseTime", time.Now())
+	mvs, err := c.client.MonitorResponseTime(ctx, id, monitorCondition(end))
+	if err != nil {
+		return nil, err
+	}
+	return monitorResponseTimeSecValue(mvs.Values), nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2019-2023 The sakuracloud_exporter Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package platform
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPITransport(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	caFile := filepath.Join(dir, "ca.pem")
+	err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600)
+	require.NoError(t, err)
+
+	t.Run("default transport", func(t *testing.T) {
+		transport, err := newAPITransport("")
+		require.NoError(t, err)
+		require.Equal(t, http.DefaultTransport, transport)
+	})
+
+	t.Run("custom CA", func(t *testing.T) {
+		transport, err := newAPITransport(caFile)
+		require.NoError(t, err)
+
+		tlsConfig := transport.(*http.Transport).TLSClientConfig
+		require.NotNil(t, tlsConfig)
+		require.NotNil(t, tlsConfig.RootCAs)
+
+		// the certificate of the test server is trusted via the custom CA pool
+		res, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		res.Body.Close()
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		invalidFile := filepath.Join(dir, "invalid.pem")
+		err := os.WriteFile(invalidFile, []byte("invalid"), 0600)
+		require.NoError(t, err)
+
+		_, err = newAPITransport(invalidFile)
+		require.Error(t, err)
+
+		_, err = newAPITransport(filepath.Join(dir, "not-exist.pem"))
+		require.Error(t, err)
+	})
+}
@@ -36,16 +36,18 @@ type VPCRouterClient interface {
 	MaintenanceInfo(infoURL string) (*newsfeed.FeedItem, error)
 }
 
-func getVPCRouterClient(caller iaas.APICaller, zones []string) VPCRouterClient {
+func getVPCRouterClient(caller iaas.APICaller, zones []string, metrics *Metrics) VPCRouterClient {
 	return &vpcRouterClient{
-		client: iaas.NewVPCRouterOp(caller),
-		zones:  zones,
+		client:  iaas.NewVPCRouterOp(caller),
+		zones:   zones,
+		metrics: metrics,
 	}
 }
 
 type vpcRouterClient struct {
-	client iaas.VPCRouterAPI
-	zones  []string
+	client  iaas.VPCRouterAPI
+	zones   []string
+	metrics *Metrics
 }
 
 func (c *vpcRouterClient) find(ctx context.Context, zone string) ([]interface{}, error) {
@@ -66,18 +68,17 @@ func (c *vpcRouterClient) find(ctx context.Context, zone string) ([]interface{},
 }
 
 func (c *vpcRouterClient) Find(ctx context.Context) ([]*VPCRouter, error) {
-	res, err := queryToZones(ctx, c.zones, c.find)
-	if err != nil {
-		return nil, err
-	}
+	defer c.metrics.observeAPIRequest("vpc_router", "Find", time.Now())
+	res, err := queryToZones(ctx, c.metrics, "vpc_router", c.zones, c.find)
 	var results []*VPCRouter
 	for _, s := range res {
 		results = append(results, s.(*VPCRouter))
 	}
-	return results, nil
+	return results, err
 }
 
 func (c *vpcRouterClient) MonitorNIC(ctx context.Context, zone string, id types.ID, index int, end time.Time) (*iaas.MonitorInterfaceValue, error) {
+	defer c.metrics.observeAPIRequest("vpc_router", "MonitorNIC", time.Now())
 	mvs, err := c.client.MonitorInterface(ctx, zone, id, index, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -86,6 +87,7 @@ func (c *vpcRouterClient) MonitorNIC(ctx context.Context, zone string, id types.
 }
 
 func (c *vpcRouterClient) MonitorCPU(ctx context.Context, zone string, id types.ID, end time.Time) (*iaas.MonitorCPUTimeValue, error) {
+	defer c.metrics.observeAPIRequest("vpc_router", "MonitorCPU", time.Now())
 	mvs, err := c.client.MonitorCPU(ctx, zone, id, monitorCondition(end))
 	if err != nil {
 		return nil, err
@@ -94,6 +96,7 @@ func (c *vpcRouterClient) MonitorCPU(ctx context.Context, zone string, id types.
 }
 
 func (c *vpcRouterClient) Status(ctx context.Context, zone string, id types.ID) (*iaas.VPCRouterStatus, error) {
+	defer c.metrics.observeAPIRequest("vpc_router", "Status", time.Now())
 	return c.client.Status(ctx, zone, id)
 }
 
@@ -16,6 +16,7 @@ package platform
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacloud/webaccel-api-go"
 )
@@ -26,17 +27,20 @@ type WebAccelClient interface {
 	Usage(ctx context.Context) (*webaccel.MonthlyUsageResults, error)
 }
 
-func getWebAccelClient(caller webaccel.APICaller) WebAccelClient {
+func getWebAccelClient(caller webaccel.APICaller, metrics *Metrics) WebAccelClient {
 	return &webAccelClient{
-		client: webaccel.NewOp(caller),
+		client:  webaccel.NewOp(caller),
+		metrics: metrics,
 	}
 }
 
 type webAccelClient struct {
-	client webaccel.API
+	client  webaccel.API
+	metrics *Metrics
 }
 
 func (c *webAccelClient) Find(ctx context.Context) ([]*webaccel.Site, error) {
+	defer c.metrics.observeAPIRequest("webaccel", "Find", time.Now())
 	res, err := c.client.List(ctx)
 	if err != nil {
 		return nil, err
@@ -45,5 +49,6 @@ func (c *webAccelClient) Find(ctx context.Context) ([]*webaccel.Site, error) {
 }
 
 func (c *webAccelClient) Usage(ctx context.Context) (*webaccel.MonthlyUsageResults, error) {
+	defer c.metrics.observeAPIRequest("webaccel", "Usage", time.Now())
 	return c.client.MonthlyUsage(ctx, "")
 }
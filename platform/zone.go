@@ -16,6 +16,7 @@ package platform
 
 import (
 	"context"
+	"time"
 
 	"github.com/sacloud/iaas-api-go"
 )
@@ -25,17 +26,20 @@ type ZoneClient interface {
 	Find(ctx context.Context) ([]*iaas.Zone, error)
 }
 
-func getZoneClient(caller iaas.APICaller) ZoneClient {
+func getZoneClient(caller iaas.APICaller, metrics *Metrics) ZoneClient {
 	return &zoneClient{
-		client: iaas.NewZoneOp(caller),
+		client:  iaas.NewZoneOp(caller),
+		metrics: metrics,
 	}
 }
 
 type zoneClient struct {
-	client iaas.ZoneAPI
+	client  iaas.ZoneAPI
+	metrics *Metrics
 }
 
 func (c *zoneClient) Find(ctx context.Context) ([]*iaas.Zone, error) {
+	defer c.metrics.observeAPIRequest("zone", "Find", time.Now())
 	res, err := c.client.Find(ctx, nil)
 	if err != nil {
 		return nil, err